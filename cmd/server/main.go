@@ -7,6 +7,8 @@ import (
 
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/config"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/game"
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/ledger"
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/poker"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/store"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/ws"
 	"github.com/gorilla/mux"
@@ -22,8 +24,20 @@ func main() {
 	// 1. Inicializa RedisStore con la dirección saneada
 	redisStore := store.NewRedisStore(redisAddr, cfg.RedisPass, cfg.RedisDB)
 
-	// 2. Crea el Hub
-	gameMgr := game.NewManager()
+	// 2. Crea el Hub con la fuente de barajado y el ledger configurados
+	deckSource, err := poker.NewDeckSource(cfg.DeckSource, cfg.DeckSeed)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.DeckSource != poker.DeckSourceCrypto {
+		log.Printf("⚠️ Deck source %q: solo para tests y QA", cfg.DeckSource)
+	}
+	ledgerBackend, err := ledger.NewBackend(cfg.LedgerBackend, redisAddr, cfg.RedisPass, cfg.RedisDB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("📒 Ledger backend: %s", cfg.LedgerBackend)
+	gameMgr := game.NewManagerWithLedger(deckSource, ledger.New(ledgerBackend))
 	hub := ws.NewHub(redisStore, gameMgr)
 
 	// 3. Configura el router
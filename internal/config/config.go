@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -10,6 +11,13 @@ type Config struct {
 	RedisDB   int
 	RedisPass string
 	HTTPPort  string
+
+	// Fuente de barajado: crypto (producción), seeded o scripted (QA)
+	DeckSource string
+	DeckSeed   int64
+
+	// Backend del ledger de fichas: redis (por defecto) o memory
+	LedgerBackend string
 }
 
 func Load() Config {
@@ -18,6 +26,11 @@ func Load() Config {
 		RedisDB:   0,
 		RedisPass: strings.TrimSpace(getEnv("REDIS_PASS", "")),
 		HTTPPort:  strings.TrimSpace(getEnv("HTTP_PORT", "8080")),
+
+		DeckSource: strings.TrimSpace(getEnv("DECK_SOURCE", "crypto")),
+		DeckSeed:   getEnvInt64("DECK_SEED", 0),
+
+		LedgerBackend: strings.TrimSpace(getEnv("LEDGER_BACKEND", "redis")),
 	}
 }
 
@@ -27,3 +40,11 @@ func getEnv(key, def string) string {
 	}
 	return def
 }
+
+func getEnvInt64(key string, def int64) int64 {
+	v, err := strconv.ParseInt(strings.TrimSpace(os.Getenv(key)), 10, 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
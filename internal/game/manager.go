@@ -2,9 +2,11 @@ package game
 
 import (
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/ledger"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/poker"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/tournament"
 )
@@ -19,7 +21,7 @@ type TableState struct {
 	Host      string   `json:"host"`
 	Players   []Player `json:"players"`
 	Pot       int      `json:"pot"`
-	TurnIndex int      `json:"turnIndex"`
+	TurnIndex int      `json:"turnIndex"` // Con poker engine es el asiento que actúa (índice en PokerTable.Players); sin él, índice en Players
 
 	// Nuevos campos para poker real
 	PokerTable *poker.PokerTable `json:"poker_table,omitempty"`
@@ -34,6 +36,22 @@ type Manager interface {
 
 	// Nuevos métodos para poker
 	PokerAction(tableID, playerName, action string, amount int) (*TableState, error)
+	DrawCards(tableID, playerName string, discards []int) (*TableState, error) // Descarte en variantes de draw
+	RequestStraddle(tableID, playerName string) (*TableState, error)           // Straddle para la próxima mano
+	VoteRunItTwice(tableID, playerName string, runs int) (*TableState, error)  // Veces a correr el board en un all-in
+	ShowCards(tableID, playerName string) (*TableState, error)                 // Mostrar las cartas al terminar la mano
+	GetHandHistory(tableID, playerName string) (string, error)                 // Manos jugadas en formato PokerStars
+	StackDeck(tableID string, cards []poker.Card) error                        // Deck programado para la próxima mano (QA)
+	SetClientSeed(tableID, playerName, seed string) (*TableState, error)       // Semilla propia para el barajado provably fair
+	UseTimeBank(tableID, playerName string) (*TableState, error)               // Extender el shot clock con el time bank
+	SitOut(tableID, playerName string) (*TableState, error)                    // Conservar el asiento sin recibir cartas
+	SitIn(tableID, playerName string) (*TableState, error)                     // Volver a jugar (paga los blinds perdidos)
+	LeaveTable(tableID, playerName string) (*TableState, *poker.CashOut, error) // Dejar la mesa (cash out nil si termina la mano en curso)
+	OnCashOut(handler func(cashOut poker.CashOut))                             // Aviso de cada jugador que deja una mesa con su stack
+	Rebuy(tableID, playerName string, amount int) (*TableState, error)         // Recompra o top-up (se acredita entre manos)
+	GetBalance(playerName string) (int, error)                                 // Saldo del jugador en el ledger (negativo = debe)
+	Settle(tableID string) (*ledger.Settlement, error)                         // Resultados de la sesión y pagos mínimos entre jugadores
+	OnTableUpdate(handler func(tableID string))                                // Aviso de cambios hechos por el engine (shot clock, auto-restart)
 	GetTableState(tableID string) (*TableState, error)
 	GetTableStateForPlayer(tableID, playerName string) (*TableState, error) // Nuevo: estado filtrado por jugador
 
@@ -45,6 +63,7 @@ type Manager interface {
 	// Métodos para torneos
 	CreateTournament(tournamentID, name string, buyIn int, tournamentType string) (*tournament.Tournament, error)
 	RegisterForTournament(tournamentID, playerID, playerName string) error
+	UnregisterFromTournament(tournamentID, playerID, playerName string) error
 	StartTournament(tournamentID string) error
 	GetTournament(tournamentID string) (*tournament.Tournament, error)
 	ListTournaments() map[string]*tournament.Tournament
@@ -56,6 +75,10 @@ type Manager interface {
 
 	// Métodos para configuración de buy-in
 	JoinWithBuyIn(tableID, playerName string, buyInAmount int) (*TableState, error)
+
+	// Métodos para asientos
+	JoinAtSeat(tableID, playerName string, seat, buyInAmount int) (*TableState, error) // Sentarse en un asiento elegido
+	ChangeSeat(tableID, playerName string, seat int) (*TableState, error)              // Cambiar de asiento en el lobby
 	GetTableConfig(tableID string) (*poker.TableConfig, error)
 	UpdateTableConfig(tableID string, config poker.TableConfig) error
 	ValidateBuyIn(tableID string, buyInAmount int) error
@@ -67,16 +90,33 @@ type managerImpl struct {
 	tables           map[string]*TableState
 	pokerEngine      *poker.PokerEngine
 	tournamentManager *tournament.Manager
+	ledger           *ledger.Ledger
 }
 
 // NewManager crea un Manager con poker engine
 func NewManager() Manager {
-	pokerEngine := poker.NewPokerEngine()
-	return &managerImpl{
+	return NewManagerWithDeckSource(poker.CryptoDeckSource{})
+}
+
+// NewManagerWithDeckSource crea un Manager cuyo engine baraja con la fuente dada
+func NewManagerWithDeckSource(source poker.DeckSource) Manager {
+	return NewManagerWithLedger(source, ledger.NewMemory())
+}
+
+// NewManagerWithLedger crea un Manager que registra buy-ins, recompras, cash outs,
+// entradas y premios de torneos en el ledger dado
+func NewManagerWithLedger(source poker.DeckSource, l *ledger.Ledger) Manager {
+	pokerEngine := poker.NewPokerEngineWithDeckSource(source)
+	m := &managerImpl{
 		tables:            make(map[string]*TableState),
 		pokerEngine:       pokerEngine,
 		tournamentManager: tournament.NewManager(pokerEngine),
+		ledger:            l,
 	}
+	pokerEngine.SetCashOutHandler(m.recordCashOut)
+	pokerEngine.SetRakeHandler(m.recordRake)
+	m.tournamentManager.SetFinishHandler(m.recordPrizes)
+	return m
 }
 
 func (m *managerImpl) Join(tableID, playerName string) *TableState {
@@ -111,14 +151,13 @@ func (m *managerImpl) Join(tableID, playerName string) *TableState {
 	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
 	pokerTable, err := m.pokerEngine.AddPlayer(tableID, playerID, playerName)
 	if err == nil {
+		m.recordBuyIn(pokerTable, playerID, playerName)
 		t.PokerTable = pokerTable
 		t.Phase = pokerTable.Phase
 		t.Pot = pokerTable.Pot
 
 		// Sincronizar TurnIndex con poker engine
-		if len(pokerTable.Players) > 0 {
-			t.TurnIndex = pokerTable.CurrentPlayer
-		}
+		t.TurnIndex = pokerTable.CurrentPlayer
 	}
 
 	return t
@@ -210,6 +249,431 @@ func (m *managerImpl) pokerActionInternal(tableID, playerName, action string, am
 	return t, nil
 }
 
+// DrawCards descarta y repone cartas en las rondas de descarte de las variantes de draw
+func (m *managerImpl) DrawCards(tableID, playerName string, discards []int) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.PlayerDraw(tableID, playerID, discards)
+	if err != nil {
+		return t, err
+	}
+
+	// Actualizar estado legacy
+	t.PokerTable = updatedTable
+	t.Phase = updatedTable.Phase
+	t.Pot = updatedTable.Pot
+	t.TurnIndex = updatedTable.CurrentPlayer
+
+	return t, nil
+}
+
+// RequestStraddle pide un straddle para la próxima mano (se aplica al repartir)
+func (m *managerImpl) RequestStraddle(tableID, playerName string) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.RequestStraddle(tableID, playerID)
+	if err != nil {
+		return t, err
+	}
+
+	t.PokerTable = updatedTable
+	return t, nil
+}
+
+// VoteRunItTwice registra cuántas veces quiere correr el board el jugador (1 = una sola vez)
+func (m *managerImpl) VoteRunItTwice(tableID, playerName string, runs int) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.VoteRunItTwice(tableID, playerID, runs)
+	if err != nil {
+		return t, err
+	}
+
+	// Actualizar estado legacy (si todos decidieron la mano ya terminó)
+	t.PokerTable = updatedTable
+	t.Phase = updatedTable.Phase
+	t.Pot = updatedTable.Pot
+	t.TurnIndex = updatedTable.CurrentPlayer
+
+	return t, nil
+}
+
+// ShowCards muestra las cartas del jugador a toda la mesa al terminar la mano
+func (m *managerImpl) ShowCards(tableID, playerName string) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.ShowCards(tableID, playerID)
+	if err != nil {
+		return t, err
+	}
+
+	t.PokerTable = updatedTable
+	t.Phase = updatedTable.Phase
+	t.Pot = updatedTable.Pot
+	t.TurnIndex = updatedTable.CurrentPlayer
+
+	return t, nil
+}
+
+// GetHandHistory exporta en formato PokerStars las manos de la mesa en las que jugó el jugador
+func (m *managerImpl) GetHandHistory(tableID, playerName string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tables[tableID]; !ok {
+		return "", fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	histories, err := m.pokerEngine.GetHandHistories(tableID)
+	if err != nil {
+		return "", err
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	played := make([]*poker.HandHistory, 0, len(histories))
+	for _, history := range histories {
+		if history.HasPlayer(playerID) {
+			played = append(played, history)
+		}
+	}
+
+	return poker.FormatHandHistories(played, playerID), nil
+}
+
+// StackDeck carga un deck programado que se reparte en la próxima mano que empiece.
+// Solo funciona si el servidor arrancó con la fuente de deck "scripted".
+func (m *managerImpl) StackDeck(tableID string, cards []poker.Card) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tables[tableID]; !ok {
+		return fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	return m.pokerEngine.StackDeck(tableID, cards)
+}
+
+// SetClientSeed guarda la semilla que el jugador aporta al barajado de las próximas manos
+func (m *managerImpl) SetClientSeed(tableID, playerName, seed string) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.SetClientSeed(tableID, playerID, seed)
+	if err != nil {
+		return t, err
+	}
+
+	t.PokerTable = updatedTable
+	return t, nil
+}
+
+// UseTimeBank extiende el plazo del jugador que tiene el turno con su time bank
+func (m *managerImpl) UseTimeBank(tableID, playerName string) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.UseTimeBank(tableID, playerID)
+	if err != nil {
+		return t, err
+	}
+
+	t.PokerTable = updatedTable
+	return t, nil
+}
+
+// SitOut sienta afuera al jugador conservando su asiento y su stack
+func (m *managerImpl) SitOut(tableID, playerName string) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.SitOut(tableID, playerID)
+	if err != nil {
+		return t, err
+	}
+
+	// Si le tocaba actuar, el engine ya pasó o se retiró por él
+	t.PokerTable = updatedTable
+	t.Phase = updatedTable.Phase
+	t.Pot = updatedTable.Pot
+	t.TurnIndex = updatedTable.CurrentPlayer
+
+	return t, nil
+}
+
+// SitIn vuelve a sentar al jugador en juego desde la próxima mano
+func (m *managerImpl) SitIn(tableID, playerName string) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.SitIn(tableID, playerID)
+	if err != nil {
+		return t, err
+	}
+
+	t.PokerTable = updatedTable
+	return t, nil
+}
+
+// LeaveTable saca al jugador de la mesa. Si está jugando una mano el cash out
+// se registra al terminarla y se avisa por OnCashOut.
+func (m *managerImpl) LeaveTable(tableID, playerName string) (*TableState, *poker.CashOut, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, cashOut, err := m.pokerEngine.LeaveTable(tableID, playerID)
+	if err != nil {
+		return t, nil, err
+	}
+
+	// Sacar de la lista legacy; el host pasa al siguiente jugador
+	for i, p := range t.Players {
+		if p.Name == playerName {
+			t.Players = append(t.Players[:i], t.Players[i+1:]...)
+			break
+		}
+	}
+	if t.Host == playerName {
+		t.Host = ""
+		if len(t.Players) > 0 {
+			t.Host = t.Players[0].Name
+		}
+	}
+
+	// Si le tocaba actuar, el engine ya se retiró por él
+	t.PokerTable = updatedTable
+	t.Phase = updatedTable.Phase
+	t.Pot = updatedTable.Pot
+	t.TurnIndex = updatedTable.CurrentPlayer
+
+	return t, cashOut, nil
+}
+
+// Rebuy compra más fichas para el jugador; si está jugando una mano se acreditan al empezar la próxima
+func (m *managerImpl) Rebuy(tableID, playerName string, amount int) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.Rebuy(tableID, playerID, amount)
+	if err != nil {
+		return t, err
+	}
+	if _, err := m.ledger.Rebuy(playerName, tableID, amount); err != nil {
+		log.Printf("⚠️ Ledger rebuy for %s on %s failed: %v", playerName, tableID, err)
+	}
+
+	t.PokerTable = updatedTable
+	return t, nil
+}
+
+// OnCashOut registra el aviso de cada cash out. Se llama con la mesa del engine
+// bloqueada: el handler no debe volver a llamar al Manager.
+func (m *managerImpl) OnCashOut(handler func(cashOut poker.CashOut)) {
+	m.pokerEngine.SetCashOutHandler(func(cashOut poker.CashOut) {
+		m.recordCashOut(cashOut)
+		handler(cashOut)
+	})
+}
+
+// GetBalance retorna el saldo del jugador en el ledger
+func (m *managerImpl) GetBalance(playerName string) (int, error) {
+	return m.ledger.Balance(playerName)
+}
+
+// Settle calcula el resultado de cada jugador en la sesión de la mesa (los que
+// siguen sentados con su stack actual) y los pagos mínimos que la liquidan.
+// Si la mesa cobró rake la casa entra como acreedora.
+func (m *managerImpl) Settle(tableID string) (*ledger.Settlement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tables[tableID]; !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	cashOuts, rake, err := m.pokerEngine.SessionCashOuts(tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ledger.PlayerResult, 0, len(cashOuts)+1)
+	for _, cashOut := range cashOuts {
+		results = append(results, ledger.PlayerResult{
+			Player:  cashOut.PlayerName,
+			BuyIn:   cashOut.BuyIn,
+			CashOut: cashOut.Amount,
+		})
+	}
+	if rake > 0 {
+		results = append(results, ledger.PlayerResult{Player: ledger.House, CashOut: rake})
+	}
+	return ledger.Settle(tableID, results)
+}
+
+// recordBuyIn registra en el ledger las fichas con las que el jugador se sentó
+func (m *managerImpl) recordBuyIn(pokerTable *poker.PokerTable, playerID, playerName string) {
+	for _, player := range pokerTable.Players {
+		if player.ID != playerID {
+			continue
+		}
+		if _, err := m.ledger.BuyIn(playerName, pokerTable.ID, player.BuyIn); err != nil {
+			log.Printf("⚠️ Ledger buy-in for %s on %s failed: %v", playerName, pokerTable.ID, err)
+		}
+		return
+	}
+}
+
+// recordCashOut registra en el ledger el stack con el que el jugador dejó la mesa
+// (se llama con la mesa del engine bloqueada)
+func (m *managerImpl) recordCashOut(cashOut poker.CashOut) {
+	if cashOut.Amount == 0 {
+		return // Se fue sin fichas: no hay nada que devolver
+	}
+	if _, err := m.ledger.CashOut(cashOut.PlayerName, cashOut.TableID, cashOut.Amount); err != nil {
+		log.Printf("⚠️ Ledger cash out for %s on %s failed: %v", cashOut.PlayerName, cashOut.TableID, err)
+	}
+}
+
+// recordRake registra en el ledger el rake de una mano (se llama con la mesa bloqueada)
+func (m *managerImpl) recordRake(rake poker.Rake) {
+	if _, err := m.ledger.Rake(rake.TableID, rake.Amount); err != nil {
+		log.Printf("⚠️ Ledger rake for hand %d on %s failed: %v", rake.HandNumber, rake.TableID, err)
+	}
+}
+
+// recordPrizes registra en el ledger los premios de un torneo terminado
+// (se llama con el torneo bloqueado)
+func (m *managerImpl) recordPrizes(t *tournament.Tournament) {
+	for playerID, amount := range t.Prizes {
+		player, ok := t.Players[playerID]
+		if !ok || amount <= 0 {
+			continue
+		}
+		if _, err := m.ledger.Prize(player.Name, t.ID, amount); err != nil {
+			log.Printf("⚠️ Ledger prize for %s in %s failed: %v", player.Name, t.ID, err)
+		}
+	}
+}
+
+// OnTableUpdate registra el aviso para los cambios que el engine hace por su cuenta
+// (acción automática del shot clock, auto-restart). El estado ya llega sincronizado.
+func (m *managerImpl) OnTableUpdate(handler func(tableID string)) {
+	m.pokerEngine.SetUpdateHandler(func(tableID string) {
+		m.mu.Lock()
+		if t, ok := m.tables[tableID]; ok && t.PokerTable != nil {
+			t.Phase = t.PokerTable.Phase
+			t.Pot = t.PokerTable.Pot
+			t.TurnIndex = t.PokerTable.CurrentPlayer
+		}
+		m.mu.Unlock()
+
+		handler(tableID)
+	})
+}
+
 func (m *managerImpl) GetTableState(tableID string) (*TableState, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -283,7 +747,38 @@ func (m *managerImpl) RegisterForTournament(tournamentID, playerID, playerName s
 		return err
 	}
 
-	return tournament.RegisterPlayer(playerID, playerName)
+	if err := tournament.RegisterPlayer(playerID, playerName); err != nil {
+		return err
+	}
+
+	// La entrada sale del saldo del jugador y va al pozo de premios
+	if tournament.Config.BuyIn > 0 {
+		if _, err := m.ledger.TournamentEntry(playerName, tournamentID, tournament.Config.BuyIn); err != nil {
+			log.Printf("⚠️ Ledger entry for %s in %s failed: %v", playerName, tournamentID, err)
+		}
+	}
+	return nil
+}
+
+// UnregisterFromTournament baja a un jugador de un torneo que todavía no empezó
+// y le devuelve la entrada
+func (m *managerImpl) UnregisterFromTournament(tournamentID, playerID, playerName string) error {
+	tournament, err := m.tournamentManager.GetTournament(tournamentID)
+	if err != nil {
+		return err
+	}
+
+	if err := tournament.UnregisterPlayer(playerID); err != nil {
+		return err
+	}
+
+	// La entrada vuelve del pozo de premios al saldo del jugador
+	if tournament.Config.BuyIn > 0 {
+		if _, err := m.ledger.TournamentRefund(playerName, tournamentID, tournament.Config.BuyIn); err != nil {
+			log.Printf("⚠️ Ledger refund for %s in %s failed: %v", playerName, tournamentID, err)
+		}
+	}
+	return nil
 }
 
 // StartTournament inicia un torneo manualmente
@@ -437,6 +932,7 @@ func (m *managerImpl) JoinWithBuyIn(tableID, playerName string, buyInAmount int)
 		}
 		return t, err
 	}
+	m.recordBuyIn(pokerTable, playerID, playerName)
 
 	// Actualizar estado
 	t.PokerTable = pokerTable
@@ -444,10 +940,79 @@ func (m *managerImpl) JoinWithBuyIn(tableID, playerName string, buyInAmount int)
 	t.Pot = pokerTable.Pot
 
 	// Sincronizar TurnIndex con poker engine
-	if len(pokerTable.Players) > 0 {
-		t.TurnIndex = pokerTable.CurrentPlayer
+	t.TurnIndex = pokerTable.CurrentPlayer
+
+	return t, nil
+}
+
+// JoinAtSeat permite a un jugador sentarse en un asiento elegido (buy-in 0 = estándar de la mesa)
+func (m *managerImpl) JoinAtSeat(tableID, playerName string, seat, buyInAmount int) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		// Crear nueva tabla
+		t = &TableState{
+			Host:      playerName,
+			TurnIndex: 0,
+			Players:   make([]Player, 0),
+		}
+		m.tables[tableID] = t
+	}
+
+	// Sentar en el poker engine antes de tocar la lista legacy
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	pokerTable, err := m.pokerEngine.AddPlayerAtSeat(tableID, playerID, playerName, seat, buyInAmount)
+	if err != nil {
+		return t, err
+	}
+	m.recordBuyIn(pokerTable, playerID, playerName)
+
+	// Evitar duplicados en la lista legacy
+	playerExists := false
+	for _, p := range t.Players {
+		if p.Name == playerName {
+			playerExists = true
+			break
+		}
+	}
+	if !playerExists {
+		t.Players = append(t.Players, Player{Name: playerName})
 	}
 
+	// Actualizar estado
+	t.PokerTable = pokerTable
+	t.Phase = pokerTable.Phase
+	t.Pot = pokerTable.Pot
+	t.TurnIndex = pokerTable.CurrentPlayer
+
+	return t, nil
+}
+
+// ChangeSeat mueve a un jugador sentado a otro asiento libre
+func (m *managerImpl) ChangeSeat(tableID, playerName string, seat int) (*TableState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[tableID]
+	if !ok {
+		return nil, fmt.Errorf("mesa %s no existe", tableID)
+	}
+
+	if t.PokerTable == nil {
+		return nil, fmt.Errorf("poker engine not initialized for table %s", tableID)
+	}
+
+	playerID := fmt.Sprintf("%s_%s", tableID, playerName)
+	updatedTable, err := m.pokerEngine.ChangeSeat(tableID, playerID, seat)
+	if err != nil {
+		return t, err
+	}
+
+	t.PokerTable = updatedTable
+	t.TurnIndex = updatedTable.CurrentPlayer
+
 	return t, nil
 }
 
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/game"
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/ledger"
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/poker"
 )
 
 func TestManager_JoinAndTurns(t *testing.T) {
@@ -87,3 +89,209 @@ func TestManager_Distribute(t *testing.T) {
 		t.Errorf("expected pot >= 0, got pot=%d", state.Pot)
 	}
 }
+
+func TestManager_StackDeck(t *testing.T) {
+	stacked := []poker.Card{{Suit: "hearts", Rank: "A"}, {Suit: "spades", Rank: "K"}}
+
+	mgr := game.NewManager()
+	mgr.Join("mesa1", "A")
+	if err := mgr.StackDeck("mesa1", stacked); err == nil {
+		t.Fatal("expected stack_deck to fail with the crypto deck source")
+	}
+
+	qa := game.NewManagerWithDeckSource(poker.NewScriptedDeckSource())
+	if err := qa.StackDeck("missing", stacked); err == nil {
+		t.Fatal("expected error for unknown table")
+	}
+	qa.Join("mesa1", "A")
+	if err := qa.StackDeck("mesa1", stacked); err != nil {
+		t.Fatalf("unexpected error stacking deck: %v", err)
+	}
+}
+
+func TestManager_LeaveTable(t *testing.T) {
+	mgr := game.NewManager()
+	var cashOuts []poker.CashOut
+	mgr.OnCashOut(func(cashOut poker.CashOut) { cashOuts = append(cashOuts, cashOut) })
+
+	mgr.Join("mesa1", "A")
+	mgr.Join("mesa1", "B")
+
+	state, cashOut, err := mgr.LeaveTable("mesa1", "A")
+	if err != nil {
+		t.Fatalf("unexpected error leaving: %v", err)
+	}
+	if cashOut == nil || cashOut.PlayerName != "A" || cashOut.Amount != 1000 {
+		t.Fatalf("expected a cash out of 1000 for A, got %+v", cashOut)
+	}
+	if len(cashOuts) != 1 || len(state.Players) != 1 || state.Host != "B" {
+		t.Errorf("expected A removed and B as host, got %+v (%d cash outs)", state.Players, len(cashOuts))
+	}
+
+	if _, _, err := mgr.LeaveTable("mesa1", "A"); err == nil {
+		t.Error("expected error leaving twice")
+	}
+	if _, _, err := mgr.LeaveTable("missing", "B"); err == nil {
+		t.Error("expected error for unknown table")
+	}
+}
+
+func TestManager_Rebuy(t *testing.T) {
+	mgr := game.NewManager()
+	mgr.Join("mesa1", "A")
+
+	state, err := mgr.Rebuy("mesa1", "A", 500)
+	if err != nil {
+		t.Fatalf("unexpected error rebuying: %v", err)
+	}
+	player := state.PokerTable.Players[0]
+	if player.Stack != 1500 || player.BuyIn != 1500 {
+		t.Errorf("expected a 1500 stack over a 1500 buy-in, got %d / %d", player.Stack, player.BuyIn)
+	}
+	if _, err := mgr.Rebuy("mesa1", "A", 1000000); err == nil {
+		t.Error("expected error above the maximum buy-in")
+	}
+}
+
+func TestManager_LedgerTracksChips(t *testing.T) {
+	mgr := game.NewManager()
+
+	mgr.Join("mesa1", "A")
+	if _, err := mgr.JoinWithBuyIn("mesa1", "B", 800); err != nil {
+		t.Fatalf("unexpected error joining: %v", err)
+	}
+	if _, err := mgr.Rebuy("mesa1", "B", 200); err != nil {
+		t.Fatalf("unexpected error rebuying: %v", err)
+	}
+
+	if balance, _ := mgr.GetBalance("A"); balance != -1000 {
+		t.Errorf("expected A to owe the 1000 buy-in, got %d", balance)
+	}
+	if balance, _ := mgr.GetBalance("B"); balance != -1000 {
+		t.Errorf("expected B to owe 800 + 200, got %d", balance)
+	}
+
+	// Al dejar la mesa con el stack completo el saldo vuelve a cero
+	if _, _, err := mgr.LeaveTable("mesa1", "A"); err != nil {
+		t.Fatalf("unexpected error leaving: %v", err)
+	}
+	if balance, _ := mgr.GetBalance("A"); balance != 0 {
+		t.Errorf("expected A back to 0 after cashing out, got %d", balance)
+	}
+
+	// La entrada a un torneo también se debita
+	if _, err := mgr.CreateTournament("t1", "Torneo", 50, "standard"); err != nil {
+		t.Fatalf("unexpected error creating tournament: %v", err)
+	}
+	if err := mgr.RegisterForTournament("t1", "A_t1", "A"); err != nil {
+		t.Fatalf("unexpected error registering: %v", err)
+	}
+	if balance, _ := mgr.GetBalance("A"); balance != -50 {
+		t.Errorf("expected A to owe the 50 entry, got %d", balance)
+	}
+
+	// Al bajarse antes del inicio la entrada se devuelve
+	if err := mgr.UnregisterFromTournament("t1", "A_t1", "A"); err != nil {
+		t.Fatalf("unexpected error unregistering: %v", err)
+	}
+	if balance, _ := mgr.GetBalance("A"); balance != 0 {
+		t.Errorf("expected A back to 0 after the refund, got %d", balance)
+	}
+	if err := mgr.UnregisterFromTournament("t1", "A_t1", "A"); err == nil {
+		t.Error("expected error unregistering a player that is not registered")
+	}
+}
+
+func TestManager_Settle(t *testing.T) {
+	mgr := game.NewManager()
+	for _, name := range []string{"A", "B", "C"} {
+		mgr.Join("mesa1", name)
+		mgr.SetPlayerReady("mesa1", name, true)
+	}
+	state, err := mgr.StartGame("mesa1", "A")
+	if err != nil {
+		t.Fatalf("unexpected error starting game: %v", err)
+	}
+	if _, err := mgr.Settle("mesa1"); err == nil {
+		t.Error("expected error settling with a hand in play")
+	}
+
+	// Todos se retiran hasta que el big blind se lleva los blinds
+	for state.Phase == "preflop" {
+		current := state.PokerTable.Players[state.TurnIndex].Name
+		if state, err = mgr.PokerAction("mesa1", current, "fold", 0); err != nil {
+			t.Fatalf("unexpected error folding: %v", err)
+		}
+	}
+
+	settlement, err := mgr.Settle("mesa1")
+	if err != nil {
+		t.Fatalf("unexpected error settling: %v", err)
+	}
+	if settlement.Reference != "mesa1" || len(settlement.Results) != 3 {
+		t.Fatalf("expected one result per player, got %+v", settlement.Results)
+	}
+	owed := 0
+	for _, result := range settlement.Results {
+		if result.Net > 0 {
+			owed += result.Net
+		}
+	}
+	paid := 0
+	for _, transfer := range settlement.Transfers {
+		paid += transfer.Amount
+	}
+	if owed == 0 || paid != owed {
+		t.Errorf("expected the transfers to pay the %d won, got %+v", owed, settlement.Transfers)
+	}
+
+	if _, err := mgr.Settle("missing"); err == nil {
+		t.Error("expected error for unknown table")
+	}
+}
+
+func TestManager_RakeToHouse(t *testing.T) {
+	l := ledger.NewMemory()
+	mgr := game.NewManagerWithLedger(poker.CryptoDeckSource{}, l)
+	mgr.Join("mesa1", "A")
+	mgr.Join("mesa1", "B")
+
+	config, err := mgr.GetTableConfig("mesa1")
+	if err != nil {
+		t.Fatalf("unexpected error getting config: %v", err)
+	}
+	config.RakePercent = 10
+	if err := mgr.UpdateTableConfig("mesa1", *config); err != nil {
+		t.Fatalf("unexpected error setting the rake: %v", err)
+	}
+
+	mgr.SetPlayerReady("mesa1", "A", true)
+	mgr.SetPlayerReady("mesa1", "B", true)
+	state, err := mgr.StartGame("mesa1", "A")
+	if err != nil {
+		t.Fatalf("unexpected error starting game: %v", err)
+	}
+
+	// El small blind se retira: el big blind gana 20 igualados y la casa cobra 2
+	current := state.PokerTable.Players[state.TurnIndex].Name
+	if _, err := mgr.PokerAction("mesa1", current, "fold", 0); err != nil {
+		t.Fatalf("unexpected error folding: %v", err)
+	}
+	if house, _ := l.AccountBalance(ledger.HouseAccount); house != 2 {
+		t.Errorf("expected 2 in the house account, got %d", house)
+	}
+
+	settlement, err := mgr.Settle("mesa1")
+	if err != nil {
+		t.Fatalf("unexpected error settling: %v", err)
+	}
+	received := 0
+	for _, transfer := range settlement.Transfers {
+		if transfer.To == ledger.House {
+			received += transfer.Amount
+		}
+	}
+	if len(settlement.Results) != 3 || received != 2 {
+		t.Errorf("expected the house to collect 2 in the settlement, got %+v", settlement.Transfers)
+	}
+}
@@ -0,0 +1,251 @@
+// Package ledger lleva las cuentas de fichas del servidor con partida doble:
+// cada movimiento es una transacción cuyos asientos suman cero, así que las
+// fichas nunca aparecen ni desaparecen. Las fichas de un jugador salen de su
+// cuenta al comprar en una mesa o entrar a un torneo y vuelven con el cash
+// out o el premio. El rake de las mesas pasa de la mesa a la cuenta de la
+// casa. El saldo de un jugador puede ser negativo: es lo que le debe al grupo.
+package ledger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Prefijos de las cuentas
+const (
+	playerPrefix     = "player:"
+	tablePrefix      = "table:"
+	tournamentPrefix = "tournament:"
+)
+
+// HouseAccount es la cuenta de la casa, que cobra el rake de las mesas
+const HouseAccount = "house"
+
+// TxType es el tipo de una transacción
+type TxType string
+
+const (
+	TxBuyIn            TxType = "buy_in"            // Jugador → mesa al sentarse
+	TxRebuy            TxType = "rebuy"             // Jugador → mesa en una recompra o top-up
+	TxCashOut          TxType = "cash_out"          // Mesa → jugador al dejar la mesa
+	TxTournamentEntry  TxType = "tournament_entry"  // Jugador → pozo del torneo
+	TxTournamentRefund TxType = "tournament_refund" // Pozo del torneo → jugador
+	TxPrize            TxType = "prize"             // Pozo del torneo → jugador premiado
+	TxRake             TxType = "rake"              // Mesa → casa al terminar una mano con rake
+)
+
+// PlayerAccount retorna la cuenta de un jugador
+func PlayerAccount(player string) string {
+	return playerPrefix + player
+}
+
+// TableAccount retorna la cuenta con las fichas en juego de una mesa
+func TableAccount(tableID string) string {
+	return tablePrefix + tableID
+}
+
+// TournamentAccount retorna la cuenta con el pozo de premios de un torneo
+func TournamentAccount(tournamentID string) string {
+	return tournamentPrefix + tournamentID
+}
+
+// Entry es un asiento de una transacción: positivo acredita la cuenta, negativo la debita
+type Entry struct {
+	Account string `json:"account"`
+	Amount  int    `json:"amount"`
+}
+
+// Transaction es un movimiento de fichas entre cuentas
+type Transaction struct {
+	ID        string    `json:"id"`
+	Type      TxType    `json:"type"`
+	Reference string    `json:"reference"` // Mesa o torneo del movimiento
+	Entries   []Entry   `json:"entries"`
+	Time      time.Time `json:"time"`
+}
+
+// Validate verifica que la transacción esté balanceada
+func (tx Transaction) Validate() error {
+	if tx.Type == "" {
+		return fmt.Errorf("la transacción no tiene tipo")
+	}
+	if len(tx.Entries) < 2 {
+		return fmt.Errorf("la transacción necesita al menos dos asientos")
+	}
+
+	total := 0
+	for _, entry := range tx.Entries {
+		if entry.Account == "" {
+			return fmt.Errorf("asiento sin cuenta")
+		}
+		if entry.Amount == 0 {
+			return fmt.Errorf("asiento en cero en la cuenta %s", entry.Account)
+		}
+		total += entry.Amount
+	}
+	if total != 0 {
+		return fmt.Errorf("la transacción no está balanceada (suma %d)", total)
+	}
+	return nil
+}
+
+// Backend guarda las transacciones y los saldos de las cuentas
+type Backend interface {
+	// Apply guarda la transacción y actualiza los saldos de forma atómica
+	Apply(tx Transaction) error
+	// Balance retorna el saldo de una cuenta (0 si no tiene movimientos)
+	Balance(account string) (int, error)
+	// Balances retorna el saldo de todas las cuentas con movimientos
+	Balances() (map[string]int, error)
+	// Transactions retorna las transacciones de una cuenta, o todas si account es vacío
+	Transactions(account string) ([]Transaction, error)
+}
+
+// Backends disponibles
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// NewBackend crea el backend indicado (redis usa la dirección dada)
+func NewBackend(kind, addr, pass string, db int) (Backend, error) {
+	switch kind {
+	case BackendMemory:
+		return NewMemoryBackend(), nil
+	case BackendRedis:
+		return NewRedisBackend(addr, pass, db), nil
+	default:
+		return nil, fmt.Errorf("backend de ledger desconocido: %q", kind)
+	}
+}
+
+// Ledger registra los movimientos de fichas de jugadores, mesas y torneos
+type Ledger struct {
+	backend Backend
+}
+
+// New crea un ledger sobre el backend dado
+func New(backend Backend) *Ledger {
+	return &Ledger{backend: backend}
+}
+
+// NewMemory crea un ledger en memoria
+func NewMemory() *Ledger {
+	return New(NewMemoryBackend())
+}
+
+// Record valida y guarda una transacción (asigna ID y hora si faltan)
+func (l *Ledger) Record(tx Transaction) (Transaction, error) {
+	if err := tx.Validate(); err != nil {
+		return Transaction{}, err
+	}
+	if tx.ID == "" {
+		tx.ID = newTxID()
+	}
+	if tx.Time.IsZero() {
+		tx.Time = time.Now()
+	}
+
+	if err := l.backend.Apply(tx); err != nil {
+		return Transaction{}, fmt.Errorf("no se pudo guardar la transacción: %w", err)
+	}
+	return tx, nil
+}
+
+// transfer mueve amount fichas de una cuenta a otra
+func (l *Ledger) transfer(txType TxType, reference, from, to string, amount int) (Transaction, error) {
+	if amount <= 0 {
+		return Transaction{}, fmt.Errorf("el monto debe ser positivo: %d", amount)
+	}
+	return l.Record(Transaction{
+		Type:      txType,
+		Reference: reference,
+		Entries: []Entry{
+			{Account: from, Amount: -amount},
+			{Account: to, Amount: amount},
+		},
+	})
+}
+
+// BuyIn registra las fichas con las que el jugador se sienta en una mesa
+func (l *Ledger) BuyIn(player, tableID string, amount int) (Transaction, error) {
+	return l.transfer(TxBuyIn, tableID, PlayerAccount(player), TableAccount(tableID), amount)
+}
+
+// Rebuy registra una recompra o top-up en una mesa
+func (l *Ledger) Rebuy(player, tableID string, amount int) (Transaction, error) {
+	return l.transfer(TxRebuy, tableID, PlayerAccount(player), TableAccount(tableID), amount)
+}
+
+// CashOut registra el stack con el que el jugador deja una mesa
+func (l *Ledger) CashOut(player, tableID string, amount int) (Transaction, error) {
+	return l.transfer(TxCashOut, tableID, TableAccount(tableID), PlayerAccount(player), amount)
+}
+
+// TournamentEntry registra la entrada de un jugador a un torneo
+func (l *Ledger) TournamentEntry(player, tournamentID string, amount int) (Transaction, error) {
+	return l.transfer(TxTournamentEntry, tournamentID, PlayerAccount(player), TournamentAccount(tournamentID), amount)
+}
+
+// TournamentRefund devuelve la entrada de un jugador que se bajó del torneo
+func (l *Ledger) TournamentRefund(player, tournamentID string, amount int) (Transaction, error) {
+	return l.transfer(TxTournamentRefund, tournamentID, TournamentAccount(tournamentID), PlayerAccount(player), amount)
+}
+
+// Prize registra el premio de un jugador en un torneo
+func (l *Ledger) Prize(player, tournamentID string, amount int) (Transaction, error) {
+	return l.transfer(TxPrize, tournamentID, TournamentAccount(tournamentID), PlayerAccount(player), amount)
+}
+
+// Rake registra el rake que la casa cobró en una mano
+func (l *Ledger) Rake(tableID string, amount int) (Transaction, error) {
+	return l.transfer(TxRake, tableID, TableAccount(tableID), HouseAccount, amount)
+}
+
+// Balance retorna el saldo de un jugador
+func (l *Ledger) Balance(player string) (int, error) {
+	return l.backend.Balance(PlayerAccount(player))
+}
+
+// AccountBalance retorna el saldo de cualquier cuenta
+func (l *Ledger) AccountBalance(account string) (int, error) {
+	return l.backend.Balance(account)
+}
+
+// PlayerBalances retorna el saldo de cada jugador con movimientos
+func (l *Ledger) PlayerBalances() (map[string]int, error) {
+	balances, err := l.backend.Balances()
+	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[string]int)
+	for account, balance := range balances {
+		if player, ok := strings.CutPrefix(account, playerPrefix); ok {
+			players[player] = balance
+		}
+	}
+	return players, nil
+}
+
+// Transactions retorna los movimientos de un jugador en orden
+func (l *Ledger) Transactions(player string) ([]Transaction, error) {
+	return l.backend.Transactions(PlayerAccount(player))
+}
+
+// AllTransactions retorna todos los movimientos en orden
+func (l *Ledger) AllTransactions() ([]Transaction, error) {
+	return l.backend.Transactions("")
+}
+
+// newTxID genera un ID de transacción aleatorio
+func newTxID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("tx-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,116 @@
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/ledger"
+	"github.com/alicebob/miniredis/v2"
+)
+
+// backends arma un ledger por cada backend
+func backends(t *testing.T) map[string]*ledger.Ledger {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+
+	return map[string]*ledger.Ledger{
+		"memory": ledger.NewMemory(),
+		"redis":  ledger.New(ledger.NewRedisBackend(mr.Addr(), "", 0)),
+	}
+}
+
+func TestLedger_CashGameSession(t *testing.T) {
+	for name, l := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			mustRecord(t)(l.BuyIn("alice", "mesa1", 1000))
+			mustRecord(t)(l.BuyIn("bob", "mesa1", 1000))
+			mustRecord(t)(l.Rebuy("bob", "mesa1", 500))
+			mustRecord(t)(l.CashOut("alice", "mesa1", 1800))
+			mustRecord(t)(l.CashOut("bob", "mesa1", 700))
+
+			balances, err := l.PlayerBalances()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if balances["alice"] != 800 || balances["bob"] != -800 || len(balances) != 2 {
+				t.Errorf("expected alice +800 and bob -800, got %v", balances)
+			}
+
+			// Todas las fichas que entraron a la mesa salieron
+			if table, _ := l.AccountBalance(ledger.TableAccount("mesa1")); table != 0 {
+				t.Errorf("expected an empty table account, got %d", table)
+			}
+
+			txs, err := l.Transactions("bob")
+			if err != nil || len(txs) != 3 {
+				t.Fatalf("expected 3 transactions for bob, got %d (%v)", len(txs), err)
+			}
+			if txs[0].Type != ledger.TxBuyIn || txs[1].Type != ledger.TxRebuy || txs[2].Type != ledger.TxCashOut {
+				t.Errorf("unexpected transaction order: %v %v %v", txs[0].Type, txs[1].Type, txs[2].Type)
+			}
+			if all, _ := l.AllTransactions(); len(all) != 5 {
+				t.Errorf("expected 5 transactions, got %d", len(all))
+			}
+		})
+	}
+}
+
+func TestLedger_Tournament(t *testing.T) {
+	for name, l := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, player := range []string{"alice", "bob", "carol"} {
+				mustRecord(t)(l.TournamentEntry(player, "t1", 100))
+			}
+			mustRecord(t)(l.TournamentRefund("carol", "t1", 100))
+			mustRecord(t)(l.Prize("alice", "t1", 200))
+
+			balances, _ := l.PlayerBalances()
+			if balances["alice"] != 100 || balances["bob"] != -100 || balances["carol"] != 0 {
+				t.Errorf("unexpected balances: %v", balances)
+			}
+			if pool, _ := l.AccountBalance(ledger.TournamentAccount("t1")); pool != 0 {
+				t.Errorf("expected the prize pool paid out, got %d", pool)
+			}
+			if balance, _ := l.Balance("nobody"); balance != 0 {
+				t.Errorf("expected 0 for an unknown player, got %d", balance)
+			}
+		})
+	}
+}
+
+func TestLedger_RejectsInvalidTransactions(t *testing.T) {
+	l := ledger.NewMemory()
+
+	if _, err := l.BuyIn("alice", "mesa1", 0); err == nil {
+		t.Error("expected error for a zero amount")
+	}
+	unbalanced := ledger.Transaction{
+		Type: ledger.TxBuyIn,
+		Entries: []ledger.Entry{
+			{Account: ledger.PlayerAccount("alice"), Amount: -100},
+			{Account: ledger.TableAccount("mesa1"), Amount: 90},
+		},
+	}
+	if _, err := l.Record(unbalanced); err == nil {
+		t.Error("expected error for an unbalanced transaction")
+	}
+	if all, _ := l.AllTransactions(); len(all) != 0 {
+		t.Errorf("rejected transactions should not be stored, got %d", len(all))
+	}
+}
+
+// mustRecord falla el test si la transacción no se guardó
+func mustRecord(t *testing.T) func(ledger.Transaction, error) {
+	t.Helper()
+	return func(tx ledger.Transaction, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("unexpected error recording: %v", err)
+		}
+		if tx.ID == "" || tx.Time.IsZero() {
+			t.Errorf("expected an ID and a time, got %+v", tx)
+		}
+	}
+}
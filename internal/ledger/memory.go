@@ -0,0 +1,70 @@
+package ledger
+
+import "sync"
+
+// MemoryBackend guarda el ledger en memoria (se pierde al reiniciar el servidor)
+type MemoryBackend struct {
+	mu           sync.RWMutex
+	balances     map[string]int
+	transactions []Transaction
+	byAccount    map[string][]int // Índices en transactions por cuenta
+}
+
+// NewMemoryBackend crea un backend en memoria vacío
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		balances:  make(map[string]int),
+		byAccount: make(map[string][]int),
+	}
+}
+
+func (m *MemoryBackend) Apply(tx Transaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	index := len(m.transactions)
+	tx.Entries = append([]Entry(nil), tx.Entries...)
+	m.transactions = append(m.transactions, tx)
+
+	for _, entry := range tx.Entries {
+		m.balances[entry.Account] += entry.Amount
+		indices := m.byAccount[entry.Account]
+		if len(indices) == 0 || indices[len(indices)-1] != index {
+			m.byAccount[entry.Account] = append(indices, index)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryBackend) Balance(account string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.balances[account], nil
+}
+
+func (m *MemoryBackend) Balances() (map[string]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	balances := make(map[string]int, len(m.balances))
+	for account, balance := range m.balances {
+		balances[account] = balance
+	}
+	return balances, nil
+}
+
+func (m *MemoryBackend) Transactions(account string) ([]Transaction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if account == "" {
+		return append([]Transaction(nil), m.transactions...), nil
+	}
+
+	indices := m.byAccount[account]
+	transactions := make([]Transaction, 0, len(indices))
+	for _, index := range indices {
+		transactions = append(transactions, m.transactions[index])
+	}
+	return transactions, nil
+}
@@ -0,0 +1,125 @@
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Claves del ledger en Redis
+const (
+	redisBalancesKey     = "ledger:balances"     // Hash cuenta → saldo
+	redisTransactionsKey = "ledger:transactions" // Lista con los IDs de todas las transacciones
+)
+
+// RedisBackend guarda el ledger en Redis. Cada transacción se aplica en un
+// MULTI/EXEC, así que los saldos y el registro nunca quedan a medias.
+type RedisBackend struct {
+	client *redis.Client
+	ctx    context.Context
+}
+
+// NewRedisBackend crea un backend sobre el Redis indicado
+func NewRedisBackend(addr, pass string, db int) *RedisBackend {
+	return &RedisBackend{
+		client: redis.NewClient(&redis.Options{Addr: addr, Password: pass, DB: db}),
+		ctx:    context.Background(),
+	}
+}
+
+func redisTransactionKey(id string) string {
+	return "ledger:tx:" + id
+}
+
+func redisAccountKey(account string) string {
+	return "ledger:account:" + account
+}
+
+func (r *RedisBackend) Apply(tx Transaction) error {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+
+	_, err = r.client.TxPipelined(r.ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(r.ctx, redisTransactionKey(tx.ID), data, 0)
+		pipe.RPush(r.ctx, redisTransactionsKey, tx.ID)
+
+		listed := make(map[string]bool)
+		for _, entry := range tx.Entries {
+			pipe.HIncrBy(r.ctx, redisBalancesKey, entry.Account, int64(entry.Amount))
+			if !listed[entry.Account] {
+				pipe.RPush(r.ctx, redisAccountKey(entry.Account), tx.ID)
+				listed[entry.Account] = true
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *RedisBackend) Balance(account string) (int, error) {
+	balance, err := r.client.HGet(r.ctx, redisBalancesKey, account).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return balance, err
+}
+
+func (r *RedisBackend) Balances() (map[string]int, error) {
+	values, err := r.client.HGetAll(r.ctx, redisBalancesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]int, len(values))
+	for account, value := range values {
+		balance, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("saldo inválido en la cuenta %s: %w", account, err)
+		}
+		balances[account] = balance
+	}
+	return balances, nil
+}
+
+func (r *RedisBackend) Transactions(account string) ([]Transaction, error) {
+	key := redisTransactionsKey
+	if account != "" {
+		key = redisAccountKey(account)
+	}
+
+	ids, err := r.client.LRange(r.ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []Transaction{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = redisTransactionKey(id)
+	}
+	values, err := r.client.MGet(r.ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]Transaction, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("falta la transacción %s", ids[i])
+		}
+		var tx Transaction
+		if err := json.Unmarshal([]byte(data), &tx); err != nil {
+			return nil, fmt.Errorf("transacción %s inválida: %w", ids[i], err)
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,221 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// House es el nombre con el que la casa aparece en una liquidación
+// cuando cobró rake: es un acreedor más
+const House = "house"
+
+// maxExactSettlement es la cantidad máxima de jugadores con saldo para buscar
+// la liquidación óptima (el algoritmo exacto recorre todos los subconjuntos)
+const maxExactSettlement = 16
+
+// PlayerResult es el resultado de un jugador en una sesión
+type PlayerResult struct {
+	Player  string `json:"player"`
+	BuyIn   int    `json:"buy_in"`   // Fichas compradas (buy-ins y recompras)
+	CashOut int    `json:"cash_out"` // Fichas con las que se fue (o su stack actual)
+	Net     int    `json:"net"`      // Ganancia (positivo) o pérdida (negativo)
+}
+
+// Transfer es un pago de un jugador a otro para liquidar la sesión
+type Transfer struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount int    `json:"amount"`
+}
+
+// Settlement es la liquidación de una sesión: resultados y pagos entre jugadores
+type Settlement struct {
+	Reference string         `json:"reference"` // Mesa de la sesión
+	Results   []PlayerResult `json:"results"`
+	Transfers []Transfer     `json:"transfers"`
+}
+
+// Settle calcula los pagos que liquidan los resultados de la sesión.
+// Los resultados de un mismo jugador se suman.
+func Settle(reference string, results []PlayerResult) (*Settlement, error) {
+	byPlayer := make(map[string]*PlayerResult)
+	for _, result := range results {
+		total, ok := byPlayer[result.Player]
+		if !ok {
+			total = &PlayerResult{Player: result.Player}
+			byPlayer[result.Player] = total
+		}
+		total.BuyIn += result.BuyIn
+		total.CashOut += result.CashOut
+	}
+
+	settlement := &Settlement{Reference: reference, Results: make([]PlayerResult, 0, len(byPlayer))}
+	nets := make(map[string]int, len(byPlayer))
+	for player, total := range byPlayer {
+		total.Net = total.CashOut - total.BuyIn
+		settlement.Results = append(settlement.Results, *total)
+		nets[player] = total.Net
+	}
+	sort.Slice(settlement.Results, func(i, j int) bool {
+		return settlement.Results[i].Player < settlement.Results[j].Player
+	})
+
+	transfers, err := MinimalTransfers(nets)
+	if err != nil {
+		return nil, err
+	}
+	settlement.Transfers = transfers
+	return settlement, nil
+}
+
+// MinimalTransfers retorna la menor cantidad de pagos que deja a todos en cero.
+// Los saldos tienen que sumar cero.
+//
+// Con n jugadores con saldo hacen falta n - k pagos, donde k es la máxima
+// cantidad de grupos disjuntos que suman cero (cada grupo se liquida por
+// separado con un pago menos que jugadores). Hasta maxExactSettlement
+// jugadores se buscan los grupos recorriendo los subconjuntos; con más se
+// liquida todo como un solo grupo.
+func MinimalTransfers(nets map[string]int) ([]Transfer, error) {
+	players := make([]string, 0, len(nets))
+	total := 0
+	for player, net := range nets {
+		total += net
+		if net != 0 {
+			players = append(players, player)
+		}
+	}
+	if total != 0 {
+		return nil, fmt.Errorf("los resultados no suman cero (sobran %d)", total)
+	}
+	sort.Strings(players)
+
+	transfers := []Transfer{}
+	for _, group := range zeroSumGroups(players, nets) {
+		transfers = append(transfers, settleGroup(group, nets)...)
+	}
+	return transfers, nil
+}
+
+// zeroSumGroups parte a los jugadores en la mayor cantidad de grupos que suman cero
+func zeroSumGroups(players []string, nets map[string]int) [][]string {
+	n := len(players)
+	if n == 0 {
+		return nil
+	}
+	if n > maxExactSettlement {
+		return [][]string{players}
+	}
+
+	full := 1<<n - 1
+	sums := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		low := mask & -mask
+		index := bitIndex(low)
+		sums[mask] = sums[mask^low] + nets[players[index]]
+	}
+
+	// groups[mask]: máxima cantidad de grupos que suman cero al sacar los jugadores de a uno
+	groups := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		best := 0
+		for rest := mask; rest != 0; rest &= rest - 1 {
+			if g := groups[mask^(rest&-rest)]; g > best {
+				best = g
+			}
+		}
+		if sums[mask] == 0 {
+			best++
+		}
+		groups[mask] = best
+	}
+
+	// Reconstruir el camino: cada vez que lo que queda suma cero se cierra un grupo
+	var result [][]string
+	var current []string
+	for mask := full; mask != 0; {
+		bonus := 0
+		if sums[mask] == 0 {
+			bonus = 1
+		}
+		for rest := mask; rest != 0; rest &= rest - 1 {
+			bit := rest & -rest
+			if groups[mask^bit]+bonus == groups[mask] {
+				current = append(current, players[bitIndex(bit)])
+				mask ^= bit
+				break
+			}
+		}
+		if sums[mask] == 0 {
+			result = append(result, current)
+			current = nil
+		}
+	}
+	return result
+}
+
+// settleGroup liquida un grupo que suma cero: el que más debe le paga al que más tiene que cobrar
+func settleGroup(group []string, nets map[string]int) []Transfer {
+	balances := make(map[string]int, len(group))
+	for _, player := range group {
+		balances[player] = nets[player]
+	}
+
+	var transfers []Transfer
+	for {
+		debtor, creditor := "", ""
+		for _, player := range group {
+			if balances[player] < 0 && (debtor == "" || balances[player] < balances[debtor]) {
+				debtor = player
+			}
+			if balances[player] > 0 && (creditor == "" || balances[player] > balances[creditor]) {
+				creditor = player
+			}
+		}
+		if debtor == "" || creditor == "" {
+			return transfers
+		}
+
+		amount := -balances[debtor]
+		if balances[creditor] < amount {
+			amount = balances[creditor]
+		}
+		transfers = append(transfers, Transfer{From: debtor, To: creditor, Amount: amount})
+		balances[debtor] += amount
+		balances[creditor] -= amount
+	}
+}
+
+// bitIndex retorna la posición del único bit encendido
+func bitIndex(bit int) int {
+	index := 0
+	for bit > 1 {
+		bit >>= 1
+		index++
+	}
+	return index
+}
+
+// JSON exporta la liquidación como JSON
+func (s *Settlement) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// WriteCSV exporta la liquidación como CSV: una fila por resultado y una por pago
+func (s *Settlement) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"record", "player", "buy_in", "cash_out", "net", "pays_to", "amount"})
+	for _, result := range s.Results {
+		writer.Write([]string{"result", result.Player, strconv.Itoa(result.BuyIn),
+			strconv.Itoa(result.CashOut), strconv.Itoa(result.Net), "", ""})
+	}
+	for _, transfer := range s.Transfers {
+		writer.Write([]string{"transfer", transfer.From, "", "", "", transfer.To, strconv.Itoa(transfer.Amount)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
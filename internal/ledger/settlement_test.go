@@ -0,0 +1,117 @@
+package ledger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/ledger"
+)
+
+func TestSettle_ResultsAndTransfers(t *testing.T) {
+	settlement, err := ledger.Settle("mesa1", []ledger.PlayerResult{
+		{Player: "bob", BuyIn: 1000, CashOut: 200},
+		{Player: "alice", BuyIn: 1000, CashOut: 1500},
+		{Player: "carol", BuyIn: 500, CashOut: 0},
+		{Player: "carol", BuyIn: 500, CashOut: 1300}, // Recompra: se suma al resultado anterior
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(settlement.Results) != 3 || settlement.Results[0].Player != "alice" || settlement.Results[2].Player != "carol" {
+		t.Fatalf("expected one result per player sorted by name, got %+v", settlement.Results)
+	}
+	if settlement.Results[0].Net != 500 || settlement.Results[1].Net != -800 || settlement.Results[2].Net != 300 {
+		t.Errorf("unexpected nets: %+v", settlement.Results)
+	}
+	if len(settlement.Transfers) != 2 {
+		t.Fatalf("expected 2 transfers, got %+v", settlement.Transfers)
+	}
+	assertSettles(t, settlement)
+}
+
+func TestMinimalTransfers_ZeroSumGroups(t *testing.T) {
+	// a/b y c/d/e se liquidan por separado: 1 + 2 pagos. Un greedy sobre
+	// todos junta a a con d y termina necesitando 4.
+	nets := map[string]int{"a": -70, "b": 70, "c": -50, "d": 80, "e": -30}
+	transfers, err := ledger.MinimalTransfers(nets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transfers) != 3 {
+		t.Fatalf("expected 3 transfers, got %+v", transfers)
+	}
+	for _, transfer := range transfers {
+		nets[transfer.From] += transfer.Amount
+		nets[transfer.To] -= transfer.Amount
+	}
+	for player, net := range nets {
+		if net != 0 {
+			t.Errorf("%s left with %d after the transfers", player, net)
+		}
+	}
+
+	if transfers, _ := ledger.MinimalTransfers(map[string]int{"a": 0}); len(transfers) != 0 {
+		t.Errorf("expected no transfers when everybody is even, got %+v", transfers)
+	}
+	if _, err := ledger.MinimalTransfers(map[string]int{"a": 100, "b": -90}); err == nil {
+		t.Error("expected error when the results do not add up to zero")
+	}
+}
+
+func TestSettlement_Export(t *testing.T) {
+	settlement, err := ledger.Settle("mesa1", []ledger.PlayerResult{
+		{Player: "alice", BuyIn: 1000, CashOut: 1250},
+		{Player: "bob", BuyIn: 1000, CashOut: 750},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := settlement.JSON()
+	if err != nil {
+		t.Fatalf("unexpected error exporting JSON: %v", err)
+	}
+	var decoded ledger.Settlement
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Reference != "mesa1" || len(decoded.Transfers) != 1 {
+		t.Errorf("JSON export did not round trip: %s (%v)", data, err)
+	}
+
+	var buf bytes.Buffer
+	if err := settlement.WriteCSV(&buf); err != nil {
+		t.Fatalf("unexpected error exporting CSV: %v", err)
+	}
+	expected := strings.Join([]string{
+		"record,player,buy_in,cash_out,net,pays_to,amount",
+		"result,alice,1000,1250,250,,",
+		"result,bob,1000,750,-250,,",
+		"transfer,bob,,,,alice,250",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+}
+
+// assertSettles verifica que los pagos dejen a todos los jugadores en cero
+func assertSettles(t *testing.T, settlement *ledger.Settlement) {
+	t.Helper()
+	balances := make(map[string]int)
+	for _, result := range settlement.Results {
+		balances[result.Player] = result.Net
+	}
+	for _, transfer := range settlement.Transfers {
+		if transfer.Amount <= 0 {
+			t.Errorf("transfer with a non-positive amount: %+v", transfer)
+		}
+		balances[transfer.From] += transfer.Amount
+		balances[transfer.To] -= transfer.Amount
+	}
+	for player, balance := range balances {
+		if balance != 0 {
+			t.Errorf("%s left with %d after the transfers", player, balance)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ====== ANTES ======
+
+// Tipos de ante soportados
+const (
+	AnteClassic  = "classic"   // Cada jugador pone el ante
+	AnteBigBlind = "big_blind" // El big blind pone el ante de toda la mesa
+)
+
+// normalizeAnteType retorna el tipo de ante por defecto (classic) si no se especificó
+func normalizeAnteType(anteType string) string {
+	if anteType == "" {
+		return AnteClassic
+	}
+	return anteType
+}
+
+// validateAnteType verifica que el tipo de ante sea soportado
+func validateAnteType(anteType string) error {
+	switch anteType {
+	case "", AnteClassic, AnteBigBlind:
+		return nil
+	default:
+		return fmt.Errorf("tipo de ante desconocido: %s", anteType)
+	}
+}
+
+// postAntes cobra el ante clásico a todos los jugadores activos
+func (pe *PokerEngine) postAntes(table *PokerTable, activePlayers []int) {
+	if table.Ante <= 0 {
+		return
+	}
+
+	for _, playerIndex := range activePlayers {
+		pe.postAnte(table, playerIndex, table.Ante)
+	}
+}
+
+// postBigBlindAnte cobra el ante de toda la mesa al big blind.
+// Se cobra después del blind: si no alcanza, el blind tiene prioridad.
+func (pe *PokerEngine) postBigBlindAnte(table *PokerTable, bbPlayerIndex int) {
+	if table.Ante <= 0 {
+		return
+	}
+	pe.postAnte(table, bbPlayerIndex, table.Ante)
+}
+
+// postAnte cobra un ante a un jugador (o lo que le quede si no alcanza)
+func (pe *PokerEngine) postAnte(table *PokerTable, playerIndex, amount int) {
+	player := &table.Players[playerIndex]
+	if player.Stack < amount {
+		amount = player.Stack
+	}
+	player.Stack -= amount
+	// El ante es dinero muerto: va al pot principal sin contar para igualar ni para los niveles de los side pots
+	player.AnteBet += amount
+	table.Pot += amount
+	pe.recordAction(table, playerIndex, "ante", amount, 0)
+	if player.Stack == 0 {
+		player.IsAllIn = true
+		table.PlayersToAct[playerIndex] = false
+	}
+}
+
+// addAntesToPots suma los antes de la mano a los pots ya armados con las apuestas.
+// Los antes son del pot principal; solo quien quedó all-in con el ante (sin
+// llegar a apostar) tiene un pot propio, en el que cada jugador aporta como
+// máximo lo que puso ese jugador.
+func (pe *PokerEngine) addAntesToPots(table *PokerTable, activePlayers []int) {
+	remaining := 0
+	for _, player := range table.Players {
+		remaining += player.AnteBet
+	}
+	if remaining == 0 {
+		return
+	}
+
+	// Niveles de ante de los jugadores que quedaron all-in sin apostar
+	levelSet := make(map[int]bool)
+	for _, playerIndex := range activePlayers {
+		if player := table.Players[playerIndex]; isAnteAllIn(player) {
+			levelSet[player.AnteBet] = true
+		}
+	}
+	levels := make([]int, 0, len(levelSet))
+	for level := range levelSet {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	antePots := make([]SidePot, 0, len(levels))
+	prevLevel := 0
+	for _, level := range levels {
+		pot := SidePot{EligiblePlayers: make([]int, 0)}
+		for _, player := range table.Players {
+			pot.Amount += levelShare(player.AnteBet, prevLevel, level)
+		}
+		for _, playerIndex := range activePlayers {
+			player := table.Players[playerIndex]
+			if !isAnteAllIn(player) || player.AnteBet >= level {
+				pot.EligiblePlayers = append(pot.EligiblePlayers, playerIndex)
+			}
+		}
+		remaining -= pot.Amount
+		prevLevel = level
+		antePots = append(antePots, pot)
+	}
+
+	// El resto de los antes va al pot principal de las apuestas; sin apuestas, al último pot de antes
+	switch {
+	case len(table.SidePots) > 0:
+		table.SidePots[0].Amount += remaining
+	case len(antePots) > 0:
+		antePots[len(antePots)-1].Amount += remaining
+	default:
+		antePots = append(antePots, SidePot{Amount: remaining, EligiblePlayers: append([]int(nil), activePlayers...)})
+	}
+
+	// Un pot de antes con los mismos elegibles que el siguiente pot se junta con él
+	pots := append(antePots, table.SidePots...)
+	merged := make([]SidePot, 0, len(pots))
+	for _, pot := range pots {
+		if pot.Amount == 0 {
+			continue
+		}
+		if last := len(merged) - 1; last >= 0 && merged[last].MaxBetLevel == 0 && sameInts(merged[last].EligiblePlayers, pot.EligiblePlayers) {
+			pot.Amount += merged[last].Amount
+			merged[last] = pot
+			continue
+		}
+		merged = append(merged, pot)
+	}
+	table.SidePots = merged
+}
+
+// isAnteAllIn indica si el jugador quedó all-in con el ante, sin poner ninguna apuesta
+func isAnteAllIn(player PokerPlayer) bool {
+	return player.IsAllIn && player.AnteBet > 0 && playerContribution(player) == 0
+}
+
+// sameInts indica si dos listas tienen los mismos valores en el mismo orden
+func sameInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
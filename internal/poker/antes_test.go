@@ -0,0 +1,218 @@
+package poker
+
+import (
+	"testing"
+)
+
+// anteConfig es la configuración de una mesa de hold'em 10/20 con el ante indicado
+func anteConfig(anteType string, ante int) TableConfig {
+	return TableConfig{
+		SmallBlind: 10, BigBlind: 20, Ante: ante, AnteType: anteType,
+		BuyInAmount: 1000, MinBuyIn: 1, MaxBuyIn: 2000,
+	}
+}
+
+// TestClassicAntes verifica que cada jugador ponga el ante además de los blinds
+func TestClassicAntes(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "classic_antes", anteConfig(AnteClassic, 5), "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	if table.Pot != 3*5+10+20 {
+		t.Errorf("Expected pot %d, got %d", 3*5+10+20, table.Pot)
+	}
+	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if player.AnteBet != 5 {
+			t.Errorf("Player %s should have posted an ante of 5, got %d", player.Name, player.AnteBet)
+		}
+		if player.Stack+playerInvestment(player) != 1000 {
+			t.Errorf("Player %s chips not conserved", player.Name)
+		}
+	}
+
+	// El ante no cuenta para igualar la apuesta: el primero en actuar debe pagar el big blind completo
+	current := table.CurrentPlayer
+	stackBefore := table.Players[current].Stack
+	if _, err := engine.PlayerAction(table.ID, table.Players[current].ID, "call", 0); err != nil {
+		t.Fatalf("Unexpected error calling: %v", err)
+	}
+	if paid := stackBefore - table.Players[current].Stack; paid != 20 {
+		t.Errorf("Expected call of 20 over the ante, paid %d", paid)
+	}
+}
+
+// TestBigBlindAnte verifica que solo el big blind ponga el ante de toda la mesa
+func TestBigBlindAnte(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "big_blind_ante", anteConfig(AnteBigBlind, 20), "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	if table.Pot != 10+20+20 {
+		t.Errorf("Expected pot 50, got %d", table.Pot)
+	}
+
+	antesPosted := 0
+	for _, player := range table.Players {
+		if player.AnteBet > 0 {
+			antesPosted++
+			if player.CurrentBet != 20 || player.AnteBet != 20 {
+				t.Errorf("Only the big blind should post the ante, %s posted blind %d ante %d",
+					player.Name, player.CurrentBet, player.AnteBet)
+			}
+		}
+	}
+	if antesPosted != 1 {
+		t.Errorf("Expected exactly one ante, got %d", antesPosted)
+	}
+}
+
+// TestBigBlindAnteShortStack verifica que el blind tenga prioridad sobre el ante si no alcanza
+func TestBigBlindAnteShortStack(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "big_blind_ante_short", anteConfig(AnteBigBlind, 20), "alice", "bob", "carol")
+	table.Players[0].Stack = 25
+	table.Players[1].Stack = 25
+	table.Players[2].Stack = 25
+	startTestGame(t, engine, table)
+
+	for _, player := range table.Players {
+		if player.CurrentBet == 20 {
+			if player.AnteBet != 5 || !player.IsAllIn {
+				t.Errorf("Big blind should post the full blind and 5 of ante (all-in), got ante %d all-in %v",
+					player.AnteBet, player.IsAllIn)
+			}
+			return
+		}
+	}
+	t.Error("No player posted the big blind")
+}
+
+// TestAnteAllInSidePots verifica los side pots cuando un jugador queda all-in con menos que el ante
+func TestAnteAllInSidePots(t *testing.T) {
+	engine := NewPokerEngine()
+	table := &PokerTable{
+		Players: []PokerPlayer{
+			{ID: "short", IsActive: true, IsAllIn: true, AnteBet: 3},
+			{ID: "bob", IsActive: true, AnteBet: 5, CurrentBet: 100},
+			{ID: "carol", IsActive: true, AnteBet: 5, CurrentBet: 100},
+		},
+	}
+
+	engine.createSidePots(table)
+
+	if len(table.SidePots) != 2 {
+		t.Fatalf("Expected 2 pots, got %d", len(table.SidePots))
+	}
+	if table.SidePots[0].Amount != 9 || len(table.SidePots[0].EligiblePlayers) != 3 {
+		t.Errorf("Main pot should be 9 for 3 players, got %d for %d",
+			table.SidePots[0].Amount, len(table.SidePots[0].EligiblePlayers))
+	}
+	if table.SidePots[1].Amount != 204 || len(table.SidePots[1].EligiblePlayers) != 2 {
+		t.Errorf("Side pot should be 204 for 2 players, got %d for %d",
+			table.SidePots[1].Amount, len(table.SidePots[1].EligiblePlayers))
+	}
+}
+
+// TestAntesAreDeadMoney verifica que los antes vayan al pot principal sin crear
+// un pot que solo pueda ganar quien los puso
+func TestAntesAreDeadMoney(t *testing.T) {
+	engine := NewPokerEngine()
+
+	// Big blind ante de 30: los demás quedan all-in por el blind
+	table := &PokerTable{
+		Players: []PokerPlayer{
+			{ID: "big_blind", IsActive: true, AnteBet: 30, CurrentBet: 20},
+			{ID: "bob", IsActive: true, IsAllIn: true, CurrentBet: 20},
+			{ID: "carol", IsActive: true, IsAllIn: true, CurrentBet: 20},
+		},
+	}
+	engine.createSidePots(table)
+	if len(table.SidePots) != 1 || table.SidePots[0].Amount != 90 || len(table.SidePots[0].EligiblePlayers) != 3 {
+		t.Errorf("Expected a single pot of 90 for 3 players, got %+v", table.SidePots)
+	}
+
+	// Antes clásicos con un all-in: los antes no suben el nivel del pot principal
+	table = &PokerTable{
+		Players: []PokerPlayer{
+			{ID: "short", IsActive: true, IsAllIn: true, AnteBet: 5, CurrentBet: 50},
+			{ID: "bob", IsActive: true, AnteBet: 5, CurrentBet: 100},
+			{ID: "carol", IsActive: true, AnteBet: 5, CurrentBet: 100},
+		},
+	}
+	engine.createSidePots(table)
+	if len(table.SidePots) != 2 {
+		t.Fatalf("Expected 2 pots, got %+v", table.SidePots)
+	}
+	if table.SidePots[0].Amount != 165 || table.SidePots[0].MaxBetLevel != 50 || len(table.SidePots[0].EligiblePlayers) != 3 {
+		t.Errorf("Main pot should be 165 at level 50 for 3 players, got %+v", table.SidePots[0])
+	}
+	if table.SidePots[1].Amount != 100 || len(table.SidePots[1].EligiblePlayers) != 2 {
+		t.Errorf("Side pot should be 100 for 2 players, got %+v", table.SidePots[1])
+	}
+}
+
+// TestShortAnteGoesAllIn verifica que un jugador sin fichas para el ante quede all-in
+func TestShortAnteGoesAllIn(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "short_ante", anteConfig(AnteClassic, 5), "alice", "bob", "carol")
+	table.Players[0].Stack = 3
+	startTestGame(t, engine, table)
+
+	short := table.Players[0]
+	if short.AnteBet+short.CurrentBet != 3 || !short.IsAllIn || short.Stack != 0 {
+		t.Errorf("Short stack should be all-in for 3, got ante %d blind %d stack %d all-in %v",
+			short.AnteBet, short.CurrentBet, short.Stack, short.IsAllIn)
+	}
+
+	// Al terminar la mano solo puede ganar los antes hasta lo que puso (3 de cada uno)
+	table.AutoRestart = false
+	for i := 0; i < 20 && table.Phase != "showdown"; i++ {
+		player := table.Players[table.CurrentPlayer]
+		action := "check"
+		if table.CurrentBet > player.CurrentBet {
+			action = "call"
+		}
+		mustAct(t, engine, table, action, 0)
+	}
+	if table.Phase != "showdown" {
+		t.Fatalf("Expected showdown, got %s", table.Phase)
+	}
+	total := 0
+	for _, player := range table.Players {
+		total += player.Stack
+	}
+	if total != 2003 {
+		t.Errorf("Chips not conserved: expected 2003, got %d", total)
+	}
+	if table.Players[0].Stack > 9 {
+		t.Errorf("Short stack can win at most 9 from the antes, got %d", table.Players[0].Stack)
+	}
+}
+
+// TestAnteTypeValidation verifica que se rechacen tipos de ante desconocidos
+func TestAnteTypeValidation(t *testing.T) {
+	engine := NewPokerEngine()
+	engine.CreateTable("ante_validation")
+
+	err := engine.UpdateTableConfig("ante_validation", TableConfig{
+		SmallBlind: 10, BigBlind: 20, Ante: 5, AnteType: "button",
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+	})
+	if err == nil {
+		t.Error("Unknown ante type should be rejected")
+	}
+
+	err = engine.UpdateTableConfig("ante_validation", TableConfig{
+		SmallBlind: 10, BigBlind: 20, Ante: 20, AnteType: AnteBigBlind,
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config, _ := engine.GetTableConfig("ante_validation"); config.AnteType != AnteBigBlind || config.Ante != 20 {
+		t.Errorf("Expected big blind ante of 20, got %s %d", config.AnteType, config.Ante)
+	}
+}
@@ -61,6 +61,16 @@ func BenchmarkHandEvaluation(b *testing.B) {
 		}
 	})
 	
+	b.Run("LookupEvaluate", func(b *testing.B) {
+		cards := append(append([]Card(nil), playerCards...), communityCards...)
+		LookupEvaluate(cards) // Armar las tablas fuera de la medición
+		
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = LookupEvaluate(cards)
+		}
+	})
+	
 	b.Run("CompareHands", func(b *testing.B) {
 		hand1 := EvaluateHand(playerCards, communityCards)
 		hand2 := EvaluateHand([]Card{{Suit: "hearts", Rank: "2"}, {Suit: "spades", Rank: "3"}}, communityCards)
@@ -0,0 +1,150 @@
+package poker
+
+import "fmt"
+
+// ====== ESTRUCTURAS DE APUESTAS ======
+
+// Estructuras de apuestas soportadas
+const (
+	BettingNoLimit    = "no_limit"
+	BettingPotLimit   = "pot_limit"
+	BettingFixedLimit = "fixed_limit"
+)
+
+// limitBetCap es la cantidad máxima de apuestas por calle en fixed limit (1 bet + 3 raises)
+const limitBetCap = 4
+
+// normalizeBettingStructure aplica la estructura por defecto de cada variante
+// (pot limit en Omaha, no limit en el resto)
+func normalizeBettingStructure(structure, variant string) string {
+	if structure != "" {
+		return structure
+	}
+	if normalizeVariant(variant) == VariantOmaha {
+		return BettingPotLimit
+	}
+	return BettingNoLimit
+}
+
+// validateBettingStructure verifica que la estructura de apuestas sea soportada
+func validateBettingStructure(structure string) error {
+	switch structure {
+	case "", BettingNoLimit, BettingPotLimit, BettingFixedLimit:
+		return nil
+	default:
+		return fmt.Errorf("estructura de apuestas desconocida: %s", structure)
+	}
+}
+
+// isPotLimit indica si la mesa limita los raises al tamaño del pot
+func isPotLimit(table *PokerTable) bool {
+	return table.BettingStructure == BettingPotLimit
+}
+
+// isFixedLimit indica si la mesa juega con apuestas de tamaño fijo
+func isFixedLimit(table *PokerTable) bool {
+	return table.BettingStructure == BettingFixedLimit
+}
+
+// limitSmallBet retorna el tamaño de la apuesta chica (por defecto el big blind)
+func limitSmallBet(table *PokerTable) int {
+	if table.SmallBet > 0 {
+		return table.SmallBet
+	}
+	return table.BigBlind
+}
+
+// limitBigBet retorna el tamaño de la apuesta grande (por defecto el doble de la chica)
+func limitBigBet(table *PokerTable) int {
+	if table.BigBet > 0 {
+		return table.BigBet
+	}
+	return 2 * limitSmallBet(table)
+}
+
+// bigBetPhases son las calles que se juegan con la apuesta grande en fixed limit
+var bigBetPhases = map[string]bool{
+	"turn":             true,
+	"river":            true,
+	"fifth_street":     true,
+	"sixth_street":     true,
+	"seventh_street":   true,
+	"post_second_draw": true,
+	"post_third_draw":  true,
+}
+
+// limitBetSize retorna el tamaño de apuesta de la calle actual en fixed limit
+func limitBetSize(table *PokerTable) int {
+	if bigBetPhases[table.Phase] {
+		return limitBigBet(table)
+	}
+	return limitSmallBet(table)
+}
+
+// validateLimitRaise verifica un raise en fixed limit: tamaño exacto y tope de apuestas por calle
+func (pe *PokerEngine) validateLimitRaise(table *PokerTable, amount int) error {
+	if table.RaiseCount >= limitBetCap {
+		return fmt.Errorf("se alcanzó el máximo de %d apuestas en esta calle", limitBetCap)
+	}
+
+	if betSize := pe.fullRaiseSize(table); amount != betSize {
+		return fmt.Errorf("en fixed limit el raise debe ser exactamente %d", betSize)
+	}
+	return nil
+}
+
+// fullRaiseSize retorna el tamaño de un raise completo en la ronda actual.
+// Un all-in por menos no reabre la apuesta para quienes ya actuaron.
+func (pe *PokerEngine) fullRaiseSize(table *PokerTable) int {
+	if !isFixedLimit(table) {
+		return pe.minRaiseAmount(table)
+	}
+
+	betSize := limitBetSize(table)
+
+	// En stud, completar el bring-in hasta la apuesta chica cuenta como la apuesta de la calle
+	if table.Variant == VariantStud && table.Phase == "third_street" && table.CurrentBet < betSize {
+		betSize -= table.CurrentBet
+	}
+	return betSize
+}
+
+// reopenBetting hace que todos los jugadores que pueden apostar respondan a un raise completo
+func (pe *PokerEngine) reopenBetting(table *PokerTable, raiserIndex int) {
+	clearRaiseLocks(table)
+	for i, player := range table.Players {
+		if player.IsActive && !player.HasFolded && !player.IsAllIn && i != raiserIndex {
+			table.PlayersToAct[i] = true
+		}
+	}
+}
+
+// requireCallOnly obliga a responder a un all-in incompleto sin reabrir la apuesta:
+// quienes ya habían igualado la apuesta anterior solo pueden igualar o retirarse
+func (pe *PokerEngine) requireCallOnly(table *PokerTable, allInIndex, previousBet int) {
+	if len(table.RaiseLocked) != len(table.Players) {
+		table.RaiseLocked = make([]bool, len(table.Players))
+	}
+
+	for i, player := range table.Players {
+		if i == allInIndex || !player.IsActive || player.HasFolded || player.IsAllIn {
+			continue
+		}
+		if !table.PlayersToAct[i] && player.CurrentBet == previousBet {
+			table.PlayersToAct[i] = true
+			table.RaiseLocked[i] = true
+		}
+	}
+}
+
+// isRaiseLocked indica si el jugador solo puede igualar o retirarse
+func isRaiseLocked(table *PokerTable, playerIndex int) bool {
+	return playerIndex < len(table.RaiseLocked) && table.RaiseLocked[playerIndex]
+}
+
+// clearRaiseLocks permite volver a subir a todos los jugadores (nueva calle o raise completo)
+func clearRaiseLocks(table *PokerTable) {
+	for i := range table.RaiseLocked {
+		table.RaiseLocked[i] = false
+	}
+}
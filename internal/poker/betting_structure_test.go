@@ -0,0 +1,169 @@
+package poker
+
+import (
+	"testing"
+)
+
+// limitConfig es la configuración de las mesas de hold'em fixed limit 20/40 de los tests
+var limitConfig = TableConfig{
+	BettingStructure: BettingFixedLimit, SmallBlind: 10, BigBlind: 20,
+	SmallBet: 20, BigBet: 40,
+	BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+}
+
+// TestBettingStructureDefaults verifica la estructura por defecto de cada variante
+func TestBettingStructureDefaults(t *testing.T) {
+	engine := NewPokerEngine()
+
+	tests := []struct {
+		variant   string
+		structure string
+		expected  string
+	}{
+		{variant: "", structure: "", expected: BettingNoLimit},
+		{variant: VariantHoldem, structure: BettingFixedLimit, expected: BettingFixedLimit},
+		{variant: VariantOmaha, structure: "", expected: BettingPotLimit},
+		{variant: VariantOmaha, structure: BettingNoLimit, expected: BettingNoLimit},
+		{variant: VariantStud, structure: "", expected: BettingNoLimit},
+	}
+
+	for i, tt := range tests {
+		table := mustCreateTable(t, engine, tt.variant+tt.structure, TableConfig{
+			Variant: tt.variant, BettingStructure: tt.structure, SmallBlind: 10, BigBlind: 20,
+		})
+		if table.BettingStructure != tt.expected {
+			t.Errorf("Case %d (%s/%s): expected %s, got %s", i, tt.variant, tt.structure, tt.expected, table.BettingStructure)
+		}
+	}
+
+	engine.CreateTable("lobby")
+	err := engine.UpdateTableConfig("lobby", TableConfig{
+		BettingStructure: "spread_limit", SmallBlind: 10, BigBlind: 20,
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+	})
+	if err == nil {
+		t.Error("Unknown betting structure should be rejected")
+	}
+}
+
+// TestFixedLimitRaiseSize verifica que en fixed limit solo se acepte el tamaño exacto
+func TestFixedLimitRaiseSize(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "limit_raise_size", limitConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	player := table.Players[table.CurrentPlayer]
+	for _, amount := range []int{10, 30, 100} {
+		if _, err := engine.PlayerAction(table.ID, player.ID, "raise", amount); err == nil {
+			t.Errorf("Raise of %d should be rejected with a small bet of 20", amount)
+		}
+	}
+
+	if _, err := engine.PlayerAction(table.ID, player.ID, "raise", 20); err != nil {
+		t.Fatalf("Raise of exactly one small bet should be accepted: %v", err)
+	}
+	if table.CurrentBet != 40 || table.RaiseCount != 2 {
+		t.Errorf("Expected current bet 40 and raise count 2, got %d and %d", table.CurrentBet, table.RaiseCount)
+	}
+}
+
+// TestFixedLimitCap verifica el tope de 1 bet + 3 raises por calle (el big blind cuenta como bet)
+func TestFixedLimitCap(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "limit_cap", limitConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	for i := 0; i < 3; i++ {
+		player := table.Players[table.CurrentPlayer]
+		if _, err := engine.PlayerAction(table.ID, player.ID, "raise", 20); err != nil {
+			t.Fatalf("Raise %d should be accepted: %v", i+1, err)
+		}
+	}
+
+	if table.CurrentBet != 80 || table.RaiseCount != limitBetCap {
+		t.Fatalf("Expected capped pot at 80 with %d bets, got %d and %d", limitBetCap, table.CurrentBet, table.RaiseCount)
+	}
+
+	player := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerAction(table.ID, player.ID, "raise", 20); err == nil {
+		t.Error("Fifth bet on the street should be rejected")
+	}
+	if _, err := engine.PlayerAction(table.ID, player.ID, "all_in", 0); err == nil {
+		t.Error("All-in raising a capped street should be rejected")
+	}
+	if _, err := engine.PlayerAction(table.ID, player.ID, "call", 0); err != nil {
+		t.Errorf("Call should be accepted on a capped street: %v", err)
+	}
+}
+
+// TestFixedLimitBigBetStreets verifica que turn y river se jueguen con la apuesta grande
+func TestFixedLimitBigBetStreets(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "limit_big_bet", limitConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	for i := 0; i < 20 && table.Phase != "turn"; i++ {
+		player := table.Players[table.CurrentPlayer]
+		action := "check"
+		if table.CurrentBet > player.CurrentBet {
+			action = "call"
+		}
+		if _, err := engine.PlayerAction(table.ID, player.ID, action, 0); err != nil {
+			t.Fatalf("Unexpected error on %s (%s, phase %s): %v", action, player.Name, table.Phase, err)
+		}
+	}
+
+	if table.Phase != "turn" || table.RaiseCount != 0 {
+		t.Fatalf("Expected turn with no bets, got %s with %d bets", table.Phase, table.RaiseCount)
+	}
+
+	player := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerAction(table.ID, player.ID, "raise", 20); err == nil {
+		t.Error("Small bet should be rejected on the turn")
+	}
+	if _, err := engine.PlayerAction(table.ID, player.ID, "raise", 40); err != nil {
+		t.Errorf("Big bet should be accepted on the turn: %v", err)
+	}
+}
+
+// TestFixedLimitShortAllIn verifica que un all-in por menos de una apuesta sea válido
+func TestFixedLimitShortAllIn(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "limit_all_in", limitConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	current := table.CurrentPlayer
+	table.Players[current].Stack = 30
+	if _, err := engine.PlayerAction(table.ID, table.Players[current].ID, "all_in", 0); err != nil {
+		t.Fatalf("Short all-in should be accepted: %v", err)
+	}
+	if table.CurrentBet != 30 {
+		t.Errorf("Expected current bet 30, got %d", table.CurrentBet)
+	}
+
+	next := table.CurrentPlayer
+	table.Players[next].Stack = 500
+	if _, err := engine.PlayerAction(table.ID, table.Players[next].ID, "all_in", 0); err == nil {
+		t.Error("All-in larger than one bet should be rejected in fixed limit")
+	}
+}
+
+// TestNoLimitAllowsAnyRaise verifica que en no limit se acepte cualquier raise sobre el mínimo
+func TestNoLimitAllowsAnyRaise(t *testing.T) {
+	engine := NewPokerEngine()
+	table := engine.CreateTable("no_limit_raise")
+	engine.AddPlayer(table.ID, "alice", "alice")
+	engine.AddPlayer(table.ID, "bob", "bob")
+	engine.AddPlayer(table.ID, "carol", "carol")
+	for i := range table.Players {
+		table.Players[i].IsReady = true
+	}
+	if _, err := engine.StartGame(table.ID, "alice"); err != nil {
+		t.Fatalf("Error starting game: %v", err)
+	}
+
+	player := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerAction(table.ID, player.ID, "raise", 130); err != nil {
+		t.Errorf("No limit raise of 130 should be accepted: %v", err)
+	}
+}
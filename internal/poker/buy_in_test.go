@@ -21,7 +21,7 @@ func TestBuyInConfiguration(t *testing.T) {
 		RestartDelay: 3 * time.Second,
 	}
 	
-	table := engine.CreateTableWithConfig("buy_in_test", config)
+	table := mustCreateTable(t, engine, "buy_in_test", config)
 	
 	// Verificar configuración
 	if table.SmallBlind != 5 {
@@ -44,6 +44,27 @@ func TestBuyInConfiguration(t *testing.T) {
 	}
 }
 
+// TestCreateTableWithConfigValidates verifica que la mesa no se cree con una configuración inválida
+func TestCreateTableWithConfigValidates(t *testing.T) {
+	engine := NewPokerEngine()
+
+	invalid := map[string]TableConfig{
+		"variante desconocida": {Variant: "razz"},
+		"un solo asiento":      {MaxSeats: 1},
+		"demasiados asientos":  {Variant: VariantStud, MaxSeats: 9},
+		"rake negativo":        {RakePercent: -5, IsCashGame: true},
+		"rake en torneo":       {RakePercent: 5},
+	}
+	for name, config := range invalid {
+		if _, err := engine.CreateTableWithConfig("invalid_"+name, config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if _, err := engine.GetTable("invalid_" + name); err == nil {
+			t.Errorf("%s: the table should not have been created", name)
+		}
+	}
+}
+
 // TestAddPlayerWithBuyIn prueba agregar jugadores con buy-in personalizado
 func TestAddPlayerWithBuyIn(t *testing.T) {
 	engine := NewPokerEngine()
@@ -0,0 +1,163 @@
+package poker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ====== DEJAR LA MESA Y CASH OUT ======
+//
+// Un jugador que deja la mesa se lleva su stack como un cash out. Si está
+// jugando una mano (o se retiró con fichas en el pot), queda sentado afuera y se retira cuando le toca actuar;
+// sus fichas en el pot siguen en juego y el asiento se libera al terminar la
+// mano, con el stack que le quedó. Cada cash out queda en la mesa (visible
+// para los clientes) y se avisa al handler registrado con SetCashOutHandler.
+
+// CashOut es el registro del stack con el que un jugador dejó la mesa
+type CashOut struct {
+	TableID    string    `json:"table_id"`
+	PlayerID   string    `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+	Seat       int       `json:"seat"`
+	Amount     int       `json:"amount"`      // Stack final que se lleva
+	BuyIn      int       `json:"buy_in"`      // Fichas que compró en la mesa
+	HandNumber int       `json:"hand_number"` // Última mano repartida en la mesa
+	Time       time.Time `json:"time"`
+}
+
+// Net retorna la ganancia (o pérdida, si es negativa) de la sesión
+func (c CashOut) Net() int {
+	return c.Amount - c.BuyIn
+}
+
+// SetCashOutHandler registra una función que recibe cada cash out. Se llama con
+// la mesa bloqueada, así que no debe volver a llamar al engine.
+func (pe *PokerEngine) SetCashOutHandler(handler func(CashOut)) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.onCashOut = handler
+}
+
+// LeaveTable saca al jugador de la mesa. Si no está jugando una mano el asiento
+// se libera enseguida y retorna el cash out; si no, el cash out es nil y se
+// registra al terminar la mano.
+func (pe *PokerEngine) LeaveTable(tableID, playerID string) (*PokerTable, *CashOut, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, nil, fmt.Errorf("table not found")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, nil, fmt.Errorf("player not found")
+	}
+
+	player := &table.Players[seat]
+	if player.Leaving {
+		return nil, nil, fmt.Errorf("el jugador ya está dejando la mesa")
+	}
+
+	if !playingHand(table, seat) {
+		cashOut := pe.cashOut(table, seat)
+		return table, &cashOut, nil
+	}
+
+	// Sus fichas siguen en el pot: se retira al actuar y el asiento se libera al terminar la mano
+	player.Leaving = true
+	if !player.SittingOut {
+		pe.sitOut(table, seat)
+	} else if awaitingAction(table) && table.CurrentPlayer == seat {
+		pe.resetShotClock(table)
+	}
+	return table, nil, nil
+}
+
+// SessionCashOuts retorna los cash outs de la sesión (los de quienes ya dejaron la
+// mesa y, para los que siguen sentados, uno provisorio con su stack actual) y el
+// rake cobrado. Falla si hay una mano en juego, porque parte de los stacks está en el pot.
+func (pe *PokerEngine) SessionCashOuts(tableID string) ([]CashOut, int, error) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, 0, fmt.Errorf("table not found")
+	}
+
+	switch table.Phase {
+	case "lobby", "waiting", "showdown":
+	default:
+		return nil, 0, fmt.Errorf("no se puede liquidar la sesión con una mano en juego")
+	}
+
+	cashOuts := append([]CashOut(nil), table.CashOuts...)
+	for seat, player := range table.Players {
+		if !isEmptySeat(player) {
+			cashOuts = append(cashOuts, newCashOut(table, seat))
+		}
+	}
+	return cashOuts, table.RakeTotal, nil
+}
+
+// playingHand indica si el jugador sigue en la mano en curso o tiene fichas en el pot
+// (un jugador que se retiró conserva el asiento hasta que se reparte lo que apostó)
+func playingHand(table *PokerTable, seat int) bool {
+	switch table.Phase {
+	case "lobby", "waiting", "showdown":
+		return false
+	}
+	player := table.Players[seat]
+	return player.IsActive || playerInvestment(player) > 0
+}
+
+// releaseLeavingSeats libera los asientos de los jugadores que dejaron la mesa durante la mano
+func (pe *PokerEngine) releaseLeavingSeats(table *PokerTable) {
+	for seat := range table.Players {
+		if table.Players[seat].Leaving {
+			pe.cashOut(table, seat)
+		}
+	}
+}
+
+// newCashOut arma el cash out del jugador sentado en el asiento
+func newCashOut(table *PokerTable, seat int) CashOut {
+	player := table.Players[seat]
+	return CashOut{
+		TableID:    table.ID,
+		PlayerID:   player.ID,
+		PlayerName: player.Name,
+		Seat:       seat,
+		Amount:     player.Stack + player.PendingBuyIn, // La recompra sin acreditar se devuelve
+		BuyIn:      player.BuyIn + player.PendingBuyIn,
+		HandNumber: table.HandNumber,
+		Time:       time.Now(),
+	}
+}
+
+// cashOut registra el stack del jugador y libera su asiento
+func (pe *PokerEngine) cashOut(table *PokerTable, seat int) CashOut {
+	player := table.Players[seat]
+	record := newCashOut(table, seat)
+	table.CashOuts = append(table.CashOuts, record)
+
+	table.Players[seat] = PokerPlayer{Position: seat}
+
+	// El host pasa al siguiente jugador sentado
+	if player.IsHost {
+		for offset := 1; offset < len(table.Players); offset++ {
+			next := &table.Players[(seat+offset)%len(table.Players)]
+			if !isEmptySeat(*next) {
+				next.IsHost = true
+				break
+			}
+		}
+	}
+
+	if pe.onCashOut != nil {
+		pe.onCashOut(record)
+	}
+	return record
+}
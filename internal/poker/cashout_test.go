@@ -0,0 +1,151 @@
+package poker
+
+import (
+	"testing"
+)
+
+// TestLeaveTableBetweenHands verifica el cash out inmediato fuera de una mano
+func TestLeaveTableBetweenHands(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "leave_between_hands", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+
+	var notified []CashOut
+	engine.SetCashOutHandler(func(cashOut CashOut) { notified = append(notified, cashOut) })
+
+	_, cashOut, err := engine.LeaveTable(table.ID, "seat0")
+	if err != nil {
+		t.Fatalf("Unexpected error leaving: %v", err)
+	}
+	if cashOut == nil || cashOut.Amount != 1000 || cashOut.BuyIn != 1000 || cashOut.Net() != 0 || cashOut.Seat != 0 {
+		t.Fatalf("Expected an immediate cash out of 1000, got %+v", cashOut)
+	}
+	if !isEmptySeat(table.Players[0]) || !table.Players[1].IsHost {
+		t.Error("Seat should be freed and the host passed to the next player")
+	}
+	if len(table.CashOuts) != 1 || len(notified) != 1 || notified[0] != *cashOut {
+		t.Errorf("Expected the cash out on the table and in the handler, got %v / %v", table.CashOuts, notified)
+	}
+
+	if _, _, err := engine.LeaveTable(table.ID, "seat0"); err == nil {
+		t.Error("Leaving twice should be rejected")
+	}
+	if _, _, err := engine.LeaveTable("missing", "seat1"); err == nil {
+		t.Error("Unknown table should be rejected")
+	}
+}
+
+// TestLeaveTableMidHand verifica que quien deja la mesa se retire y cobre al terminar la mano
+func TestLeaveTableMidHand(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "leave_mid_hand", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	// Button 1, small blind 2, big blind 0
+	engine.startHand(table)
+	_, cashOut, err := engine.LeaveTable(table.ID, "seat0")
+	if err != nil || cashOut != nil {
+		t.Fatalf("Expected a pending cash out, got %+v (%v)", cashOut, err)
+	}
+	if _, _, err := engine.LeaveTable(table.ID, "seat0"); err == nil {
+		t.Error("Leaving twice should be rejected")
+	}
+	if _, err := engine.SitIn(table.ID, "seat0"); err == nil {
+		t.Error("A leaving player cannot sit back in")
+	}
+
+	// El big blind podría pasar, pero al dejar la mesa se retira
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "call", 0)
+	if !table.Players[0].HasFolded || table.Phase != "flop" {
+		t.Fatalf("Expected the leaving big blind to fold, got phase %s", table.Phase)
+	}
+	if isEmptySeat(table.Players[0]) || len(table.CashOuts) != 0 {
+		t.Fatal("Seat should stay taken until the hand ends")
+	}
+
+	foldToShowdown(t, engine, table)
+	if !isEmptySeat(table.Players[0]) || len(table.CashOuts) != 1 {
+		t.Fatal("Seat should be freed when the hand ends")
+	}
+	record := table.CashOuts[0]
+	if record.PlayerID != "seat0" || record.Amount != 980 || record.Net() != -20 || record.HandNumber != 1 {
+		t.Errorf("Expected a cash out of 980 after hand 1, got %+v", record)
+	}
+
+	assertContains(t, table.HandHistories[0].Format(""), "seat0: folds")
+	if _, err := ReplayHand(table.HandHistories[0]); err != nil {
+		t.Errorf("Hand with a leaving player should replay: %v", err)
+	}
+	if totalChips(table)+record.Amount != 3000 {
+		t.Errorf("Chips not conserved: %d at the table", totalChips(table))
+	}
+}
+
+// TestLeaveTableAfterFolding verifica que quien se retiró deje sus fichas en el pot hasta terminar la mano
+func TestLeaveTableAfterFolding(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "leave_after_folding", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	// Button 1, small blind 2, big blind 0: UTG iguala y el small blind se retira
+	engine.startHand(table)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "fold", 0)
+
+	_, cashOut, err := engine.LeaveTable(table.ID, "seat2")
+	if err != nil || cashOut != nil {
+		t.Fatalf("Expected a pending cash out, got %+v (%v)", cashOut, err)
+	}
+	if isEmptySeat(table.Players[2]) || table.Players[2].TotalBet+table.Players[2].CurrentBet != 10 {
+		t.Fatal("Folded player's blind should stay in the pot until the hand ends")
+	}
+
+	mustAct(t, engine, table, "check", 0)
+	foldToShowdown(t, engine, table)
+	if !isEmptySeat(table.Players[2]) || len(table.CashOuts) != 1 {
+		t.Fatal("Seat should be freed when the hand ends")
+	}
+	record := table.CashOuts[0]
+	if record.PlayerID != "seat2" || record.Amount != 990 {
+		t.Errorf("Expected a cash out of 990, got %+v", record)
+	}
+	if totalChips(table)+record.Amount != 3000 {
+		t.Errorf("Chips not conserved: %d at the table plus %d cashed out", totalChips(table), record.Amount)
+	}
+}
+
+// TestSessionCashOuts verifica los resultados de la sesión para la liquidación
+func TestSessionCashOuts(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "session_cash_outs", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	if _, _, err := engine.LeaveTable(table.ID, "seat0"); err != nil {
+		t.Fatalf("Unexpected error leaving: %v", err)
+	}
+	table.Players[1].Stack = 1400
+	table.Players[2].Stack = 600
+
+	cashOuts, rake, err := engine.SessionCashOuts(table.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cashOuts) != 3 || cashOuts[0].PlayerID != "seat0" || rake != 0 {
+		t.Fatalf("Expected the cash out of seat0 plus one per seated player, got %+v", cashOuts)
+	}
+	if cashOuts[1].Net() != 400 || cashOuts[2].Net() != -400 {
+		t.Errorf("Expected provisional cash outs with the current stacks, got %+v", cashOuts[1:])
+	}
+	if len(table.CashOuts) != 1 || isEmptySeat(table.Players[1]) {
+		t.Error("Settling should not cash anybody out")
+	}
+
+	engine.startHand(table)
+	if _, _, err := engine.SessionCashOuts(table.ID); err == nil {
+		t.Error("Settling with a hand in play should be rejected")
+	}
+}
@@ -27,6 +27,21 @@ func TestComprehensiveSystemIntegration(t *testing.T) {
 		testCompleteFlowIntegration(t, engine)
 	})
 
+	// Test 4: Min-raise según el último raise completo
+	t.Run("MinRaise", func(t *testing.T) {
+		testMinRaiseIntegration(t, engine)
+	})
+
+	// Test 5: All-in incompleto no reabre la apuesta
+	t.Run("IncompleteAllIn", func(t *testing.T) {
+		testIncompleteAllInIntegration(t, engine)
+	})
+
+	// Test 6: All-in completo sí reabre la apuesta
+	t.Run("FullAllIn", func(t *testing.T) {
+		testFullAllInIntegration(t, engine)
+	})
+
 	t.Log("✅ TODAS LAS PRUEBAS COMPLETADAS EXITOSAMENTE")
 }
 
@@ -159,10 +174,10 @@ func testCompleteFlowIntegration(t *testing.T, engine *PokerEngine) {
 		}
 	}
 	
-	if cardsDealt == len(table.Players) {
+	if cardsDealt == seatedPlayers(table) {
 		t.Log("   ✅ Cartas repartidas a todos los jugadores")
 	} else {
-		t.Errorf("   ❌ Expected cards for %d players, got %d", len(table.Players), cardsDealt)
+		t.Errorf("   ❌ Expected cards for %d players, got %d", seatedPlayers(table), cardsDealt)
 	}
 	
 	// Verificar blinds
@@ -218,4 +233,188 @@ func countActivePlayers(table *PokerTable) int {
 		}
 	}
 	return count
-}
\ No newline at end of file
+}
+
+// mustCreateTable crea una mesa con la configuración dada y falla el test si es rechazada
+func mustCreateTable(t *testing.T, engine *PokerEngine, tableID string, config TableConfig) *PokerTable {
+	t.Helper()
+	table, err := engine.CreateTableWithConfig(tableID, config)
+	if err != nil {
+		t.Fatalf("Error creating table %s: %v", tableID, err)
+	}
+	return table
+}
+
+// newTestTable crea una mesa con la configuración dada y sienta a los jugadores
+// (stack de 1000, en orden de asiento) listos para empezar. La mano no se inicia.
+func newTestTable(t *testing.T, engine *PokerEngine, tableID string, config TableConfig, playerIDs ...string) *PokerTable {
+	t.Helper()
+	table := mustCreateTable(t, engine, tableID, config)
+	for _, id := range playerIDs {
+		if _, err := engine.AddPlayer(tableID, id, id); err != nil {
+			t.Fatalf("Error adding %s: %v", id, err)
+		}
+	}
+	for i := range table.Players {
+		table.Players[i].IsReady = true
+	}
+	return table
+}
+
+// startTestGame inicia la primera mano de la mesa como lo haría el host
+func startTestGame(t *testing.T, engine *PokerEngine, table *PokerTable) {
+	t.Helper()
+	for _, player := range table.Players {
+		if player.IsHost {
+			if _, err := engine.StartGame(table.ID, player.ID); err != nil {
+				t.Fatalf("Error starting game on %s: %v", table.ID, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("Table %s has no host", table.ID)
+}
+
+// bettingConfig es la configuración de las mesas de 4 jugadores (SB=10, BB=20) de los tests de apuestas:
+// UTG (índice 0) actúa primero, el small blind es el índice 2 y el big blind el 3.
+var bettingConfig = TableConfig{
+	SmallBlind: 10, BigBlind: 20,
+	BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000, IsCashGame: true,
+}
+
+// mustAct ejecuta una acción del jugador actual y falla el test si es rechazada
+func mustAct(t *testing.T, engine *PokerEngine, table *PokerTable, action string, amount int) {
+	t.Helper()
+	player := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerAction(table.ID, player.ID, action, amount); err != nil {
+		t.Fatalf("   ❌ %s %s %d rejected: %v", player.Name, action, amount, err)
+	}
+}
+
+func testMinRaiseIntegration(t *testing.T, engine *PokerEngine) {
+	t.Log("🎯 Test 4: Min-raise según el último raise completo")
+
+	table := newTestTable(t, engine, "min_raise_integration", bettingConfig, "alice", "bob", "carol", "dave")
+	startTestGame(t, engine, table)
+
+	// Un raise menor al big blind es rechazado
+	if _, err := engine.PlayerAction(table.ID, "alice", "raise", 10); err == nil {
+		t.Error("   ❌ Raise of 10 should be rejected (big blind is 20)")
+	}
+
+	// Alice sube 50 (apuesta total 70): el próximo raise mínimo es 50
+	mustAct(t, engine, table, "raise", 50)
+	if table.LastRaiseSize != 50 {
+		t.Errorf("   ❌ Expected last raise size 50, got %d", table.LastRaiseSize)
+	}
+
+	if _, err := engine.PlayerAction(table.ID, "bob", "raise", 30); err == nil {
+		t.Error("   ❌ Re-raise of 30 should be rejected after a raise of 50")
+	} else {
+		t.Logf("   ✅ Under-raise rechazado: %v", err)
+	}
+
+	mustAct(t, engine, table, "raise", 50)
+	if table.CurrentBet != 120 {
+		t.Errorf("   ❌ Expected current bet 120, got %d", table.CurrentBet)
+	}
+
+	// Un raise mayor aumenta el mínimo siguiente
+	mustAct(t, engine, table, "raise", 80)
+	if table.LastRaiseSize != 80 || table.CurrentBet != 200 {
+		t.Errorf("   ❌ Expected last raise 80 and bet 200, got %d and %d", table.LastRaiseSize, table.CurrentBet)
+	}
+
+	// Todos igualan: en la nueva calle el mínimo vuelve a ser el big blind
+	for i := 0; i < 10 && table.Phase == "preflop"; i++ {
+		mustAct(t, engine, table, "call", 0)
+	}
+	if table.Phase != "flop" {
+		t.Fatalf("   ❌ Expected flop, got %s", table.Phase)
+	}
+	if table.LastRaiseSize != 0 || engine.minRaiseAmount(table) != table.BigBlind {
+		t.Errorf("   ❌ Min raise should reset to the big blind on a new street, got %d", engine.minRaiseAmount(table))
+	}
+	mustAct(t, engine, table, "raise", 20)
+	t.Log("   ✅ Min-raise correcto en cada calle")
+}
+
+func testIncompleteAllInIntegration(t *testing.T, engine *PokerEngine) {
+	t.Log("🎯 Test 5: All-in incompleto no reabre la apuesta")
+
+	table := newTestTable(t, engine, "incomplete_all_in_integration", bettingConfig, "alice", "bob", "carol", "dave")
+	startTestGame(t, engine, table)
+
+	// Alice sube a 40, Bob iguala, Carol (SB) iguala
+	mustAct(t, engine, table, "raise", 20)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "call", 0)
+
+	// Dave (BB) va all-in por 50 en total: sube solo 10, menos que un raise completo
+	table.Players[3].Stack = 30
+	mustAct(t, engine, table, "all_in", 0)
+	if table.CurrentBet != 50 {
+		t.Fatalf("   ❌ Expected current bet 50, got %d", table.CurrentBet)
+	}
+
+	// Alice ya actuó: solo puede igualar o retirarse
+	if table.CurrentPlayer != 0 {
+		t.Fatalf("   ❌ Expected Alice to act, got %d", table.CurrentPlayer)
+	}
+	if _, err := engine.PlayerAction(table.ID, "alice", "raise", 40); err == nil {
+		t.Error("   ❌ Raise should be rejected after an incomplete all-in")
+	}
+	if _, err := engine.PlayerAction(table.ID, "alice", "all_in", 0); err == nil {
+		t.Error("   ❌ All-in raise should be rejected after an incomplete all-in")
+	}
+
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "fold", 0)
+
+	if table.Phase != "flop" {
+		t.Errorf("   ❌ Expected the round to end on the flop, got %s", table.Phase)
+	}
+	if table.Pot != 50*3+40 {
+		t.Errorf("   ❌ Expected pot %d, got %d", 50*3+40, table.Pot)
+	}
+
+	// Quien todavía no actuó sí puede subir después de un all-in incompleto
+	table = newTestTable(t, engine, "incomplete_all_in_unacted", bettingConfig, "alice", "bob", "carol", "dave")
+	startTestGame(t, engine, table)
+	mustAct(t, engine, table, "raise", 20)
+	table.Players[1].Stack = 50
+	mustAct(t, engine, table, "all_in", 0)
+	if table.CurrentBet != 50 {
+		t.Fatalf("   ❌ Expected current bet 50, got %d", table.CurrentBet)
+	}
+	if _, err := engine.PlayerAction(table.ID, "carol", "raise", 10); err == nil {
+		t.Error("   ❌ Raise below the last full raise (20) should be rejected")
+	}
+	mustAct(t, engine, table, "raise", 20)
+	t.Log("   ✅ All-in incompleto no reabre la apuesta para quienes ya actuaron")
+}
+
+func testFullAllInIntegration(t *testing.T, engine *PokerEngine) {
+	t.Log("🎯 Test 6: All-in completo sí reabre la apuesta")
+
+	table := newTestTable(t, engine, "full_all_in_integration", bettingConfig, "alice", "bob", "carol", "dave")
+	startTestGame(t, engine, table)
+
+	mustAct(t, engine, table, "raise", 20)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "call", 0)
+
+	// Dave va all-in por 100: sube 60, es un raise completo
+	table.Players[3].Stack = 80
+	mustAct(t, engine, table, "all_in", 0)
+	if table.LastRaiseSize != 60 {
+		t.Errorf("   ❌ Expected last raise size 60, got %d", table.LastRaiseSize)
+	}
+
+	if _, err := engine.PlayerAction(table.ID, "alice", "raise", 40); err == nil {
+		t.Error("   ❌ Raise below the all-in raise size (60) should be rejected")
+	}
+	mustAct(t, engine, table, "raise", 60)
+	t.Log("   ✅ All-in completo reabre la apuesta")
+}
@@ -0,0 +1,284 @@
+package poker
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"sync"
+)
+
+// ====== FUENTES DE DECK ======
+//
+// El engine pide un deck nuevo a su DeckSource al empezar cada mano y le pide
+// que rebaraje los descartes en los juegos de draw. En producción se usa
+// CryptoDeckSource; SeededDeckSource repite la misma secuencia de barajados
+// a partir de una semilla y ScriptedDeckSource reparte decks armados a mano
+// para forzar boards y showdowns exactos en tests y QA.
+
+// DeckSource provee los decks que usa el engine
+type DeckSource interface {
+	// NewDeck retorna un deck completo de la variante listo para repartir
+	NewDeck(variant string) []Card
+	// Shuffle baraja las cartas en el lugar
+	Shuffle(cards []Card)
+}
+
+// Tipos de fuente de deck que acepta NewDeckSource
+const (
+	DeckSourceCrypto   = "crypto"
+	DeckSourceSeeded   = "seeded"
+	DeckSourceScripted = "scripted"
+)
+
+// NewDeckSource crea una fuente de deck por nombre (por ejemplo desde la configuración del servidor)
+func NewDeckSource(kind string, seed int64) (DeckSource, error) {
+	switch kind {
+	case "", DeckSourceCrypto:
+		return CryptoDeckSource{}, nil
+	case DeckSourceSeeded:
+		return NewSeededDeckSource(seed), nil
+	case DeckSourceScripted:
+		return NewScriptedDeckSource(), nil
+	default:
+		return nil, fmt.Errorf("fuente de deck desconocida: %s", kind)
+	}
+}
+
+// newDeck crea el deck ordenado que corresponde a la variante
+// (52 cartas, o 36 cartas del 6 al As en short-deck)
+func newDeck(variant string) []Card {
+	suits := []string{"hearts", "diamonds", "clubs", "spades"}
+	ranks := deckRanks(variant)
+
+	deck := make([]Card, 0, len(suits)*len(ranks))
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			deck = append(deck, Card{Suit: suit, Rank: rank})
+		}
+	}
+	return deck
+}
+
+// CryptoDeckSource baraja con crypto/rand (comportamiento por defecto)
+type CryptoDeckSource struct{}
+
+// NewDeck crea y baraja el deck de la variante
+func (CryptoDeckSource) NewDeck(variant string) []Card {
+	deck := newDeck(variant)
+	shuffleCards(deck)
+	return deck
+}
+
+// Shuffle baraja las cartas con crypto/rand
+func (CryptoDeckSource) Shuffle(cards []Card) {
+	shuffleCards(cards)
+}
+
+// shuffleCards baraja las cartas en el lugar usando crypto/rand para seguridad
+func shuffleCards(deck []Card) {
+	for i := len(deck) - 1; i > 0; i-- {
+		j, _ := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		deck[i], deck[j.Int64()] = deck[j.Int64()], deck[i]
+	}
+}
+
+// SeededDeckSource baraja con un PRNG determinístico: la misma semilla
+// produce la misma secuencia de decks
+type SeededDeckSource struct {
+	mu  sync.Mutex
+	rng *mathrand.Rand
+}
+
+// NewSeededDeckSource crea una fuente determinística a partir de la semilla
+func NewSeededDeckSource(seed int64) *SeededDeckSource {
+	return &SeededDeckSource{rng: mathrand.New(mathrand.NewSource(seed))}
+}
+
+// NewDeck crea y baraja el deck de la variante con el PRNG
+func (s *SeededDeckSource) NewDeck(variant string) []Card {
+	deck := newDeck(variant)
+	s.Shuffle(deck)
+	return deck
+}
+
+// Shuffle baraja las cartas con el PRNG
+func (s *SeededDeckSource) Shuffle(cards []Card) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rng.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+}
+
+// ScriptedDeckSource reparte decks armados a mano, uno por mano y en el orden
+// en que se cargaron. Cada deck cargado puede ser parcial: esas cartas van
+// arriba y el resto del deck sigue en orden estándar. Sin decks cargados
+// usa la fuente de respaldo (crypto por defecto). Los decks cargados con
+// PushForTable solo se reparten en esa mesa; los de Push, en la próxima mano
+// de cualquier mesa.
+//
+// Orden de reparto de hold'em y omaha: una carta por vuelta a cada jugador
+// activo en orden de asiento, después quema + 3 cartas de flop, quema + turn
+// y quema + river.
+type ScriptedDeckSource struct {
+	mu       sync.Mutex
+	queue    [][]Card
+	tables   map[string][][]Card // Decks cargados para una mesa puntual
+	fallback DeckSource
+}
+
+// NewScriptedDeckSource crea una fuente con los decks dados en cola
+// (los decks inválidos se ignoran; usar Push para validar)
+func NewScriptedDeckSource(decks ...[]Card) *ScriptedDeckSource {
+	source := &ScriptedDeckSource{tables: make(map[string][][]Card), fallback: CryptoDeckSource{}}
+	for _, deck := range decks {
+		source.Push(deck)
+	}
+	return source
+}
+
+// SetFallback cambia la fuente que se usa cuando no quedan decks cargados
+func (s *ScriptedDeckSource) SetFallback(fallback DeckSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fallback = fallback
+}
+
+// Push agrega un deck al final de la cola
+func (s *ScriptedDeckSource) Push(cards []Card) error {
+	if len(cards) == 0 {
+		return fmt.Errorf("el deck programado está vacío")
+	}
+	if err := validateScriptedCards(cards); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = append(s.queue, append([]Card(nil), cards...))
+	return nil
+}
+
+// PushForTable agrega un deck a la cola de una mesa: solo se reparte en las manos de esa mesa
+func (s *ScriptedDeckSource) PushForTable(tableID string, cards []Card) error {
+	if len(cards) == 0 {
+		return fmt.Errorf("el deck programado está vacío")
+	}
+	if err := validateScriptedCards(cards); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables[tableID] = append(s.tables[tableID], append([]Card(nil), cards...))
+	return nil
+}
+
+// Pending retorna cuántos decks cargados quedan por repartir
+func (s *ScriptedDeckSource) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// NewDeck retorna el próximo deck cargado completado con las cartas que faltan
+func (s *ScriptedDeckSource) NewDeck(variant string) []Card {
+	return s.NewTableDeck("", variant)
+}
+
+// NewTableDeck retorna el próximo deck cargado para la mesa o, si no tiene,
+// el próximo de la cola compartida
+func (s *ScriptedDeckSource) NewTableDeck(tableID, variant string) []Card {
+	s.mu.Lock()
+	var scripted []Card
+	switch {
+	case len(s.tables[tableID]) > 0:
+		scripted = s.tables[tableID][0]
+		s.tables[tableID] = s.tables[tableID][1:]
+	case len(s.queue) > 0:
+		scripted = s.queue[0]
+		s.queue = s.queue[1:]
+	default:
+		fallback := s.fallback
+		s.mu.Unlock()
+		return fallback.NewDeck(variant)
+	}
+	s.mu.Unlock()
+
+	return stackedDeck(variant, scripted)
+}
+
+// stackedDeck arma el deck de la variante con las cartas programadas arriba
+func stackedDeck(variant string, scripted []Card) []Card {
+	full := newDeck(variant)
+	inDeck := make(map[Card]bool, len(full))
+	for _, card := range full {
+		inDeck[card] = true
+	}
+
+	// Cartas programadas arriba (las que no existen en la variante se saltean)
+	deck := make([]Card, 0, len(full))
+	used := make(map[Card]bool, len(scripted))
+	for _, card := range scripted {
+		if inDeck[card] {
+			deck = append(deck, card)
+			used[card] = true
+		}
+	}
+	for _, card := range full {
+		if !used[card] {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+// Shuffle no cambia el orden: los rebarajados también son predecibles
+func (s *ScriptedDeckSource) Shuffle(cards []Card) {}
+
+// validateScriptedCards verifica que las cartas existan y no se repitan
+func validateScriptedCards(cards []Card) error {
+	valid := make(map[Card]bool, 52)
+	for _, card := range newDeck(VariantHoldem) {
+		valid[card] = true
+	}
+
+	seen := make(map[Card]bool, len(cards))
+	for _, card := range cards {
+		if !valid[card] {
+			return fmt.Errorf("carta inválida: %s de %s", card.Rank, card.Suit)
+		}
+		if seen[card] {
+			return fmt.Errorf("carta repetida en el deck: %s", formatCard(card))
+		}
+		seen[card] = true
+	}
+	return nil
+}
+
+// StackDeck carga un deck programado para una próxima mano de la mesa. Solo
+// funciona si el engine se creó con un ScriptedDeckSource.
+func (pe *PokerEngine) StackDeck(tableID string, cards []Card) error {
+	pe.mu.RLock()
+	_, exists := pe.tables[tableID]
+	pe.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("table not found")
+	}
+
+	scripted, ok := pe.deckSource.(*ScriptedDeckSource)
+	if !ok {
+		return fmt.Errorf("el engine no usa un deck programado")
+	}
+	return scripted.PushForTable(tableID, cards)
+}
+
+// createTableDeck pide el deck de la próxima mano de la mesa; con un deck
+// programado se reparten primero los cargados para esa mesa
+func (pe *PokerEngine) createTableDeck(table *PokerTable) []Card {
+	if scripted, ok := pe.deckSource.(*ScriptedDeckSource); ok {
+		return scripted.NewTableDeck(table.ID, table.Variant)
+	}
+	return pe.createShuffledDeckFor(table.Variant)
+}
@@ -102,4 +102,139 @@ func BenchmarkDeckShuffle(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = engine.createShuffledDeck()
 	}
-}
\ No newline at end of file
+}
+// TestSeededDeckSourceDeterministic verifica que la misma semilla repita los barajados
+func TestSeededDeckSourceDeterministic(t *testing.T) {
+	first := NewSeededDeckSource(42)
+	second := NewSeededDeckSource(42)
+	other := NewSeededDeckSource(7)
+
+	for hand := 0; hand < 3; hand++ {
+		deck := first.NewDeck(VariantHoldem)
+		if !sameCards(deck, second.NewDeck(VariantHoldem)) {
+			t.Fatalf("Hand %d: same seed should produce the same deck", hand)
+		}
+		if sameCards(deck, other.NewDeck(VariantHoldem)) {
+			t.Errorf("Hand %d: different seeds produced the same deck", hand)
+		}
+	}
+
+	if deck := first.NewDeck(VariantShortDeck); len(deck) != 36 {
+		t.Errorf("Expected a 36-card short deck, got %d", len(deck))
+	}
+}
+
+// TestScriptedDeckSource verifica el orden de los decks programados y su validación
+func TestScriptedDeckSource(t *testing.T) {
+	source := NewScriptedDeckSource()
+	stacked := []Card{{"spades", "A"}, {"hearts", "2"}, {"clubs", "K"}}
+	if err := source.Push(stacked); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := source.Push([]Card{{"spades", "A"}, {"spades", "A"}}); err == nil {
+		t.Error("Duplicated cards should be rejected")
+	}
+	if err := source.Push([]Card{{"spades", "1"}}); err == nil {
+		t.Error("Invalid cards should be rejected")
+	}
+	if source.Pending() != 1 {
+		t.Fatalf("Expected 1 pending deck, got %d", source.Pending())
+	}
+
+	// Las cartas programadas van arriba y el resto sigue completo
+	deck := source.NewDeck(VariantHoldem)
+	if len(deck) != 52 || !sameCards(deck[:3], stacked) {
+		t.Fatalf("Expected the stacked cards on top of a full deck, got %v", deck[:3])
+	}
+	seen := make(map[Card]bool)
+	for _, card := range deck {
+		if seen[card] {
+			t.Fatalf("Duplicated card in scripted deck: %v", card)
+		}
+		seen[card] = true
+	}
+
+	// Short-deck saltea las cartas que no existen en la variante
+	source.Push(stacked)
+	short := source.NewDeck(VariantShortDeck)
+	if len(short) != 36 || short[0] != stacked[0] || short[1] != stacked[2] {
+		t.Errorf("Expected the 2h to be skipped in short deck, got %v", short[:2])
+	}
+
+	// Sin decks cargados usa la fuente de respaldo
+	source.SetFallback(NewSeededDeckSource(1))
+	if !sameCards(source.NewDeck(VariantHoldem), NewSeededDeckSource(1).NewDeck(VariantHoldem)) {
+		t.Error("Empty queue should fall back to the fallback source")
+	}
+}
+
+// TestScriptedDeckForcesShowdown verifica que un deck programado fuerce el board en el flujo real del engine
+func TestScriptedDeckForcesShowdown(t *testing.T) {
+	source := NewScriptedDeckSource()
+	engine := NewPokerEngineWithDeckSource(source)
+	table := newTestTable(t, engine, "scripted_showdown", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+	table.AutoRestart = false
+
+	// seat0 AA contra seat1 KK; el river le da póker de K a seat1
+	err := engine.StackDeck(table.ID, []Card{
+		{"hearts", "A"}, {"hearts", "K"}, {"diamonds", "A"}, {"diamonds", "K"},
+		{"clubs", "2"}, {"clubs", "K"}, {"spades", "7"}, {"diamonds", "3"},
+		{"diamonds", "2"}, {"hearts", "9"},
+		{"hearts", "2"}, {"spades", "K"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error stacking the deck: %v", err)
+	}
+
+	engine.startHand(table)
+	mustAct(t, engine, table, "all_in", 0) // seat1 es el button y actúa primero
+	mustAct(t, engine, table, "call", 0)
+	// Las calles restantes se cierran con checks hasta el showdown
+	for street := 0; table.Phase != "showdown" && street < 3; street++ {
+		mustAct(t, engine, table, "check", 0)
+	}
+
+	expectedBoard := []Card{{"clubs", "K"}, {"spades", "7"}, {"diamonds", "3"}, {"hearts", "9"}, {"spades", "K"}}
+	if table.Phase != "showdown" || !sameCards(table.CommunityCards, expectedBoard) {
+		t.Fatalf("Expected the scripted board at showdown, got %v (phase %s)", table.CommunityCards, table.Phase)
+	}
+	if table.Players[1].Stack != 2000 || table.Players[0].Stack != 0 {
+		t.Errorf("Expected seat1 to win every chip, got %d / %d", table.Players[0].Stack, table.Players[1].Stack)
+	}
+
+	if err := engine.StackDeck("missing", expectedBoard); err == nil {
+		t.Error("Stacking a deck should fail for an unknown table")
+	}
+	plain := NewPokerEngine()
+	mustCreateTable(t, plain, "plain", seatConfig(6))
+	if err := plain.StackDeck("plain", expectedBoard); err == nil {
+		t.Error("Stacking a deck should fail without a scripted source")
+	}
+}
+
+// TestStackDeckPerTable verifica que el deck programado de una mesa no se reparta en otra
+func TestStackDeckPerTable(t *testing.T) {
+	engine := NewPokerEngineWithDeckSource(NewScriptedDeckSource())
+	other := newTestTable(t, engine, "stack_other", seatConfig(6))
+	mustSeat(t, engine, other, 0, 1)
+	table := newTestTable(t, engine, "stack_target", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+
+	stacked := []Card{{"hearts", "A"}, {"hearts", "K"}, {"diamonds", "A"}, {"diamonds", "K"}}
+	if err := engine.StackDeck(table.ID, stacked); err != nil {
+		t.Fatalf("Unexpected error stacking the deck: %v", err)
+	}
+
+	// La otra mesa empieza primero y no debe llevarse el deck
+	engine.startHand(other)
+	if sameCards(other.Players[0].Cards, []Card{stacked[0], stacked[2]}) {
+		t.Error("Another table dealt the stacked deck")
+	}
+
+	engine.startHand(table)
+	if !sameCards(table.Players[0].Cards, []Card{stacked[0], stacked[2]}) ||
+		!sameCards(table.Players[1].Cards, []Card{stacked[1], stacked[3]}) {
+		t.Errorf("Expected the stacked hole cards, got %v / %v", table.Players[0].Cards, table.Players[1].Cards)
+	}
+}
@@ -0,0 +1,213 @@
+package poker
+
+import "fmt"
+
+// ====== 2-7 TRIPLE DRAW ======
+//
+// Secuencia de fases: predraw (apuestas) → first_draw → post_first_draw (apuestas)
+// → second_draw → post_second_draw → third_draw → post_third_draw → showdown.
+// En las fases de draw cada jugador, en orden, descarta y repone cartas.
+
+// drawRounds mapea cada ronda de apuestas a la fase de descarte que le sigue
+var drawRounds = map[string]string{
+	"predraw":          "first_draw",
+	"post_first_draw":  "second_draw",
+	"post_second_draw": "third_draw",
+}
+
+// bettingAfterDraw mapea cada fase de descarte a la ronda de apuestas que le sigue
+var bettingAfterDraw = map[string]string{
+	"first_draw":  "post_first_draw",
+	"second_draw": "post_second_draw",
+	"third_draw":  "post_third_draw",
+}
+
+// isDrawPhase indica si la fase actual es una ronda de descarte
+func isDrawPhase(phase string) bool {
+	_, ok := bettingAfterDraw[phase]
+	return ok
+}
+
+// advanceDrawRound pasa de una ronda de apuestas a la siguiente ronda de descarte o al showdown
+func (pe *PokerEngine) advanceDrawRound(table *PokerTable) {
+	drawPhase, ok := drawRounds[table.Phase]
+	if !ok {
+		// Después de la última ronda de apuestas va el showdown
+		table.Phase = "showdown"
+		pe.completeHand(table)
+		return
+	}
+
+	table.Phase = drawPhase
+
+	// Descartan los que siguen en la mano; los que están all-in quedan servidos
+	for i, player := range table.Players {
+		table.PlayersToAct[i] = player.IsActive && !player.HasFolded && !player.IsAllIn
+		if player.IsAllIn {
+			table.Players[i].LastDrawCount = 0
+		}
+	}
+
+	// Si nadie puede descartar se pasa directo a la ronda de apuestas
+	if pe.isDrawRoundComplete(table) {
+		pe.startBettingAfterDraw(table)
+		return
+	}
+	pe.setFirstPlayerPostFlop(table)
+	if !table.PlayersToAct[table.CurrentPlayer] {
+		pe.nextPlayer(table)
+	}
+}
+
+// PlayerDraw procesa el descarte de un jugador: descarta las cartas en los índices
+// indicados y recibe la misma cantidad del deck (una lista vacía es "servido")
+func (pe *PokerEngine) PlayerDraw(tableID, playerID string, discardIndices []int) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, err := pe.playerDraw(tableID, playerID, discardIndices)
+	if err == nil {
+		pe.resetShotClock(table)
+	}
+	return table, err
+}
+
+// playerDraw procesa el descarte sin tomar el lock (también lo usa el shot clock)
+func (pe *PokerEngine) playerDraw(tableID, playerID string, discardIndices []int) (*PokerTable, error) {
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	if table.Variant != VariantTripleDraw {
+		return nil, fmt.Errorf("la mesa no es de draw")
+	}
+
+	if !isDrawPhase(table.Phase) {
+		return nil, fmt.Errorf("no es una ronda de descarte")
+	}
+
+	// Encontrar jugador
+	playerIndex := findPlayerSeat(table, playerID)
+	if playerIndex == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	// Verificar turno
+	if table.CurrentPlayer != playerIndex {
+		return nil, fmt.Errorf("not your turn")
+	}
+
+	// Cada jugador descarta una sola vez por ronda
+	if !table.PlayersToAct[playerIndex] {
+		return nil, fmt.Errorf("ya descartaste en esta ronda")
+	}
+
+	player := &table.Players[playerIndex]
+
+	// Validar índices de descarte
+	discard := make(map[int]bool, len(discardIndices))
+	for _, index := range discardIndices {
+		if index < 0 || index >= len(player.Cards) {
+			return nil, fmt.Errorf("índice de descarte inválido: %d", index)
+		}
+		if discard[index] {
+			return nil, fmt.Errorf("índice de descarte repetido: %d", index)
+		}
+		discard[index] = true
+	}
+
+	discarded := make([]Card, 0, len(discard))
+	for i, card := range player.Cards {
+		if discard[i] {
+			discarded = append(discarded, card)
+		}
+	}
+	pe.recordDraw(table, playerIndex, discarded)
+
+	pe.replaceCards(table, player, discard)
+
+	table.PlayersToAct[playerIndex] = false
+
+	// Si todos descartaron, empieza la siguiente ronda de apuestas
+	if pe.isDrawRoundComplete(table) {
+		pe.startBettingAfterDraw(table)
+	} else {
+		pe.nextPlayer(table)
+	}
+
+	return table, nil
+}
+
+// replaceCards mueve las cartas descartadas a la pila de descartes y repone desde el deck
+func (pe *PokerEngine) replaceCards(table *PokerTable, player *PokerPlayer, discard map[int]bool) {
+	// Si el deck no alcanza, se rebarajan los descartes anteriores
+	// (las cartas que descarta este jugador no entran en el nuevo deck)
+	if len(table.Deck) < len(discard) && len(table.Discards) > 0 {
+		table.Deck = append(table.Deck, table.Discards...)
+		table.Discards = nil
+		pe.deckSource.Shuffle(table.Deck)
+	}
+
+	kept := make([]Card, 0, len(player.Cards))
+	for i, card := range player.Cards {
+		if discard[i] {
+			table.Discards = append(table.Discards, card)
+		} else {
+			kept = append(kept, card)
+		}
+	}
+
+	drawn := len(discard)
+	if drawn > len(table.Deck) {
+		drawn = len(table.Deck)
+	}
+	kept = append(kept, table.Deck[:drawn]...)
+	table.Deck = table.Deck[drawn:]
+
+	player.Cards = kept
+	player.LastDrawCount = len(discard)
+}
+
+// isDrawRoundComplete verifica si todos los jugadores en la mano ya descartaron
+func (pe *PokerEngine) isDrawRoundComplete(table *PokerTable) bool {
+	for i, player := range table.Players {
+		if player.IsActive && !player.HasFolded && table.PlayersToAct[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// canStillBet indica si el jugador sigue en la mano y todavía tiene fichas para apostar
+func canStillBet(player PokerPlayer) bool {
+	return player.IsActive && !player.HasFolded && !player.IsAllIn && player.Stack > 0
+}
+
+// startBettingAfterDraw abre la ronda de apuestas que sigue a un descarte
+func (pe *PokerEngine) startBettingAfterDraw(table *PokerTable) {
+	table.Phase = bettingAfterDraw[table.Phase]
+	table.CurrentBet = 0
+	table.LastRaiser = -1
+	table.LastRaiseSize = 0
+	table.RaiseCount = 0
+	clearRaiseLocks(table)
+
+	bettors := 0
+	for i, player := range table.Players {
+		table.PlayersToAct[i] = player.IsActive && !player.HasFolded && !player.IsAllIn
+		if canStillBet(player) {
+			bettors++
+		}
+	}
+
+	// Sin al menos dos jugadores con fichas no hay apuestas: sigue el próximo descarte
+	if bettors < 2 {
+		pe.advanceDrawRound(table)
+		return
+	}
+	pe.setFirstPlayerPostFlop(table)
+	if !table.PlayersToAct[table.CurrentPlayer] {
+		pe.nextPlayer(table)
+	}
+}
@@ -1,9 +1,7 @@
 package poker
 
 import (
-	"crypto/rand"
 	"fmt"
-	"math/big"
 	"sync"
 	"time"
 )
@@ -27,10 +25,27 @@ type PokerPlayer struct {
 	Name       string `json:"name"`
 	Stack      int    `json:"stack"`
 	Cards      []Card `json:"cards"`
+	UpCards    []Card `json:"up_cards"` // Cartas boca arriba visibles para todos (stud)
 	Position   int    `json:"position"`
 	IsActive   bool   `json:"is_active"`
 	HasFolded  bool   `json:"has_folded"`
 	CurrentBet int    `json:"current_bet"`
+	LastDrawCount int `json:"last_draw_count"` // Cartas cambiadas en el último draw (público)
+	TotalBet   int    `json:"total_bet"` // Fichas aportadas en calles anteriores de esta mano
+	AnteBet    int    `json:"ante_bet"`  // Ante puesto en esta mano (dinero muerto, no cuenta como apuesta)
+	StraddleRequested bool `json:"straddle_requested"` // Pidió straddle para la próxima mano
+	RunItTwiceVote int `json:"run_it_twice_vote"` // Runouts pedidos en el run it twice (0 = sin decidir)
+	CardsRevealed bool `json:"cards_revealed"` // Mostró sus cartas en el showdown (visibles para todos)
+	HasMucked     bool `json:"has_mucked"`     // Tiró sus cartas en el showdown sin mostrarlas
+	ClientSeed    string `json:"client_seed,omitempty"` // Semilla que aporta al barajado provably fair
+	TimeBank      time.Duration `json:"time_bank"`      // Tiempo extra que le queda para decidir
+	SittingOut      bool      `json:"sitting_out"`       // Conserva asiento y stack pero no recibe cartas
+	SittingOutSince time.Time `json:"sitting_out_since"` // Desde cuándo está sentado afuera
+	OwedBlinds      int       `json:"owed_blinds"`       // Blinds perdidos que paga al volver a jugar
+	Leaving         bool      `json:"leaving"`           // Dejó la mesa: el asiento se libera al terminar la mano
+	BuyIn           int       `json:"buy_in"`            // Fichas compradas en la mesa (buy-in y recompras)
+	PendingBuyIn    int       `json:"pending_buy_in"`    // Recompra que se acredita al empezar la próxima mano
+	Rebuys          int       `json:"rebuys"`            // Recompras y top-ups acreditados
 	IsReady      bool      `json:"is_ready"`         // Nuevo: ¿Está listo para jugar?
 	IsHost       bool      `json:"is_host"`          // Nuevo: ¿Es el host de la mesa?
 	IsAllIn      bool      `json:"is_all_in"`        // Nuevo: ¿Está en all-in?
@@ -41,24 +56,71 @@ type PokerPlayer struct {
 // PokerTable representa el estado completo de una mesa de poker
 type PokerTable struct {
 	ID               string        `json:"id"`
-	Players          []PokerPlayer `json:"players"`
+	Variant          string        `json:"variant"` // holdem, omaha, short_deck, stud, triple_draw
+	Players          []PokerPlayer `json:"players"`          // Indexado por asiento; los asientos vacíos no tienen ID
+	MaxSeats         int           `json:"max_seats"`        // Cantidad de asientos de la mesa (2-10)
 	CommunityCards   []Card        `json:"community_cards"`
+	Boards           [][]Card      `json:"boards"`           // Un board completo por runout cuando se corre más de una vez
+	Runouts          int           `json:"runouts"`          // Veces que se corrió el board en esta mano
+	RunItTwiceDeadline time.Time   `json:"run_it_twice_deadline"` // Plazo para decidir el run it twice pendiente
 	Pot              int           `json:"pot"`              // Pot principal (legacy, para compatibilidad)
 	SidePots         []SidePot     `json:"side_pots"`        // Sistema de side pots para all-ins múltiples
+	HandResult       *HandResult   `json:"hand_result,omitempty"` // Reparto de la última mano terminada
+	History          *HandHistory   `json:"-"`                     // Historial de la mano en curso
+	HandHistories    []*HandHistory `json:"-"`                     // Últimas manos terminadas (la más vieja primero)
 	CurrentPlayer    int           `json:"current_player"`
 	Phase            string        `json:"phase"` // lobby, preflop, flop, turn, river, showdown
 	Deck             []Card        `json:"-"`     // No enviar en JSON
+	Discards         []Card        `json:"-"`     // Cartas descartadas en juegos de draw
 	StartTime        time.Time     `json:"start_time"`
 	SmallBlind       int           `json:"small_blind"`
 	BigBlind         int           `json:"big_blind"`
-	DealerPosition   int           `json:"dealer_position"`
+	BettingStructure string        `json:"betting_structure"` // no_limit, pot_limit, fixed_limit
+	SmallBet         int           `json:"small_bet"`         // Apuesta chica en fixed limit (por defecto el big blind)
+	BigBet           int           `json:"big_bet"`           // Apuesta grande en fixed limit (por defecto 2x la chica)
+	RaiseCount       int           `json:"raise_count"`       // Apuestas y raises en la calle actual
+	Ante             int           `json:"ante"`              // Ante por jugador, o total de la mesa con big blind ante
+	AnteType         string        `json:"ante_type"`         // classic, big_blind
+	Straddle         string        `json:"straddle"`          // off, utg, mississippi
+	RunItTwice       bool          `json:"run_it_twice"`      // Si la mesa ofrece correr el board varias veces en all-ins
+	BringIn          int           `json:"bring_in"`          // Apuesta forzada de la carta más baja (stud)
+	DealerPosition   int           `json:"dealer_position"`   // Asiento del button (puede ser un dead button)
+	SmallBlindSeat   int           `json:"small_blind_seat"`  // Asiento del small blind (muerto si el asiento no juega la mano)
+	BigBlindSeat     int           `json:"big_blind_seat"`    // Asiento del big blind
+	HandNumber       int           `json:"hand_number"`       // Manos repartidas en la mesa
 	CurrentBet       int           `json:"current_bet"`       // Apuesta actual más alta en esta ronda
 	LastRaiser       int           `json:"last_raiser"`       // Índice del último jugador que subió
+	LastRaiseSize    int           `json:"last_raise_size"`   // Tamaño del último raise completo en esta ronda
+	LastAggressor    int           `json:"last_aggressor"`    // Último que apostó o subió en la ronda de apuestas anterior (-1 si nadie)
+	ShowdownOrder    []int         `json:"showdown_order"`    // Asientos en el orden en que muestran en el showdown
 	PlayersToAct     []bool        `json:"players_to_act"`    // Qué jugadores necesitan actuar en esta ronda
+	RaiseLocked      []bool        `json:"raise_locked"`      // Jugadores que solo pueden igualar o retirarse (all-in incompleto)
 	BettingComplete  bool          `json:"betting_complete"`  // Si la ronda de apuestas está completa
 	AutoRestart      bool          `json:"auto_restart"`      // Si las manos se reinician automáticamente
 	ShowdownEndTime  time.Time     `json:"-"`                 // Tiempo cuando terminó el showdown
 	RestartDelay     time.Duration `json:"-"`                 // Retraso antes del auto-restart (ej: 5 segundos)
+
+	// Shot clock (ver shotclock.go)
+	ActionTimeout    time.Duration `json:"action_timeout"`   // Tiempo para actuar (0 = sin shot clock)
+	TimeBank         time.Duration `json:"time_bank"`        // Time bank inicial de cada jugador
+	ActionSeat       int           `json:"action_seat"`      // Asiento con el plazo en curso (-1 si no hay)
+	ActionDeadline   time.Time     `json:"action_deadline"`  // Momento en que vence el plazo del jugador
+	TimeBankActive   bool          `json:"time_bank_active"` // Si el jugador está gastando su time bank
+	TimeBankStart    time.Time     `json:"-"`                // Desde cuándo corre el time bank
+
+	// Barajado provably fair (ver fairness.go)
+	NextSeedHash     string         `json:"next_seed_hash"`     // Compromiso del server seed de la próxima mano
+	NextServerSeed   string         `json:"-"`                  // Server seed de la próxima mano (secreto)
+	ServerSeed       string         `json:"-"`                  // Server seed de la mano en curso (se revela al terminar)
+	Fairness         *FairnessProof `json:"fairness,omitempty"` // Prueba de la mano en curso o de la última terminada
+
+	CashOuts         []CashOut     `json:"cash_outs"`         // Jugadores que dejaron la mesa y su stack final
+
+	// Rake (ver rake.go)
+	RakePercent      float64       `json:"rake_percent"`      // Porcentaje de cada pot para la casa (0 = sin rake)
+	RakeCap          int           `json:"rake_cap"`          // Máximo de rake por mano (0 = sin tope)
+	NoFlopNoDrop     bool          `json:"no_flop_no_drop"`   // Sin rake si la mano termina antes del flop
+	RakeTotal        int           `json:"rake_total"`        // Rake cobrado en la mesa desde que se creó
 	
 	// Configuración de Buy-in
 	BuyInAmount      int           `json:"buy_in_amount"`     // Cantidad estándar de buy-in
@@ -69,25 +131,58 @@ type PokerTable struct {
 
 // TableConfig representa la configuración para crear una mesa personalizada
 type TableConfig struct {
+	Variant      string        `json:"variant"`       // holdem (por defecto), omaha, short_deck, stud
 	SmallBlind   int           `json:"small_blind"`   // Blind pequeño
-	BigBlind     int           `json:"big_blind"`     // Blind grande
+	BigBlind     int           `json:"big_blind"`     // Blind grande (en stud, apuesta mínima)
+	BettingStructure string    `json:"betting_structure"` // no_limit, pot_limit, fixed_limit (por defecto según la variante)
+	SmallBet     int           `json:"small_bet"`     // Apuesta chica en fixed limit
+	BigBet       int           `json:"big_bet"`       // Apuesta grande en fixed limit
+	Ante         int           `json:"ante"`          // Ante por jugador, o total de la mesa con big blind ante
+	AnteType     string        `json:"ante_type"`     // classic (por defecto) o big_blind
+	Straddle     string        `json:"straddle"`      // off (por defecto), utg o mississippi
+	MaxSeats     int           `json:"max_seats"`     // Asientos de la mesa, 2-10 (por defecto el máximo de la variante)
+	RunItTwice   bool          `json:"run_it_twice"`  // Ofrecer run it twice cuando todos están all-in
+	BringIn      int           `json:"bring_in"`      // Bring-in (stud, por defecto el small blind)
 	BuyInAmount  int           `json:"buy_in_amount"` // Cantidad estándar de buy-in
 	MinBuyIn     int           `json:"min_buy_in"`    // Buy-in mínimo permitido
 	MaxBuyIn     int           `json:"max_buy_in"`    // Buy-in máximo permitido
 	IsCashGame   bool          `json:"is_cash_game"`  // true = cash game, false = torneo
 	AutoRestart  bool          `json:"auto_restart"`  // Si las manos se reinician automáticamente
 	RestartDelay time.Duration `json:"restart_delay"` // Retraso antes del auto-restart
+	ActionTimeout time.Duration `json:"action_timeout"` // Tiempo para actuar antes del check/fold automático (0 = sin shot clock)
+	TimeBank      time.Duration `json:"time_bank"`      // Time bank de cada jugador al sentarse
+	RakePercent   float64       `json:"rake_percent"`    // Porcentaje de cada pot para la casa, solo cash games (0 = sin rake)
+	RakeCap       int           `json:"rake_cap"`        // Máximo de rake por mano (0 = sin tope)
+	NoFlopNoDrop  bool          `json:"no_flop_no_drop"` // Sin rake si la mano termina antes del flop
 }
 
 // PokerEngine maneja la lógica del poker
 type PokerEngine struct {
 	mu     sync.RWMutex
 	tables map[string]*PokerTable
+
+	// deckSource provee un deck nuevo por mano (crypto, con semilla o programado)
+	deckSource DeckSource
+
+	shotClocks map[string]*time.Timer  // Plazo en curso de cada mesa
+	onUpdate   func(tableID string)    // Aviso de cambios que no vienen de un jugador
+	onCashOut  func(CashOut)           // Aviso de cada jugador que deja una mesa
+	onRake     func(Rake)              // Aviso del rake de cada mano
 }
 
 func NewPokerEngine() *PokerEngine {
+	return NewPokerEngineWithDeckSource(CryptoDeckSource{})
+}
+
+// NewPokerEngineWithDeckSource crea un engine que baraja con la fuente dada
+func NewPokerEngineWithDeckSource(source DeckSource) *PokerEngine {
+	if source == nil {
+		source = CryptoDeckSource{}
+	}
 	return &PokerEngine{
-		tables: make(map[string]*PokerTable),
+		tables:     make(map[string]*PokerTable),
+		deckSource: source,
+		shotClocks: make(map[string]*time.Timer),
 	}
 }
 
@@ -95,19 +190,27 @@ func NewPokerEngine() *PokerEngine {
 func (pe *PokerEngine) createTableInternal(tableID string) *PokerTable {
 	table := &PokerTable{
 		ID:             tableID,
-		Players:        make([]PokerPlayer, 0, 10), // Soportar hasta 10 jugadores
+		Variant:        VariantHoldem,
+		BettingStructure: BettingNoLimit,
+		AnteType:       AnteClassic,
+		Straddle:       StraddleOff,
+		Players:        newSeatMap(maxTableSeats), // Soportar hasta 10 jugadores
+		MaxSeats:       maxTableSeats,
 		CommunityCards: make([]Card, 0, 5),
 		Pot:            0,
 		SidePots:       make([]SidePot, 0),         // Inicializar sistema de side pots
 		CurrentPlayer:  0,
 		Phase:          "waiting",
-		Deck:           pe.createShuffledDeck(),
+		Deck:           newDeck(VariantHoldem), // Se baraja al empezar cada mano
 		StartTime:      time.Now(),
 		SmallBlind:     10,  // Default blinds
 		BigBlind:       20,
 		DealerPosition: 0,
 		AutoRestart:    true,              // Por defecto auto-restart habilitado
 		RestartDelay:   5 * time.Second,   // 5 segundos de delay por defecto
+		ActionTimeout:  0,                 // Sin shot clock salvo que la mesa lo configure
+		TimeBank:       defaultTimeBank,
+		ActionSeat:     -1,
 		
 		// Configuración de Buy-in por defecto
 		BuyInAmount:    1000,              // Buy-in estándar de 1000
@@ -115,6 +218,7 @@ func (pe *PokerEngine) createTableInternal(tableID string) *PokerTable {
 		MaxBuyIn:       2000,              // Máximo 2000 (100BB)
 		IsCashGame:     true,              // Por defecto cash game
 	}
+	pe.commitNextSeed(table)
 	pe.tables[tableID] = table
 	return table
 }
@@ -136,44 +240,67 @@ func (pe *PokerEngine) CreateTable(tableID string) *PokerTable {
 func (pe *PokerEngine) createTableWithConfigInternal(tableID string, config TableConfig) *PokerTable {
 	table := &PokerTable{
 		ID:             tableID,
-		Players:        make([]PokerPlayer, 0, 10),
+		Variant:        normalizeVariant(config.Variant),
+		Players:        newSeatMap(normalizeSeatCount(config.MaxSeats, config.Variant)),
+		MaxSeats:       normalizeSeatCount(config.MaxSeats, config.Variant),
 		CommunityCards: make([]Card, 0, 5),
 		Pot:            0,
 		SidePots:       make([]SidePot, 0),
 		CurrentPlayer:  0,
 		Phase:          "waiting",
-		Deck:           pe.createShuffledDeck(),
+		Deck:           newDeck(config.Variant),
 		StartTime:      time.Now(),
 		SmallBlind:     config.SmallBlind,
 		BigBlind:       config.BigBlind,
+		BettingStructure: normalizeBettingStructure(config.BettingStructure, config.Variant),
+		SmallBet:       config.SmallBet,
+		BigBet:         config.BigBet,
+		Ante:           config.Ante,
+		AnteType:       normalizeAnteType(config.AnteType),
+		Straddle:       normalizeStraddleMode(config.Straddle),
+		RunItTwice:     config.RunItTwice,
+		BringIn:        config.BringIn,
 		DealerPosition: 0,
 		AutoRestart:    config.AutoRestart,
 		RestartDelay:   config.RestartDelay,
+		ActionTimeout:  config.ActionTimeout,
+		TimeBank:       config.TimeBank,
+		ActionSeat:     -1,
 		
 		// Configuración de Buy-in personalizada
 		BuyInAmount:    config.BuyInAmount,
 		MinBuyIn:       config.MinBuyIn,
 		MaxBuyIn:       config.MaxBuyIn,
 		IsCashGame:     config.IsCashGame,
+
+		RakePercent:    config.RakePercent,
+		RakeCap:        config.RakeCap,
+		NoFlopNoDrop:   config.NoFlopNoDrop,
 	}
+	pe.commitNextSeed(table)
 	pe.tables[tableID] = table
 	return table
 }
 
-// CreateTableWithConfig crea una mesa con configuración personalizada
-func (pe *PokerEngine) CreateTableWithConfig(tableID string, config TableConfig) *PokerTable {
+// CreateTableWithConfig crea una mesa con configuración personalizada.
+// Falla si la configuración no es válida (las mismas reglas que UpdateTableConfig).
+func (pe *PokerEngine) CreateTableWithConfig(tableID string, config TableConfig) (*PokerTable, error) {
 	pe.mu.Lock()
 	defer pe.mu.Unlock()
 	
 	// Verificar si ya existe
 	if existing, exists := pe.tables[tableID]; exists {
-		return existing
+		return existing, nil
+	}
+
+	if err := validateTableConfig(config); err != nil {
+		return nil, err
 	}
 	
-	return pe.createTableWithConfigInternal(tableID, config)
+	return pe.createTableWithConfigInternal(tableID, config), nil
 }
 
-// AddPlayer agrega un jugador a la mesa
+// AddPlayer agrega un jugador a la mesa en el primer asiento libre
 func (pe *PokerEngine) AddPlayer(tableID, playerID, playerName string) (*PokerTable, error) {
 	pe.mu.Lock()
 	defer pe.mu.Unlock()
@@ -184,46 +311,9 @@ func (pe *PokerEngine) AddPlayer(tableID, playerID, playerName string) (*PokerTa
 		table = pe.createTableInternal(tableID)
 	}
 
-	// Verificar si el jugador ya existe
-	for _, player := range table.Players {
-		if player.ID == playerID {
-			return table, fmt.Errorf("player already at table")
-		}
-	}
-
-	// Verificar límite de jugadores
-	if len(table.Players) >= 10 {
-		return table, fmt.Errorf("table is full")
-	}
-
-	// Determinar si es el host (primer jugador)
-	isHost := len(table.Players) == 0
-	
-	// Agregar jugador
-	player := PokerPlayer{
-		ID:           playerID,
-		Name:         playerName,
-		Stack:        1000, // Stack inicial
-		Cards:        make([]Card, 0, 2),
-		Position:     len(table.Players),
-		IsActive:     true,
-		HasFolded:    false,
-		CurrentBet:   0,
-		IsReady:      false, // Por defecto no está listo
-		IsHost:       isHost, // Primer jugador es host
-		IsConnected:  true,   // Conectado al agregarlo
-		LastSeenTime: time.Now(),
-	}
-
-	table.Players = append(table.Players, player)
-
-	// YA NO auto-start - Solo cambiar fase si está "waiting" → "lobby"
-	if table.Phase == "waiting" && len(table.Players) >= 1 {
-		table.Phase = "lobby" // Nuevo estado: esperando que los jugadores estén listos
-	} else if table.Phase != "waiting" && table.Phase != "lobby" {
-		// Si hay una mano en progreso, el jugador debe esperar a la siguiente mano
-		// Marcar como inactivo hasta la siguiente mano
-		table.Players[len(table.Players)-1].IsActive = false
+	// Stack inicial estándar; YA NO auto-start, el jugador queda en el lobby
+	if err := pe.seatPlayer(table, playerID, playerName, 1000, -1); err != nil {
+		return table, err
 	}
 
 	return table, nil
@@ -247,45 +337,9 @@ func (pe *PokerEngine) AddPlayerWithBuyIn(tableID, playerID, playerName string,
 		return table, fmt.Errorf("buy-in amount %d is above maximum %d", buyInAmount, table.MaxBuyIn)
 	}
 
-	// Verificar si el jugador ya existe
-	for _, player := range table.Players {
-		if player.ID == playerID {
-			return table, fmt.Errorf("player already at table")
-		}
-	}
-
-	// Verificar límite de jugadores
-	if len(table.Players) >= 10 {
-		return table, fmt.Errorf("table is full")
-	}
-
-	// Determinar si es el host (primer jugador)
-	isHost := len(table.Players) == 0
-	
-	// Agregar jugador con buy-in personalizado
-	player := PokerPlayer{
-		ID:           playerID,
-		Name:         playerName,
-		Stack:        buyInAmount, // Stack inicial basado en buy-in
-		Cards:        make([]Card, 0, 2),
-		Position:     len(table.Players),
-		IsActive:     true,
-		HasFolded:    false,
-		CurrentBet:   0,
-		IsReady:      false,
-		IsHost:       isHost,
-		IsConnected:  true,
-		LastSeenTime: time.Now(),
-	}
-
-	table.Players = append(table.Players, player)
-
-	// Cambiar fase si está "waiting" → "lobby"
-	if table.Phase == "waiting" && len(table.Players) >= 1 {
-		table.Phase = "lobby"
-	} else if table.Phase != "waiting" && table.Phase != "lobby" {
-		// Si hay una mano en progreso, el jugador debe esperar a la siguiente mano
-		table.Players[len(table.Players)-1].IsActive = false
+	// Stack inicial basado en buy-in, en el primer asiento libre
+	if err := pe.seatPlayer(table, playerID, playerName, buyInAmount, -1); err != nil {
+		return table, err
 	}
 
 	return table, nil
@@ -293,20 +347,16 @@ func (pe *PokerEngine) AddPlayerWithBuyIn(tableID, playerID, playerName string,
 
 // SetPlayerReady marca a un jugador como listo/no listo
 func (pe *PokerEngine) SetPlayerReady(tableID, playerID string, ready bool) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return nil, fmt.Errorf("table not found")
 	}
 
 	// Encontrar jugador
-	playerIndex := -1
-	for i, player := range table.Players {
-		if player.ID == playerID {
-			playerIndex = i
-			break
-		}
-	}
-
+	playerIndex := findPlayerSeat(table, playerID)
 	if playerIndex == -1 {
 		return nil, fmt.Errorf("player not found")
 	}
@@ -322,21 +372,17 @@ func (pe *PokerEngine) SetPlayerReady(tableID, playerID string, ready bool) (*Po
 
 // StartGame inicia el juego manualmente (solo por el host)
 func (pe *PokerEngine) StartGame(tableID, playerID string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return nil, fmt.Errorf("table not found")
 	}
 
 	// Verificar que sea el host
-	var isHost bool
-	for _, player := range table.Players {
-		if player.ID == playerID && player.IsHost {
-			isHost = true
-			break
-		}
-	}
-
-	if !isHost {
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 || !table.Players[seat].IsHost {
 		return nil, fmt.Errorf("only the host can start the game")
 	}
 
@@ -346,13 +392,13 @@ func (pe *PokerEngine) StartGame(tableID, playerID string) (*PokerTable, error)
 	}
 
 	// Verificar que haya al menos 2 jugadores
-	if len(table.Players) < 2 {
+	if seatedPlayers(table) < 2 {
 		return nil, fmt.Errorf("need at least 2 players to start")
 	}
 
 	// Verificar que todos estén listos
 	for _, player := range table.Players {
-		if !player.IsReady {
+		if !isEmptySeat(player) && !player.IsReady {
 			return nil, fmt.Errorf("all players must be ready to start (player %s is not ready)", player.Name)
 		}
 	}
@@ -364,6 +410,9 @@ func (pe *PokerEngine) StartGame(tableID, playerID string) (*PokerTable, error)
 
 // GetReadyStatus obtiene el estado de "ready" de todos los jugadores
 func (pe *PokerEngine) GetReadyStatus(tableID string) (map[string]bool, error) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return nil, fmt.Errorf("table not found")
@@ -371,6 +420,9 @@ func (pe *PokerEngine) GetReadyStatus(tableID string) (map[string]bool, error) {
 
 	status := make(map[string]bool)
 	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
 		status[player.Name] = player.IsReady
 	}
 
@@ -379,30 +431,52 @@ func (pe *PokerEngine) GetReadyStatus(tableID string) (map[string]bool, error) {
 
 // StartHand inicia una nueva mano (exportado para testing)
 func (pe *PokerEngine) StartHand(table *PokerTable) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
 	pe.startHand(table)
 }
 
 // startHand inicia una nueva mano
 func (pe *PokerEngine) startHand(table *PokerTable) {
-	// Reiniciar deck
-	table.Deck = pe.createShuffledDeck()
+	// El shot clock arranca con el primer jugador en actuar
+	defer pe.resetShotClock(table)
+
 	table.CommunityCards = make([]Card, 0, 5)
+	table.Boards = nil
+	table.Runouts = 0
+	table.RunItTwiceDeadline = time.Time{}
+	table.Discards = nil
 	table.Pot = 0
 	table.SidePots = make([]SidePot, 0) // Reiniciar side pots para nueva mano
+	table.HandResult = nil
 	table.Phase = "preflop"
 	table.CurrentBet = table.BigBlind // La apuesta inicial es el big blind
 	table.LastRaiser = -1
+	table.LastRaiseSize = 0
+	table.RaiseCount = 0
+	table.LastAggressor = -1
+	table.ShowdownOrder = nil
 	table.BettingComplete = false
 
+	// Acreditar las recompras pedidas durante la mano anterior
+	pe.applyPendingBuyIns(table)
+
 	// Contar jugadores activos y reactivar a todos los que tienen fichas
 	activePlayers := make([]int, 0)
 	for i := range table.Players {
-		table.Players[i].Cards = make([]Card, 0, 2)
+		table.Players[i].Cards = make([]Card, 0, holeCardCount(table.Variant))
+		table.Players[i].UpCards = nil
+		table.Players[i].LastDrawCount = 0
 		table.Players[i].HasFolded = false
 		table.Players[i].CurrentBet = 0
+		table.Players[i].TotalBet = 0
+		table.Players[i].AnteBet = 0
+		table.Players[i].RunItTwiceVote = 0
+		table.Players[i].CardsRevealed = false
+		table.Players[i].HasMucked = false
 		table.Players[i].IsAllIn = false // Reiniciar estado de all-in
 		// Reactivar todos los jugadores que tienen fichas (incluyendo los que llegaron durante la mano anterior)
-		table.Players[i].IsActive = table.Players[i].Stack > 0 && table.Players[i].IsConnected
+		table.Players[i].IsActive = table.Players[i].Stack > 0 && table.Players[i].IsConnected && !table.Players[i].SittingOut
 		
 		if table.Players[i].IsActive {
 			activePlayers = append(activePlayers, i)
@@ -411,6 +485,7 @@ func (pe *PokerEngine) startHand(table *PokerTable) {
 
 	// Inicializar array de jugadores que necesitan actuar
 	table.PlayersToAct = make([]bool, len(table.Players))
+	table.RaiseLocked = make([]bool, len(table.Players))
 	for _, playerIndex := range activePlayers {
 		table.PlayersToAct[playerIndex] = true
 	}
@@ -420,22 +495,57 @@ func (pe *PokerEngine) startHand(table *PokerTable) {
 		return
 	}
 
-	// Avanzar dealer position
-	table.DealerPosition = (table.DealerPosition + 1) % len(activePlayers)
+	table.HandNumber++
 
-	// Repartir cartas (2 por jugador)
-	pe.dealCards(table)
+	// Barajar con el server seed comprometido y las client seeds
+	table.Deck = pe.shuffleHandDeck(table, activePlayers)
+	pe.beginHandHistory(table)
+
+	// Stud no usa blinds ni button para decidir quién actúa
+	if table.Variant == VariantStud {
+		pe.startStudHand(table, activePlayers)
+		pe.recordHoleCards(table)
+		return
+	}
+
+	// Mover el button y los blinds asiento por asiento (dead button)
+	previousBigBlind := table.BigBlindSeat
+	pe.moveButton(table, activePlayers)
+	if table.HandNumber > 1 {
+		pe.trackMissedBlinds(table, previousBigBlind)
+	}
 
-	// Colocar blinds
-	pe.postBlinds(table, activePlayers)
+	// Repartir cartas privadas (2 en Hold'em, 4 en Omaha)
+	pe.dealCards(table)
 
-	// Establecer primer jugador (después del big blind)
-	if len(activePlayers) > 2 {
-		table.CurrentPlayer = (table.DealerPosition + 3) % len(activePlayers)
+	// Colocar antes y blinds (el big blind cuenta como la primera apuesta de la calle)
+	if table.AnteType == AnteBigBlind {
+		pe.postBlinds(table, activePlayers)
+		pe.postBigBlindAnte(table, table.BigBlindSeat)
 	} else {
-		// Heads-up: el small blind (dealer) actúa primero preflop
-		table.CurrentPlayer = activePlayers[table.DealerPosition]
+		pe.postAntes(table, activePlayers)
+		pe.postBlinds(table, activePlayers)
+	}
+	pe.postOwedBlinds(table, activePlayers)
+	table.RaiseCount = 1
+
+	// Straddle voluntario: actúa primero el jugador a su izquierda y él actúa último
+	straddlerIndex := pe.postStraddle(table, activePlayers)
+
+	// En triple draw la primera ronda de apuestas es antes del primer descarte
+	if table.Variant == VariantTripleDraw {
+		table.Phase = "predraw"
 	}
+
+	// Establecer primer jugador: el siguiente después del big blind, o del straddle.
+	// Heads-up es el small blind (button).
+	table.CurrentPlayer = table.BigBlindSeat
+	if straddlerIndex != -1 {
+		table.CurrentPlayer = straddlerIndex
+	}
+	pe.nextPlayer(table)
+
+	pe.recordHoleCards(table)
 }
 
 // postBlinds coloca los blinds automáticamente
@@ -444,28 +554,19 @@ func (pe *PokerEngine) postBlinds(table *PokerTable, activePlayers []int) {
 		return
 	}
 
-	var sbPlayerIndex, bbPlayerIndex int
-
-	if len(activePlayers) == 2 {
-		// Heads-up: dealer es small blind, otro jugador es big blind
-		sbPlayerIndex = activePlayers[table.DealerPosition]
-		bbPlayerIndex = activePlayers[(table.DealerPosition + 1) % len(activePlayers)]
-	} else {
-		// Multi-way: small blind está a la izquierda del dealer
-		sbPosition := (table.DealerPosition + 1) % len(activePlayers)
-		sbPlayerIndex = activePlayers[sbPosition]
-		bbPosition := (table.DealerPosition + 2) % len(activePlayers)
-		bbPlayerIndex = activePlayers[bbPosition]
-	}
+	sbPlayerIndex, bbPlayerIndex := pe.blindPositions(table, activePlayers)
 
-	// Colocar small blind
-	sbAmount := table.SmallBlind
-	if table.Players[sbPlayerIndex].Stack < sbAmount {
-		sbAmount = table.Players[sbPlayerIndex].Stack
+	// Colocar small blind (si el asiento quedó vacío, el small blind está muerto)
+	if sbPlayerIndex != -1 {
+		sbAmount := table.SmallBlind
+		if table.Players[sbPlayerIndex].Stack < sbAmount {
+			sbAmount = table.Players[sbPlayerIndex].Stack
+		}
+		table.Players[sbPlayerIndex].Stack -= sbAmount
+		table.Players[sbPlayerIndex].CurrentBet = sbAmount
+		table.Pot += sbAmount
+		pe.recordAction(table, sbPlayerIndex, "small_blind", sbAmount, 0)
 	}
-	table.Players[sbPlayerIndex].Stack -= sbAmount
-	table.Players[sbPlayerIndex].CurrentBet = sbAmount
-	table.Pot += sbAmount
 
 	// Colocar big blind
 	bbAmount := table.BigBlind
@@ -475,6 +576,7 @@ func (pe *PokerEngine) postBlinds(table *PokerTable, activePlayers []int) {
 	table.Players[bbPlayerIndex].Stack -= bbAmount
 	table.Players[bbPlayerIndex].CurrentBet = bbAmount
 	table.Pot += bbAmount
+	pe.recordAction(table, bbPlayerIndex, "big_blind", bbAmount, 0)
 
 	// Los blinds ya han "actuado" para esta ronda preflop
 	// Pero el big blind puede aún hacer raise si vuelve a él
@@ -483,17 +585,37 @@ func (pe *PokerEngine) postBlinds(table *PokerTable, activePlayers []int) {
 		table.PlayersToAct[sbPlayerIndex] = true  // Small blind debe completar apuesta al big blind
 		table.PlayersToAct[bbPlayerIndex] = true // Big blind puede hacer raise cuando le toque
 	} else {
-		table.PlayersToAct[sbPlayerIndex] = false // Small blind ya puso su apuesta obligatoria
+		if sbPlayerIndex != -1 {
+			table.PlayersToAct[sbPlayerIndex] = false // Small blind ya puso su apuesta obligatoria
+		}
 		table.PlayersToAct[bbPlayerIndex] = true  // Big blind puede hacer raise cuando le toque
 	}
+
+	// Un blind que deja al jugador sin fichas (por ejemplo después del ante) lo pone all-in
+	for _, playerIndex := range []int{sbPlayerIndex, bbPlayerIndex} {
+		if playerIndex != -1 && table.Players[playerIndex].Stack == 0 {
+			table.Players[playerIndex].IsAllIn = true
+			table.PlayersToAct[playerIndex] = false
+		}
+	}
+}
+
+// blindPositions retorna los asientos del small blind y del big blind de la mano.
+// El small blind es -1 si está muerto (su asiento no juega la mano).
+func (pe *PokerEngine) blindPositions(table *PokerTable, activePlayers []int) (int, int) {
+	sbSeat := table.SmallBlindSeat
+	if !isActiveSeat(table, sbSeat) {
+		sbSeat = -1
+	}
+	return sbSeat, table.BigBlindSeat
 }
 
 // dealCards reparte cartas a los jugadores
 func (pe *PokerEngine) dealCards(table *PokerTable) {
 	cardIndex := 0
 
-	// 2 cartas por jugador
-	for round := 0; round < 2; round++ {
+	// Cantidad de cartas por jugador según la variante
+	for round := 0; round < holeCardCount(table.Variant); round++ {
 		for i := range table.Players {
 			if table.Players[i].IsActive {
 				table.Players[i].Cards = append(table.Players[i].Cards, table.Deck[cardIndex])
@@ -510,21 +632,23 @@ func (pe *PokerEngine) dealCards(table *PokerTable) {
 func (pe *PokerEngine) PlayerAction(tableID, playerID, action string, amount int) (*PokerTable, error) {
 	pe.mu.Lock()
 	defer pe.mu.Unlock()
-	
+
+	table, err := pe.playerAction(tableID, playerID, action, amount)
+	if err == nil {
+		pe.resetShotClock(table)
+	}
+	return table, err
+}
+
+// playerAction procesa la acción sin tomar el lock (también la usa el shot clock)
+func (pe *PokerEngine) playerAction(tableID, playerID, action string, amount int) (*PokerTable, error) {
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return nil, fmt.Errorf("table not found")
 	}
 
 	// Encontrar jugador
-	playerIndex := -1
-	for i, player := range table.Players {
-		if player.ID == playerID {
-			playerIndex = i
-			break
-		}
-	}
-
+	playerIndex := findPlayerSeat(table, playerID)
 	if playerIndex == -1 {
 		return nil, fmt.Errorf("player not found")
 	}
@@ -535,6 +659,17 @@ func (pe *PokerEngine) PlayerAction(tableID, playerID, action string, amount int
 	}
 
 	player := &table.Players[playerIndex]
+	betBefore, facing := player.CurrentBet, table.CurrentBet
+
+	// En las rondas de descarte solo se puede cambiar cartas
+	if isDrawPhase(table.Phase) {
+		return nil, fmt.Errorf("ronda de descarte: solo se permite la acción draw")
+	}
+
+	// Con todos all-in solo queda decidir cuántas veces se corre el board
+	if table.Phase == PhaseRunItTwice {
+		return nil, fmt.Errorf("esperando la decisión de run it twice")
+	}
 
 	// Procesar acción según el Texas Hold'em real
 	switch action {
@@ -571,11 +706,23 @@ func (pe *PokerEngine) PlayerAction(tableID, playerID, action string, amount int
 		if amount <= 0 {
 			return nil, fmt.Errorf("el raise debe ser positivo")
 		}
+		if isRaiseLocked(table, playerIndex) {
+			return nil, fmt.Errorf("la apuesta no fue reabierta: solo puedes igualar o retirarte")
+		}
 		if totalAmount > player.Stack {
 			return nil, fmt.Errorf("no tienes suficientes fichas para este raise")
 		}
-		if amount < table.BigBlind {
-			return nil, fmt.Errorf("el raise mínimo es %d", table.BigBlind)
+		if isFixedLimit(table) {
+			if err := pe.validateLimitRaise(table, amount); err != nil {
+				return nil, err
+			}
+		} else if minRaise := pe.minRaiseAmount(table); amount < minRaise {
+			return nil, fmt.Errorf("raise insuficiente: el raise mínimo es %d (último raise completo)", minRaise)
+		}
+		if isPotLimit(table) {
+			if maxRaise := pe.potLimitMaxRaise(table, player); amount > maxRaise {
+				return nil, fmt.Errorf("el raise máximo (pot limit) es %d", maxRaise)
+			}
 		}
 
 		player.Stack -= totalAmount
@@ -583,18 +730,42 @@ func (pe *PokerEngine) PlayerAction(tableID, playerID, action string, amount int
 		table.Pot += totalAmount
 		table.CurrentBet = player.CurrentBet
 		table.LastRaiser = playerIndex
+		table.LastRaiseSize = amount
+		table.RaiseCount++
 
 		// Reactivar a todos los jugadores que no han foldeado para que respondan al raise
-		for i := range table.Players {
-			if table.Players[i].IsActive && !table.Players[i].HasFolded && i != playerIndex {
-				table.PlayersToAct[i] = true
-			}
-		}
+		pe.reopenBetting(table, playerIndex)
 
 	case "all_in":
 		// All-in: apostar todas las fichas
 		amount = player.Stack
-		
+
+		// Sin la apuesta reabierta, el all-in solo puede igualar
+		if isRaiseLocked(table, playerIndex) && amount > table.CurrentBet-player.CurrentBet {
+			return nil, fmt.Errorf("la apuesta no fue reabierta: solo puedes igualar o retirarte")
+		}
+
+		// En pot limit el all-in no puede superar un raise del tamaño del pot
+		if isPotLimit(table) {
+			callAmount := table.CurrentBet - player.CurrentBet
+			if maxRaise := pe.potLimitMaxRaise(table, player); amount-callAmount > maxRaise {
+				return nil, fmt.Errorf("all-in excede el límite del pot, el raise máximo es %d", maxRaise)
+			}
+		}
+
+		// En fixed limit el all-in no puede subir más que una apuesta ni superar el tope
+		if isFixedLimit(table) {
+			callAmount := table.CurrentBet - player.CurrentBet
+			if amount > callAmount {
+				if table.RaiseCount >= limitBetCap {
+					return nil, fmt.Errorf("se alcanzó el máximo de %d apuestas en esta calle", limitBetCap)
+				}
+				if betSize := pe.fullRaiseSize(table); amount-callAmount > betSize {
+					return nil, fmt.Errorf("all-in excede el límite, el raise máximo es %d", betSize)
+				}
+			}
+		}
+
 		player.Stack = 0
 		player.CurrentBet += amount
 		player.IsAllIn = true // Marcar como all-in
@@ -602,13 +773,21 @@ func (pe *PokerEngine) PlayerAction(tableID, playerID, action string, amount int
 
 		// Si el all-in es mayor que la apuesta actual, es un raise
 		if player.CurrentBet > table.CurrentBet {
+			raiseSize := player.CurrentBet - table.CurrentBet
+			previousBet := table.CurrentBet
+			fullRaise := raiseSize >= pe.fullRaiseSize(table)
 			table.CurrentBet = player.CurrentBet
-			table.LastRaiser = playerIndex
-			// Reactivar jugadores para que respondan
-			for i := range table.Players {
-				if table.Players[i].IsActive && !table.Players[i].HasFolded && i != playerIndex {
-					table.PlayersToAct[i] = true
-				}
+
+			if fullRaise {
+				table.LastRaiser = playerIndex
+				table.LastRaiseSize = raiseSize
+				table.RaiseCount++
+				// Reactivar jugadores para que respondan
+				pe.reopenBetting(table, playerIndex)
+			} else {
+				// Un all-in menor a un raise completo no reabre la apuesta:
+				// quienes ya actuaron solo pueden igualar o retirarse
+				pe.requireCallOnly(table, playerIndex, previousBet)
 			}
 		}
 
@@ -616,23 +795,44 @@ func (pe *PokerEngine) PlayerAction(tableID, playerID, action string, amount int
 		return nil, fmt.Errorf("acción inválida: %s", action)
 	}
 
+	pe.recordPlayerAction(table, playerIndex, action, betBefore, facing)
+
 	// Marcar que este jugador ya actuó en esta ronda
 	table.PlayersToAct[playerIndex] = false
 
-	// Verificar si la ronda de apuestas terminó
-	if pe.isBettingRoundComplete(table) {
+	// Verificar si la mano terminó completamente (antes de repartir otra calle,
+	// para no cobrar el pot dos veces ni mostrar cartas que no se jugaron)
+	if pe.isHandComplete(table) {
+		pe.completeHand(table)
+	} else if pe.isBettingRoundComplete(table) {
+		// La ronda de apuestas terminó
 		pe.advanceToNextPhase(table)
 	} else {
 		// Avanzar al siguiente jugador
 		pe.nextPlayer(table)
 	}
 
-	// Verificar si la mano terminó completamente
-	if pe.isHandComplete(table) {
-		pe.completeHand(table)
+	return table, nil
+}
+
+// minRaiseAmount calcula el raise mínimo permitido en la ronda actual:
+// el tamaño del último raise completo, y nunca menos que el big blind
+func (pe *PokerEngine) minRaiseAmount(table *PokerTable) int {
+	// En stud, completar el bring-in hasta la apuesta mínima es un raise válido
+	if table.Variant == VariantStud && table.Phase == "third_street" && table.CurrentBet < table.BigBlind {
+		return table.BigBlind - table.CurrentBet
+	}
+	if table.LastRaiseSize > table.BigBlind {
+		return table.LastRaiseSize
 	}
+	return table.BigBlind
+}
 
-	return table, nil
+// potLimitMaxRaise calcula el raise máximo permitido en pot limit:
+// el tamaño del pot después de que el jugador iguale la apuesta actual
+func (pe *PokerEngine) potLimitMaxRaise(table *PokerTable, player *PokerPlayer) int {
+	callAmount := table.CurrentBet - player.CurrentBet
+	return table.Pot + callAmount
 }
 
 // nextPlayer avanza al siguiente jugador activo
@@ -647,8 +847,9 @@ func (pe *PokerEngine) nextPlayer(table *PokerTable) {
 			break
 		}
 
-		// Si encontramos un jugador activo, salimos
-		if table.Players[table.CurrentPlayer].IsActive && !table.Players[table.CurrentPlayer].HasFolded {
+		// Si encontramos un jugador activo que puede apostar, salimos
+		current := table.Players[table.CurrentPlayer]
+		if current.IsActive && !current.HasFolded && !current.IsAllIn {
 			break
 		}
 	}
@@ -693,6 +894,7 @@ func (pe *PokerEngine) advanceToNextPhase(table *PokerTable) {
 	
 	// Resetear las apuestas para la nueva ronda (pero mantener side pots)
 	for i := range table.Players {
+		table.Players[i].TotalBet += table.Players[i].CurrentBet
 		table.Players[i].CurrentBet = 0
 		// Solo reactivar jugadores que no están en all-in
 		if table.Players[i].IsActive && !table.Players[i].HasFolded && !table.Players[i].IsAllIn {
@@ -703,7 +905,26 @@ func (pe *PokerEngine) advanceToNextPhase(table *PokerTable) {
 	}
 	
 	table.CurrentBet = 0
+	table.LastAggressor = table.LastRaiser // Para el orden del showdown si esta fue la última ronda
 	table.LastRaiser = -1
+	table.LastRaiseSize = 0
+	table.RaiseCount = 0
+	clearRaiseLocks(table)
+
+	// Sin apuestas posibles antes del river, ofrecer correr el board varias veces
+	if pe.offerRunItTwice(table) {
+		return
+	}
+
+	if table.Variant == VariantStud {
+		pe.advanceStudStreet(table)
+		return
+	}
+
+	if table.Variant == VariantTripleDraw {
+		pe.advanceDrawRound(table)
+		return
+	}
 
 	switch table.Phase {
 	case "preflop":
@@ -812,6 +1033,21 @@ func (pe *PokerEngine) completeHand(table *PokerTable) {
 	// Distribuir side pots a los ganadores correspondientes
 	pe.distributeSidePots(table)
 
+	// Sumar el rake de la mano a la mesa
+	pe.collectRake(table)
+
+	// Mostrar las manos en orden; las que no pueden ganar se tiran
+	pe.revealShowdown(table)
+
+	// Revelar el server seed para que se pueda verificar el barajado
+	pe.revealServerSeed(table)
+
+	// Guardar el historial de la mano
+	pe.finishHandHistory(table)
+
+	// Liberar los asientos de los que dejaron la mesa durante la mano
+	pe.releaseLeavingSeats(table)
+
 	// Registrar tiempo de finalización del showdown
 	table.ShowdownEndTime = time.Now()
 
@@ -835,23 +1071,13 @@ func (pe *PokerEngine) hasEnoughActivePlayers(table *PokerTable) bool {
 
 // createShuffledDeck crea y baraja un deck estándar
 func (pe *PokerEngine) createShuffledDeck() []Card {
-	suits := []string{"hearts", "diamonds", "clubs", "spades"}
-	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
-
-	deck := make([]Card, 0, 52)
-	for _, suit := range suits {
-		for _, rank := range ranks {
-			deck = append(deck, Card{Suit: suit, Rank: rank})
-		}
-	}
-
-	// Barajar usando crypto/rand para seguridad
-	for i := len(deck) - 1; i > 0; i-- {
-		j, _ := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
-		deck[i], deck[j.Int64()] = deck[j.Int64()], deck[i]
-	}
+	return pe.createShuffledDeckFor(VariantHoldem)
+}
 
-	return deck
+// createShuffledDeckFor pide a la fuente del engine el deck de la variante
+// (52 cartas, o 36 cartas del 6 al As en short-deck)
+func (pe *PokerEngine) createShuffledDeckFor(variant string) []Card {
+	return pe.deckSource.NewDeck(variant)
 }
 
 // GetTable obtiene el estado de una mesa
@@ -885,8 +1111,8 @@ func (pe *PokerEngine) GetTableForPlayer(tableID, playerID string) (*PokerTable,
 	for i, player := range table.Players {
 		filteredTable.Players[i] = player
 		
-		// Solo mostrar cartas del jugador solicitante
-		if player.ID != playerID {
+		// Solo mostrar cartas del jugador solicitante y las que se mostraron en el showdown
+		if player.ID != playerID && !player.CardsRevealed {
 			// Ocultar cartas de otros jugadores
 			filteredTable.Players[i].Cards = make([]Card, len(player.Cards))
 			// Mantener el número de cartas pero sin mostrar los valores
@@ -902,6 +1128,8 @@ func (pe *PokerEngine) GetTableForPlayer(tableID, playerID string) (*PokerTable,
 // ====== SISTEMA DE SIDE POTS PARA ALL-INS MÚLTIPLES ======
 
 // createSidePots crea los side pots basados en los all-ins y apuestas de los jugadores
+// Usa el aporte total de la mano (calles anteriores + calle actual) e incluye
+// las fichas de jugadores que foldearon como dinero muerto
 func (pe *PokerEngine) createSidePots(table *PokerTable) {
 	// Limpiar side pots existentes
 	table.SidePots = make([]SidePot, 0)
@@ -921,10 +1149,6 @@ func (pe *PokerEngine) createSidePots(table *PokerTable) {
 	// Crear slice de niveles de apuesta únicos y ordenarlos
 	betLevels := pe.getSortedBetLevels(table, activePlayers)
 	
-	if len(betLevels) == 0 {
-		return
-	}
-	
 	// Crear side pots para cada nivel
 	for i, betLevel := range betLevels {
 		sidePot := SidePot{
@@ -939,33 +1163,73 @@ func (pe *PokerEngine) createSidePots(table *PokerTable) {
 			prevLevel = betLevels[i-1]
 		}
 		
-		levelContribution := betLevel - prevLevel
-		
-		// Agregar jugadores elegibles y calcular cantidad
+		// Agregar jugadores elegibles (solo los que siguen en la mano)
 		for _, playerIndex := range activePlayers {
-			player := table.Players[playerIndex]
-			if player.CurrentBet >= betLevel {
+			if playerContribution(table.Players[playerIndex]) >= betLevel {
 				sidePot.EligiblePlayers = append(sidePot.EligiblePlayers, playerIndex)
-				sidePot.Amount += levelContribution
 			}
 		}
 		
+		// Todos los jugadores aportan a este nivel, incluso los que foldearon
+		for _, player := range table.Players {
+			sidePot.Amount += levelShare(playerContribution(player), prevLevel, betLevel)
+		}
+		
 		// Solo agregar el side pot si tiene participantes y cantidad
 		if len(sidePot.EligiblePlayers) > 0 && sidePot.Amount > 0 {
 			table.SidePots = append(table.SidePots, sidePot)
 		}
 	}
 	
+	// Fichas de jugadores foldeados por encima del nivel más alto van al último pot
+	if len(betLevels) > 0 {
+		topLevel := betLevels[len(betLevels)-1]
+		deadMoney := 0
+		for _, player := range table.Players {
+			if contribution := playerContribution(player); contribution > topLevel {
+				deadMoney += contribution - topLevel
+			}
+		}
+		if deadMoney > 0 && len(table.SidePots) > 0 {
+			table.SidePots[len(table.SidePots)-1].Amount += deadMoney
+		}
+	}
+
+	// Los antes van al pot principal (o a pots propios si alguien quedó all-in en el ante)
+	pe.addAntesToPots(table, activePlayers)
+	
 	// Actualizar pot principal para compatibilidad (suma de todos los side pots)
 	table.Pot = pe.getTotalPot(table)
 }
 
+// playerContribution retorna el total de fichas que el jugador apostó en esta mano
+// (sin el ante, que es dinero muerto y no define niveles de apuesta)
+func playerContribution(player PokerPlayer) int {
+	return player.TotalBet + player.CurrentBet
+}
+
+// playerInvestment retorna todas las fichas que el jugador puso en el pot en esta mano, ante incluido
+func playerInvestment(player PokerPlayer) int {
+	return player.AnteBet + playerContribution(player)
+}
+
+// levelShare retorna cuánto de un aporte cae entre dos niveles de apuesta
+func levelShare(contribution, prevLevel, level int) int {
+	if contribution <= prevLevel {
+		return 0
+	}
+	if contribution > level {
+		contribution = level
+	}
+	return contribution - prevLevel
+}
+
 // getSortedBetLevels obtiene y ordena los niveles de apuesta únicos
 func (pe *PokerEngine) getSortedBetLevels(table *PokerTable, activePlayers []int) []int {
 	betLevelMap := make(map[int]bool)
 	
 	for _, playerIndex := range activePlayers {
-		bet := table.Players[playerIndex].CurrentBet
+		bet := playerContribution(table.Players[playerIndex])
 		if bet > 0 {
 			betLevelMap[bet] = true
 		}
@@ -1004,51 +1268,101 @@ func (pe *PokerEngine) distributeSidePots(table *PokerTable) {
 		pe.createSidePots(table)
 	}
 	
-	// Evaluar manos para todos los jugadores activos
-	playerHands := make(map[int]*HandEvaluation)
-	for i, player := range table.Players {
-		if player.IsActive && !player.HasFolded {
-			// Evaluar mano usando las 2 cartas del jugador + 5 comunitarias
-			if len(player.Cards) >= 2 && len(table.CommunityCards) >= 5 {
-				handResult := EvaluateHand(player.Cards, table.CommunityCards)
-				playerHands[i] = &handResult
-			}
-		}
+	// Valorar las manos de todos los jugadores activos, una vez por board (run it twice);
+	// la mejor mano solo se arma para los ganadores
+	boards := showdownBoards(table)
+	valuesByBoard := make([]map[int]int, len(boards))
+	for run, board := range boards {
+		valuesByBoard[run] = pe.showdownValues(table, board)
 	}
 	
+	// Registrar quién ganó cada pot para informar el showdown
+	result := newHandResult(table, boards)
+	
+	// El rake se descuenta de cada pot antes de pagarle a los ganadores
+	rakes := pe.sidePotRakes(table, boards[0])
+	
 	// Distribuir cada side pot por separado
 	for sidePotIndex, sidePot := range table.SidePots {
 		if sidePot.Amount <= 0 || len(sidePot.EligiblePlayers) == 0 {
 			continue
 		}
 		
-		// Encontrar ganadores entre jugadores elegibles para este side pot
-		winners := pe.findWinnersInSidePot(sidePot, playerHands)
-		
-		if len(winners) > 0 {
-			// Dividir el side pot entre los ganadores
-			potPerWinner := sidePot.Amount / len(winners)
-			remainder := sidePot.Amount % len(winners)
+		distributed := false
+		for run := range boards {
+			// Encontrar ganadores entre jugadores elegibles para este side pot
+			winners := pe.findWinnersInSidePot(sidePot, valuesByBoard[run])
+			if len(winners) == 0 {
+				continue
+			}
+			
+			// Dividir la parte del side pot de este runout entre los ganadores
+			rake := runoutShare(rakes[sidePotIndex], len(boards), run)
+			amount := runoutShare(sidePot.Amount, len(boards), run) - rake
+			potPerWinner := amount / len(winners)
+			remainder := amount % len(winners)
 			
+			potResult := PotResult{
+				PotIndex:        sidePotIndex,
+				Run:             run,
+				Amount:          amount,
+				Rake:            rake,
+				EligiblePlayers: append([]int(nil), sidePot.EligiblePlayers...),
+				Winners:         make([]PotWinner, 0, len(winners)),
+			}
 			for i, winnerIndex := range winners {
-				table.Players[winnerIndex].Stack += potPerWinner
+				won := potPerWinner
 				// Dar el resto al primer ganador
 				if i == 0 {
-					table.Players[winnerIndex].Stack += remainder
+					won += remainder
 				}
+				table.Players[winnerIndex].Stack += won
+				var hand *HandEvaluation
+				if !result.Uncontested {
+					hand = showdownHand(table, winnerIndex, boards[run])
+				}
+				potResult.Winners = append(potResult.Winners,
+					newPotWinner(table, winnerIndex, won, hand, result.Uncontested))
 			}
-			
-			// Marcar side pot como distribuido
+			result.Pots = append(result.Pots, potResult)
+			result.Rake += rake
+			distributed = true
+		}
+		
+		// Marcar side pot como distribuido
+		if distributed {
 			table.SidePots[sidePotIndex].Amount = 0
 		}
 	}
 	
 	// Actualizar pot principal
 	table.Pot = 0
+	table.HandResult = result
+}
+
+// showdownHand evalúa la mejor mano del jugador sobre un board, o nil si no tiene suficientes cartas
+func showdownHand(table *PokerTable, seat int, board []Card) *HandEvaluation {
+	player := table.Players[seat]
+	if !hasShowdownHand(table, player, board) {
+		return nil
+	}
+	hand := evaluatePlayerHandOnBoard(table, player, board)
+	return &hand
+}
+
+// showdownValues retorna el valor con el que compite cada jugador que sigue en la mano sobre un board
+func (pe *PokerEngine) showdownValues(table *PokerTable, board []Card) map[int]int {
+	values := make(map[int]int)
+	for i, player := range table.Players {
+		if player.IsActive && !player.HasFolded && hasShowdownHand(table, player, board) {
+			values[i] = showdownValue(table, player, board)
+		}
+	}
+	return values
 }
 
 // findWinnersInSidePot encuentra los ganadores de un side pot específico
-func (pe *PokerEngine) findWinnersInSidePot(sidePot SidePot, playerHands map[int]*HandEvaluation) []int {
+func (pe *PokerEngine) findWinnersInSidePot(sidePot SidePot, playerValues map[int]int) []int {
 	if len(sidePot.EligiblePlayers) == 0 {
 		return []int{}
 	}
@@ -1058,29 +1372,23 @@ func (pe *PokerEngine) findWinnersInSidePot(sidePot SidePot, playerHands map[int
 		return sidePot.EligiblePlayers
 	}
 	
-	// Encontrar la mejor mano entre los jugadores elegibles
-	var bestHand *HandEvaluation
+	// Encontrar la mejor mano entre los jugadores elegibles: mayor valor es mejor mano
+	bestValue := -1
 	winners := make([]int, 0)
 	
 	for _, playerIndex := range sidePot.EligiblePlayers {
-		hand, exists := playerHands[playerIndex]
+		value, exists := playerValues[playerIndex]
 		if !exists {
 			continue
 		}
 		
-		if bestHand == nil {
-			bestHand = hand
+		if value > bestValue {
+			// Nueva mejor mano
+			bestValue = value
 			winners = []int{playerIndex}
-		} else {
-			// Comparar por valor - mayor valor es mejor mano
-			if hand.Value > bestHand.Value {
-				// Nueva mejor mano
-				bestHand = hand
-				winners = []int{playerIndex}
-			} else if hand.Value == bestHand.Value {
-				// Empate - agregar a ganadores
-				winners = append(winners, playerIndex)
-			}
+		} else if value == bestValue {
+			// Empate - agregar a ganadores
+			winners = append(winners, playerIndex)
 		}
 	}
 	
@@ -1120,6 +1428,7 @@ func (pe *PokerEngine) scheduleAutoRestart(tableID string) {
 
 	// Reiniciar la mano automáticamente
 	pe.startHand(table)
+	go pe.notifyUpdate(tableID)
 }
 
 // SetAutoRestart configura el auto-restart para una mesa
@@ -1140,6 +1449,25 @@ func (pe *PokerEngine) SetAutoRestart(tableID string, enabled bool, delay time.D
 	return nil
 }
 
+// SetBlindLevel cambia los blinds y el ante de una mesa (por ejemplo al subir el nivel de un torneo)
+func (pe *PokerEngine) SetBlindLevel(tableID string, smallBlind, bigBlind, ante int) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return fmt.Errorf("table not found")
+	}
+	if smallBlind <= 0 || bigBlind < smallBlind || ante < 0 {
+		return fmt.Errorf("nivel de blinds inválido: %d/%d ante %d", smallBlind, bigBlind, ante)
+	}
+
+	table.SmallBlind = smallBlind
+	table.BigBlind = bigBlind
+	table.Ante = ante
+	return nil
+}
+
 // GetAutoRestartStatus obtiene el estado del auto-restart para una mesa
 func (pe *PokerEngine) GetAutoRestartStatus(tableID string) (bool, time.Duration, error) {
 	pe.mu.RLock()
@@ -1155,6 +1483,9 @@ func (pe *PokerEngine) GetAutoRestartStatus(tableID string) (bool, time.Duration
 
 // ForceRestartHand fuerza el reinicio de una mano (para testing o administración)
 func (pe *PokerEngine) ForceRestartHand(tableID string) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return fmt.Errorf("table not found")
@@ -1176,32 +1507,62 @@ func (pe *PokerEngine) ForceRestartHand(tableID string) error {
 
 // GetTableConfig retorna la configuración de buy-in de una mesa
 func (pe *PokerEngine) GetTableConfig(tableID string) (*TableConfig, error) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return nil, fmt.Errorf("table not found")
 	}
 
-	config := &TableConfig{
+	config := tableConfig(table)
+	return &config, nil
+}
+
+// tableConfig arma la configuración actual de una mesa
+func tableConfig(table *PokerTable) TableConfig {
+	return TableConfig{
+		Variant:      table.Variant,
 		SmallBlind:   table.SmallBlind,
 		BigBlind:     table.BigBlind,
+		BettingStructure: table.BettingStructure,
+		SmallBet:     table.SmallBet,
+		BigBet:       table.BigBet,
+		Ante:         table.Ante,
+		AnteType:     table.AnteType,
+		Straddle:     table.Straddle,
+		MaxSeats:     table.MaxSeats,
+		RunItTwice:   table.RunItTwice,
+		BringIn:      table.BringIn,
 		BuyInAmount:  table.BuyInAmount,
 		MinBuyIn:     table.MinBuyIn,
 		MaxBuyIn:     table.MaxBuyIn,
 		IsCashGame:   table.IsCashGame,
 		AutoRestart:  table.AutoRestart,
 		RestartDelay: table.RestartDelay,
+		ActionTimeout: table.ActionTimeout,
+		TimeBank:     table.TimeBank,
+		RakePercent:  table.RakePercent,
+		RakeCap:      table.RakeCap,
+		NoFlopNoDrop: table.NoFlopNoDrop,
 	}
-
-	return config, nil
 }
 
 // ValidateBuyIn valida si un monto de buy-in es válido para una mesa
 func (pe *PokerEngine) ValidateBuyIn(tableID string, buyInAmount int) error {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return fmt.Errorf("table not found")
 	}
 
+	return checkBuyIn(table, buyInAmount)
+}
+
+// checkBuyIn verifica que el monto esté entre el buy-in mínimo y el máximo de la mesa
+func checkBuyIn(table *PokerTable, buyInAmount int) error {
 	if buyInAmount < table.MinBuyIn {
 		return fmt.Errorf("buy-in amount %d is below minimum %d", buyInAmount, table.MinBuyIn)
 	}
@@ -1214,6 +1575,9 @@ func (pe *PokerEngine) ValidateBuyIn(tableID string, buyInAmount int) error {
 
 // UpdateTableConfig actualiza la configuración de buy-in de una mesa (solo para host)
 func (pe *PokerEngine) UpdateTableConfig(tableID string, config TableConfig) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return fmt.Errorf("table not found")
@@ -1224,29 +1588,75 @@ func (pe *PokerEngine) UpdateTableConfig(tableID string, config TableConfig) err
 		return fmt.Errorf("can only update configuration in lobby or waiting phase")
 	}
 
+	if err := validateTableConfig(config); err != nil {
+		return err
+	}
+
 	// Validar que MinBuyIn <= BuyInAmount <= MaxBuyIn
 	if config.MinBuyIn > config.BuyInAmount || config.BuyInAmount > config.MaxBuyIn {
 		return fmt.Errorf("invalid buy-in configuration: MinBuyIn (%d) <= BuyInAmount (%d) <= MaxBuyIn (%d)", 
 			config.MinBuyIn, config.BuyInAmount, config.MaxBuyIn)
 	}
 
+	// Ajustar los asientos antes de cambiar el resto (falla si sobra un asiento ocupado)
+	if err := resizeSeats(table, normalizeSeatCount(config.MaxSeats, config.Variant)); err != nil {
+		return err
+	}
+
 	// Actualizar configuración
+	table.Variant = normalizeVariant(config.Variant)
 	table.SmallBlind = config.SmallBlind
 	table.BigBlind = config.BigBlind
+	table.BettingStructure = normalizeBettingStructure(config.BettingStructure, config.Variant)
+	table.SmallBet = config.SmallBet
+	table.BigBet = config.BigBet
+	table.Ante = config.Ante
+	table.AnteType = normalizeAnteType(config.AnteType)
+	table.Straddle = normalizeStraddleMode(config.Straddle)
+	table.RunItTwice = config.RunItTwice
+	table.BringIn = config.BringIn
 	table.BuyInAmount = config.BuyInAmount
 	table.MinBuyIn = config.MinBuyIn
 	table.MaxBuyIn = config.MaxBuyIn
 	table.IsCashGame = config.IsCashGame
 	table.AutoRestart = config.AutoRestart
 	table.RestartDelay = config.RestartDelay
+	table.ActionTimeout = config.ActionTimeout
+	table.TimeBank = config.TimeBank
+	table.RakePercent = config.RakePercent
+	table.RakeCap = config.RakeCap
+	table.NoFlopNoDrop = config.NoFlopNoDrop
 
 	return nil
 }
 
+// validateTableConfig verifica una configuración de mesa antes de crear o actualizar la mesa
+func validateTableConfig(config TableConfig) error {
+	if err := validateVariant(config.Variant); err != nil {
+		return err
+	}
+	if err := validateBettingStructure(config.BettingStructure); err != nil {
+		return err
+	}
+	if err := validateAnteType(config.AnteType); err != nil {
+		return err
+	}
+	if err := validateStraddleMode(config.Straddle); err != nil {
+		return err
+	}
+	if err := validateSeatCount(config.MaxSeats, config.Variant); err != nil {
+		return err
+	}
+	return validateRake(config)
+}
+
 // ====== MANEJO BÁSICO DE DESCONEXIONES ======
 
 // SetPlayerConnected actualiza el estado de conexión de un jugador
 func (pe *PokerEngine) SetPlayerConnected(tableID, playerID string, connected bool) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return fmt.Errorf("table not found")
@@ -1254,18 +1664,14 @@ func (pe *PokerEngine) SetPlayerConnected(tableID, playerID string, connected bo
 
 	// Encontrar jugador
 	for i := range table.Players {
-		if table.Players[i].ID == playerID {
+		if playerID != "" && table.Players[i].ID == playerID {
 			table.Players[i].IsConnected = connected
 			table.Players[i].LastSeenTime = time.Now()
 			
-			// Si se desconectó durante el juego, puede afectar el flujo
-			if !connected && table.Phase != "waiting" && table.Phase != "lobby" {
-				// Fold automático si era su turno
-				if table.CurrentPlayer == i && table.Phase != "showdown" {
-					table.Players[i].HasFolded = true
-					table.PlayersToAct[i] = false
-					pe.nextPlayer(table)
-				}
+			// Al desconectarse queda sentado afuera: pasa o se retira cuando le toque
+			// y no recibe cartas hasta que vuelva a sentarse
+			if !connected && !table.Players[i].SittingOut {
+				pe.sitOut(table, i)
 			}
 			return nil
 		}
@@ -1276,6 +1682,9 @@ func (pe *PokerEngine) SetPlayerConnected(tableID, playerID string, connected bo
 
 // GetDisconnectedPlayers obtiene jugadores desconectados por más de X tiempo
 func (pe *PokerEngine) GetDisconnectedPlayers(tableID string, timeout time.Duration) ([]string, error) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return nil, fmt.Errorf("table not found")
@@ -1285,6 +1694,9 @@ func (pe *PokerEngine) GetDisconnectedPlayers(tableID string, timeout time.Durat
 	cutoff := time.Now().Add(-timeout)
 
 	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
 		if !player.IsConnected || player.LastSeenTime.Before(cutoff) {
 			disconnected = append(disconnected, player.Name)
 		}
@@ -1295,13 +1707,16 @@ func (pe *PokerEngine) GetDisconnectedPlayers(tableID string, timeout time.Durat
 
 // HeartbeatPlayer actualiza el último momento visto de un jugador
 func (pe *PokerEngine) HeartbeatPlayer(tableID, playerID string) error {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
 	table, exists := pe.tables[tableID]
 	if !exists {
 		return fmt.Errorf("table not found")
 	}
 
 	for i := range table.Players {
-		if table.Players[i].ID == playerID {
+		if playerID != "" && table.Players[i].ID == playerID {
 			table.Players[i].LastSeenTime = time.Now()
 			return nil
 		}
@@ -0,0 +1,304 @@
+package poker
+
+import (
+	"fmt"
+	mathrand "math/rand"
+	"time"
+)
+
+// ====== CALCULADORA DE EQUITY ======
+//
+// Equity calcula qué porcentaje de los runouts gana o empata cada mano con el
+// board dado y las cartas muertas fuera del deck. Si quedan pocos runouts
+// posibles se enumeran todos y el resultado es exacto; si no, se simula una
+// muestra al azar (Monte Carlo). Sirve para las variantes con board: hold'em,
+// omaha y short-deck.
+
+// Valores por defecto de EquityOptions
+const (
+	DefaultEquityTrials = 20000 // Runouts simulados con Monte Carlo
+	DefaultExactRunouts = 50000 // Máximo de runouts para enumerar todos
+)
+
+// EquityOptions configura el cálculo de equity
+type EquityOptions struct {
+	Variant    string // holdem (por defecto), omaha o short_deck
+	Trials     int    // Runouts a simular si no se enumeran (por defecto DefaultEquityTrials)
+	ExactLimit int    // Enumerar si hay a lo sumo esta cantidad de runouts (por defecto DefaultExactRunouts, -1 = siempre simular)
+	Seed       int64  // Semilla del Monte Carlo para repetir el cálculo (0 = aleatoria)
+}
+
+// HandEquity es el resultado de una mano
+type HandEquity struct {
+	Cards  []Card  `json:"cards"`
+	Wins   int     `json:"wins"`   // Runouts que gana sola
+	Ties   int     `json:"ties"`   // Runouts que empata
+	Win    float64 `json:"win"`    // Porcentaje de runouts que gana sola
+	Tie    float64 `json:"tie"`    // Porcentaje de runouts que empata
+	Equity float64 `json:"equity"` // Porcentaje del pot que le corresponde (los empates se dividen)
+}
+
+// EquityResult es el resultado del cálculo para todas las manos
+type EquityResult struct {
+	Hands   []HandEquity `json:"hands"`   // En el mismo orden que las manos pedidas
+	Runouts int          `json:"runouts"` // Runouts evaluados
+	Exact   bool         `json:"exact"`   // true si se enumeraron todos los runouts
+}
+
+// Equity calcula la equity de cada mano sobre el board con las cartas muertas fuera del deck
+func Equity(hands [][]Card, board []Card, dead []Card, opts EquityOptions) (*EquityResult, error) {
+	variant := normalizeVariant(opts.Variant)
+	evaluate, err := equityEvaluator(variant)
+	if err != nil {
+		return nil, err
+	}
+	deck, err := equityDeck(variant, hands, board, dead)
+	if err != nil {
+		return nil, err
+	}
+
+	trials := opts.Trials
+	if trials <= 0 {
+		trials = DefaultEquityTrials
+	}
+	exactLimit := opts.ExactLimit
+	if exactLimit == 0 {
+		exactLimit = DefaultExactRunouts
+	}
+
+	calc := newEquityCalc(hands, board, evaluate)
+	missing := 5 - len(board)
+	if runouts := combinationCount(len(deck), missing); exactLimit > 0 && runouts <= exactLimit {
+		calc.enumerate(deck, missing)
+		return calc.result(true), nil
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	calc.simulate(deck, missing, trials, mathrand.New(mathrand.NewSource(seed)))
+	return calc.result(false), nil
+}
+
+// equityEvaluator retorna el evaluador de manos de la variante
+func equityEvaluator(variant string) (func(hole, board []Card) HandEvaluation, error) {
+	switch variant {
+	case VariantHoldem:
+		return evaluateHoldemLookup, nil
+	case VariantOmaha:
+		return EvaluateOmahaHand, nil
+	case VariantShortDeck:
+		return EvaluateShortDeckHand, nil
+	default:
+		return nil, fmt.Errorf("la equity solo se calcula en variantes con board: %s", variant)
+	}
+}
+
+// evaluateHoldemLookup evalúa una mano de hold'em con las tablas precalculadas
+// (ver lookup.go); con menos de 5 cartas usa EvaluateHand
+func evaluateHoldemLookup(hole, board []Card) HandEvaluation {
+	var buf [lookupMaxCards]Card
+	cards := append(append(buf[:0], hole...), board...)
+	value, err := lookupValue(cards)
+	if err != nil {
+		return EvaluateHand(hole, board)
+	}
+	return HandEvaluation{Rank: lookupHandRank(value), Value: value}
+}
+
+// equityDeck valida las cartas y retorna las que quedan en el deck
+func equityDeck(variant string, hands [][]Card, board, dead []Card) ([]Card, error) {
+	if len(hands) < 2 {
+		return nil, fmt.Errorf("hacen falta al menos dos manos para calcular la equity")
+	}
+	switch len(board) {
+	case 0, 3, 4, 5:
+	default:
+		return nil, fmt.Errorf("el board debe tener 0, 3, 4 o 5 cartas: %d", len(board))
+	}
+
+	full := newDeck(variant)
+	valid := make(map[Card]bool, len(full))
+	for _, card := range full {
+		valid[card] = true
+	}
+	used := make(map[Card]bool)
+	use := func(cards []Card) error {
+		for _, card := range cards {
+			if !valid[card] {
+				return fmt.Errorf("carta inválida: %s de %s", card.Rank, card.Suit)
+			}
+			if used[card] {
+				return fmt.Errorf("carta repetida: %s", formatCard(card))
+			}
+			used[card] = true
+		}
+		return nil
+	}
+
+	for i, hand := range hands {
+		if len(hand) != holeCardCount(variant) {
+			return nil, fmt.Errorf("la mano %d tiene %d cartas (se esperaban %d)", i+1, len(hand), holeCardCount(variant))
+		}
+		if err := use(hand); err != nil {
+			return nil, err
+		}
+	}
+	if err := use(board); err != nil {
+		return nil, err
+	}
+	if err := use(dead); err != nil {
+		return nil, err
+	}
+
+	deck := make([]Card, 0, len(full)-len(used))
+	for _, card := range full {
+		if !used[card] {
+			deck = append(deck, card)
+		}
+	}
+	if len(deck) < 5-len(board) {
+		return nil, fmt.Errorf("no quedan cartas suficientes para completar el board")
+	}
+	return deck, nil
+}
+
+// combinationCount retorna C(n, k), saturando en un valor alto para no desbordar
+func combinationCount(n, k int) int {
+	const limit = 1 << 40
+	count := 1
+	for i := 1; i <= k; i++ {
+		count = count * (n - k + i) / i
+		if count > limit {
+			return limit
+		}
+	}
+	return count
+}
+
+// equityCalc acumula los resultados de los runouts
+type equityCalc struct {
+	hands    [][]Card
+	runout   []Card // Board completo del runout en curso
+	fixed    int    // Cartas del board que ya estaban
+	evaluate func(hole, board []Card) HandEvaluation
+
+	wins    []int
+	ties    []int
+	shares  []float64
+	runouts int
+	best    []int // Ganadores del runout en curso (reutilizado)
+}
+
+func newEquityCalc(hands [][]Card, board []Card, evaluate func(hole, board []Card) HandEvaluation) *equityCalc {
+	runout := make([]Card, 5)
+	copy(runout, board)
+	return &equityCalc{
+		hands:    hands,
+		runout:   runout,
+		fixed:    len(board),
+		evaluate: evaluate,
+		wins:     make([]int, len(hands)),
+		ties:     make([]int, len(hands)),
+		shares:   make([]float64, len(hands)),
+		best:     make([]int, 0, len(hands)),
+	}
+}
+
+// enumerate evalúa todas las combinaciones de missing cartas del deck
+func (c *equityCalc) enumerate(deck []Card, missing int) {
+	if missing == 0 {
+		c.score()
+		return
+	}
+
+	indices := make([]int, missing)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		for i, index := range indices {
+			c.runout[c.fixed+i] = deck[index]
+		}
+		c.score()
+
+		// Siguiente combinación en orden lexicográfico
+		i := missing - 1
+		for i >= 0 && indices[i] == len(deck)-missing+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indices[i]++
+		for j := i + 1; j < missing; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
+// simulate evalúa trials runouts al azar
+func (c *equityCalc) simulate(deck []Card, missing, trials int, rng *mathrand.Rand) {
+	remaining := append([]Card(nil), deck...)
+	for trial := 0; trial < trials; trial++ {
+		// Fisher-Yates parcial: solo hacen falta las primeras missing cartas
+		for i := 0; i < missing; i++ {
+			j := i + rng.Intn(len(remaining)-i)
+			remaining[i], remaining[j] = remaining[j], remaining[i]
+			c.runout[c.fixed+i] = remaining[i]
+		}
+		c.score()
+	}
+}
+
+// score evalúa las manos sobre el runout en curso y suma el resultado
+func (c *equityCalc) score() {
+	c.best = c.best[:0]
+	var bestHand HandEvaluation
+	for i, hand := range c.hands {
+		evaluation := c.evaluate(hand, c.runout)
+		switch {
+		case len(c.best) == 0 || CompareHands(evaluation, bestHand) > 0:
+			bestHand = evaluation
+			c.best = append(c.best[:0], i)
+		case CompareHands(evaluation, bestHand) == 0:
+			c.best = append(c.best, i)
+		}
+	}
+
+	c.runouts++
+	if len(c.best) == 1 {
+		c.wins[c.best[0]]++
+		c.shares[c.best[0]]++
+		return
+	}
+	for _, i := range c.best {
+		c.ties[i]++
+		c.shares[i] += 1 / float64(len(c.best))
+	}
+}
+
+// result arma los porcentajes de cada mano
+func (c *equityCalc) result(exact bool) *EquityResult {
+	result := &EquityResult{
+		Hands:   make([]HandEquity, len(c.hands)),
+		Runouts: c.runouts,
+		Exact:   exact,
+	}
+	for i, hand := range c.hands {
+		equity := HandEquity{
+			Cards: append([]Card(nil), hand...),
+			Wins:  c.wins[i],
+			Ties:  c.ties[i],
+		}
+		if c.runouts > 0 {
+			total := float64(c.runouts)
+			equity.Win = float64(c.wins[i]) * 100 / total
+			equity.Tie = float64(c.ties[i]) * 100 / total
+			equity.Equity = c.shares[i] * 100 / total
+		}
+		result.Hands[i] = equity
+	}
+	return result
+}
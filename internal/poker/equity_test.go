@@ -0,0 +1,147 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	aceHearts       = Card{Suit: "hearts", Rank: "A"}
+	aceSpades       = Card{Suit: "spades", Rank: "A"}
+	kingDiamonds    = Card{Suit: "diamonds", Rank: "K"}
+	kingClubs       = Card{Suit: "clubs", Rank: "K"}
+	equityTurnBoard = []Card{
+		{Suit: "clubs", Rank: "2"}, {Suit: "diamonds", Rank: "7"},
+		{Suit: "hearts", Rank: "9"}, {Suit: "spades", Rank: "Q"},
+	}
+)
+
+// assertEquity verifica un porcentaje con tolerancia
+func assertEquity(t *testing.T, name string, got, expected, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-expected) > tolerance {
+		t.Errorf("Expected %s %.2f%%, got %.2f%%", name, expected, got)
+	}
+}
+
+// TestEquityExactOnTurn verifica la enumeración exacta del river
+func TestEquityExactOnTurn(t *testing.T) {
+	hands := [][]Card{{aceHearts, aceSpades}, {kingDiamonds, kingClubs}}
+
+	result, err := Equity(hands, equityTurnBoard, nil, EquityOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Exact || result.Runouts != 44 {
+		t.Fatalf("Expected the 44 rivers enumerated, got %d (exact %v)", result.Runouts, result.Exact)
+	}
+	// KK solo gana con uno de los dos reyes que quedan
+	if result.Hands[1].Wins != 2 || result.Hands[0].Wins != 42 {
+		t.Errorf("Expected 42 / 2 wins, got %d / %d", result.Hands[0].Wins, result.Hands[1].Wins)
+	}
+	assertEquity(t, "AA win", result.Hands[0].Win, 42.0*100/44, 1e-9)
+
+	// Con un rey muerto KK tiene un solo out
+	result, err = Equity(hands, equityTurnBoard, []Card{{Suit: "hearts", Rank: "K"}}, EquityOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Runouts != 43 || result.Hands[1].Wins != 1 {
+		t.Errorf("Expected 1 out in 43 rivers with a dead king, got %d in %d", result.Hands[1].Wins, result.Runouts)
+	}
+}
+
+// TestEquityTies verifica los empates cuando el board juega
+func TestEquityTies(t *testing.T) {
+	royal := []Card{
+		{Suit: "spades", Rank: "10"}, {Suit: "spades", Rank: "J"}, {Suit: "spades", Rank: "Q"},
+		{Suit: "spades", Rank: "K"}, aceSpades,
+	}
+	hands := [][]Card{
+		{aceHearts, kingDiamonds},
+		{kingClubs, {Suit: "hearts", Rank: "2"}},
+		{{Suit: "clubs", Rank: "3"}, {Suit: "clubs", Rank: "4"}},
+	}
+
+	result, err := Equity(hands, royal, nil, EquityOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, hand := range result.Hands {
+		if hand.Ties != 1 || hand.Win != 0 || hand.Tie != 100 {
+			t.Errorf("Hand %d: expected a tie, got %+v", i, hand)
+		}
+		assertEquity(t, "split equity", hand.Equity, 100.0/3, 1e-9)
+	}
+}
+
+// TestEquityMonteCarloPreflop verifica la simulación con una equity conocida
+func TestEquityMonteCarloPreflop(t *testing.T) {
+	hands := [][]Card{{aceHearts, aceSpades}, {kingDiamonds, kingClubs}}
+	opts := EquityOptions{Trials: 20000, Seed: 42}
+
+	result, err := Equity(hands, nil, nil, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Exact || result.Runouts != 20000 {
+		t.Fatalf("Expected 20000 simulated runouts, got %d (exact %v)", result.Runouts, result.Exact)
+	}
+	// AA contra KK sin palos en común: ~82% / ~18%
+	assertEquity(t, "AA equity", result.Hands[0].Equity, 82, 1.5)
+	assertEquity(t, "total equity", result.Hands[0].Equity+result.Hands[1].Equity, 100, 1e-9)
+
+	again, _ := Equity(hands, nil, nil, opts)
+	if again.Hands[0].Wins != result.Hands[0].Wins {
+		t.Error("The same seed should repeat the simulation")
+	}
+}
+
+// TestEquityOmahaFlop verifica la enumeración en omaha desde el flop
+func TestEquityOmahaFlop(t *testing.T) {
+	hands := [][]Card{
+		{aceHearts, aceSpades, {Suit: "hearts", Rank: "K"}, {Suit: "spades", Rank: "K"}},
+		{{Suit: "clubs", Rank: "9"}, {Suit: "diamonds", Rank: "9"}, {Suit: "clubs", Rank: "8"}, {Suit: "diamonds", Rank: "8"}},
+	}
+	flop := []Card{{Suit: "hearts", Rank: "9"}, {Suit: "spades", Rank: "8"}, {Suit: "clubs", Rank: "2"}}
+
+	result, err := Equity(hands, flop, nil, EquityOptions{Variant: VariantOmaha})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Exact || result.Runouts != 820 {
+		t.Fatalf("Expected C(41,2) runouts enumerated, got %d", result.Runouts)
+	}
+	// El set de nueves va adelante contra los dos pares altos
+	if result.Hands[1].Equity < 70 {
+		t.Errorf("Expected top set to be a big favorite, got %.2f%%", result.Hands[1].Equity)
+	}
+}
+
+// TestEquityRejectsInvalidInput verifica la validación de las cartas
+func TestEquityRejectsInvalidInput(t *testing.T) {
+	aces := []Card{aceHearts, aceSpades}
+	kings := []Card{kingDiamonds, kingClubs}
+
+	tests := []struct {
+		name  string
+		hands [][]Card
+		board []Card
+		dead  []Card
+		opts  EquityOptions
+	}{
+		{"una sola mano", [][]Card{aces}, nil, nil, EquityOptions{}},
+		{"carta repetida", [][]Card{aces, {aceHearts, kingClubs}}, nil, nil, EquityOptions{}},
+		{"carta muerta en una mano", [][]Card{aces, kings}, nil, []Card{kingClubs}, EquityOptions{}},
+		{"mano incompleta", [][]Card{aces, {kingClubs}}, nil, nil, EquityOptions{}},
+		{"board de dos cartas", [][]Card{aces, kings}, equityTurnBoard[:2], nil, EquityOptions{}},
+		{"carta inválida", [][]Card{aces, {kingClubs, {Suit: "stars", Rank: "K"}}}, nil, nil, EquityOptions{}},
+		{"carta fuera del short deck", [][]Card{aces, kings}, equityTurnBoard, nil, EquityOptions{Variant: VariantShortDeck}},
+		{"variante sin board", [][]Card{aces, kings}, nil, nil, EquityOptions{Variant: VariantStud}},
+	}
+	for _, test := range tests {
+		if _, err := Equity(test.hands, test.board, test.dead, test.opts); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"sort"
 )
 
-// HandRank representa la fuerza de una mano
+// HandRank representa la categoría de una mano (par, color, full...).
+// El orden de las constantes es el de Hold'em y no sirve para comparar manos de
+// todas las variantes: en short-deck el color le gana al full y en 2-7 triple draw
+// gana la categoría más baja. Para decidir qué mano gana se compara solo
+// HandEvaluation.Value, que sí respeta las reglas de la variante.
 type HandRank int
 
 const (
@@ -22,9 +26,9 @@ const (
 
 // HandEvaluation contiene el resultado de evaluar una mano
 type HandEvaluation struct {
-	Rank     HandRank `json:"rank"`
-	Value    int      `json:"value"`    // Valor numérico para comparación
-	Cards    []Card   `json:"cards"`    // Las 5 mejores cartas
+	Rank     HandRank `json:"rank"`  // Categoría para mostrar (no comparar entre manos)
+	Value    int      `json:"value"` // Valor numérico para comparación (mayor gana, dentro de la misma variante)
+	Cards    []Card   `json:"cards"` // Las 5 mejores cartas
 	RankName string   `json:"rank_name"`
 }
 
@@ -64,26 +68,141 @@ func CardValue(rank string) int {
 
 // EvaluateHand evalúa la mejor mano de 5 cartas de las 7 disponibles
 func EvaluateHand(playerCards []Card, communityCards []Card) HandEvaluation {
-	allCards := append(playerCards, communityCards...)
-	
+	// Copiar para no escribir sobre el backing array de las cartas del jugador
+	allCards := make([]Card, 0, len(playerCards)+len(communityCards))
+	allCards = append(allCards, playerCards...)
+	allCards = append(allCards, communityCards...)
+
 	// Generar todas las combinaciones posibles de 5 cartas
 	bestHand := HandEvaluation{Rank: HighCard, Value: 0}
-	
+
 	// Si hay menos de 5 cartas, evaluar lo que hay
 	if len(allCards) < 5 {
 		return evaluateFiveCards(allCards)
 	}
-	
+
 	// Generar combinaciones de 5 cartas
 	combinations := generateCombinations(allCards, 5)
-	
+
 	for _, combo := range combinations {
 		evaluation := evaluateFiveCards(combo)
 		if evaluation.Value > bestHand.Value {
 			bestHand = evaluation
 		}
 	}
-	
+
+	return bestHand
+}
+
+// EvaluateShortDeckHand evalúa la mejor mano de 5 cartas con las reglas de short-deck (6+):
+// A-6-7-8-9 es escalera y el flush le gana al full house
+func EvaluateShortDeckHand(playerCards []Card, communityCards []Card) HandEvaluation {
+	allCards := make([]Card, 0, len(playerCards)+len(communityCards))
+	allCards = append(allCards, playerCards...)
+	allCards = append(allCards, communityCards...)
+
+	if len(allCards) < 5 {
+		return evaluateFiveCardsShortDeck(allCards)
+	}
+
+	bestHand := HandEvaluation{Rank: HighCard, Value: 0}
+	for _, combo := range generateCombinations(allCards, 5) {
+		evaluation := evaluateFiveCardsShortDeck(combo)
+		if evaluation.Value > bestHand.Value {
+			bestHand = evaluation
+		}
+	}
+
+	return bestHand
+}
+
+// evaluateFiveCardsShortDeck evalúa 5 cartas ajustando la evaluación estándar a short-deck.
+// Con 36 cartas los colores y los kickers empatan seguido, así que el Value desempata
+// con las cinco cartas (ver shortDeckTiebreak).
+func evaluateFiveCardsShortDeck(cards []Card) HandEvaluation {
+	evaluation := evaluateFiveCards(cards)
+
+	// La escalera baja de short-deck es A-6-7-8-9 (el 9 es la carta alta)
+	if evaluation.Rank == HighCard || evaluation.Rank == Flush {
+		if isStraight, straightHigh := checkStraightWithWheel(evaluation.Cards, shortDeckWheel); isStraight {
+			if evaluation.Rank == Flush {
+				evaluation.Rank = StraightFlush
+				evaluation.Value = 900000 + straightHigh
+				evaluation.RankName = "Straight Flush"
+			} else {
+				evaluation.Rank = Straight
+				evaluation.Value = 500000 + straightHigh
+				evaluation.RankName = "Straight"
+			}
+			return evaluation
+		}
+	}
+
+	// Con menos cartas en el deck el flush es más difícil que el full house
+	switch evaluation.Rank {
+	case Straight, StraightFlush, RoyalFlush:
+		// Las escaleras se desempatan solo por la carta alta
+	case Flush:
+		evaluation.Value = 700000 + shortDeckTiebreak(evaluation.Cards)
+	case FullHouse:
+		evaluation.Value = 600000 + shortDeckTiebreak(evaluation.Cards)
+	default:
+		evaluation.Value = (int(evaluation.Rank)+1)*100000 + shortDeckTiebreak(evaluation.Cards)
+	}
+
+	return evaluation
+}
+
+// shortDeckTiebreak codifica las cartas en orden de desempate (primero los grupos más
+// grandes, después las más altas) en base 9, del 6 (0) al As (8). Cinco cartas dan
+// menos de 9^5 = 59049, así que el desempate no pasa a la categoría siguiente.
+func shortDeckTiebreak(cards []Card) int {
+	counts := make(map[int]int, len(cards))
+	values := make([]int, 0, len(cards))
+	for _, card := range cards {
+		value := CardValue(card.Rank)
+		counts[value]++
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] > values[j]
+	})
+
+	code := 0
+	for i := 0; i < 5; i++ {
+		code *= 9
+		if i < len(values) && values[i] >= 6 {
+			code += values[i] - 6
+		}
+	}
+	return code
+}
+
+// EvaluateOmahaHand evalúa la mejor mano de Omaha: exactamente 2 cartas propias y 3 del board
+func EvaluateOmahaHand(playerCards []Card, communityCards []Card) HandEvaluation {
+	bestHand := HandEvaluation{Rank: HighCard, Value: 0}
+
+	// Sin board completo no se puede aplicar la regla 2+3, evaluar lo disponible
+	if len(playerCards) < 2 || len(communityCards) < 3 {
+		return EvaluateHand(playerCards, communityCards)
+	}
+
+	for _, hole := range generateCombinations(playerCards, 2) {
+		for _, board := range generateCombinations(communityCards, 3) {
+			combo := make([]Card, 0, 5)
+			combo = append(combo, hole...)
+			combo = append(combo, board...)
+
+			evaluation := evaluateFiveCards(combo)
+			if evaluation.Value > bestHand.Value {
+				bestHand = evaluation
+			}
+		}
+	}
+
 	return bestHand
 }
 
@@ -92,23 +211,23 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 	if len(cards) == 0 {
 		return HandEvaluation{Rank: HighCard, Value: 0, RankName: "High Card"}
 	}
-	
+
 	// Copiar y ordenar cartas por valor
 	sortedCards := make([]Card, len(cards))
 	copy(sortedCards, cards)
 	sort.Slice(sortedCards, func(i, j int) bool {
 		return CardValue(sortedCards[i].Rank) > CardValue(sortedCards[j].Rank)
 	})
-	
+
 	// Contar ranks y suits
 	rankCounts := make(map[string]int)
 	suitCounts := make(map[string]int)
-	
+
 	for _, card := range sortedCards {
 		rankCounts[card.Rank]++
 		suitCounts[card.Suit]++
 	}
-	
+
 	// Verificar flush - necesitamos exactamente 5 cartas del mismo palo
 	isFlush := false
 	flushSuit := ""
@@ -119,10 +238,10 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			break
 		}
 	}
-	
+
 	// Verificar straight
 	isStraight, straightHigh := checkStraight(sortedCards)
-	
+
 	// Evaluar combinaciones
 	if isFlush && isStraight {
 		if straightHigh == 14 { // A-K-Q-J-10
@@ -140,7 +259,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "Straight Flush",
 		}
 	}
-	
+
 	// Buscar grupos de ranks
 	var pairs, threes, fours []string
 	for rank, count := range rankCounts {
@@ -153,7 +272,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			fours = append(fours, rank)
 		}
 	}
-	
+
 	// Four of a kind
 	if len(fours) > 0 {
 		fourValue := CardValue(fours[0])
@@ -164,7 +283,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "Four of a Kind",
 		}
 	}
-	
+
 	// Full house
 	if len(threes) > 0 && len(pairs) > 0 {
 		threeValue := CardValue(threes[0])
@@ -176,7 +295,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "Full House",
 		}
 	}
-	
+
 	// Flush
 	if isFlush {
 		// Obtener las 5 cartas más altas del palo del flush
@@ -186,7 +305,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 				flushCards = append(flushCards, card)
 			}
 		}
-		
+
 		if len(flushCards) >= 5 {
 			highCard := CardValue(flushCards[0].Rank)
 			return HandEvaluation{
@@ -197,7 +316,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			}
 		}
 	}
-	
+
 	// Straight
 	if isStraight {
 		return HandEvaluation{
@@ -207,7 +326,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "Straight",
 		}
 	}
-	
+
 	// Three of a kind
 	if len(threes) > 0 {
 		threeValue := CardValue(threes[0])
@@ -218,7 +337,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "Three of a Kind",
 		}
 	}
-	
+
 	// Two pair
 	if len(pairs) >= 2 {
 		// Ordenar pairs por valor
@@ -234,11 +353,11 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "Two Pair",
 		}
 	}
-	
+
 	// One pair
 	if len(pairs) > 0 {
 		pairValue := CardValue(pairs[0])
-		
+
 		// Calcular kickers (las 3 cartas más altas que no sean el par)
 		kickers := make([]int, 0, 3)
 		for _, card := range sortedCards {
@@ -246,7 +365,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 				kickers = append(kickers, CardValue(card.Rank))
 			}
 		}
-		
+
 		// Incluir kickers en el valor
 		kickerValue := 0
 		if len(kickers) > 0 {
@@ -258,7 +377,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 		if len(kickers) > 2 {
 			kickerValue += kickers[2] // Tercer kicker
 		}
-		
+
 		return HandEvaluation{
 			Rank:     OnePair,
 			Value:    200000 + pairValue*1000 + kickerValue,
@@ -266,7 +385,7 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 			RankName: "One Pair",
 		}
 	}
-	
+
 	// High card
 	highCard := CardValue(sortedCards[0].Rank)
 	return HandEvaluation{
@@ -277,12 +396,24 @@ func evaluateFiveCards(cards []Card) HandEvaluation {
 	}
 }
 
+// Valores que forman la escalera baja con el As en cada deck
+var (
+	standardWheel  = []int{14, 2, 3, 4, 5}
+	shortDeckWheel = []int{14, 6, 7, 8, 9}
+)
+
 // checkStraight verifica si hay una escalera
 func checkStraight(sortedCards []Card) (bool, int) {
+	return checkStraightWithWheel(sortedCards, standardWheel)
+}
+
+// checkStraightWithWheel verifica si hay una escalera, usando wheel como la
+// escalera baja con el As (la carta alta de esa escalera es la última del wheel)
+func checkStraightWithWheel(sortedCards []Card, wheel []int) (bool, int) {
 	if len(sortedCards) < 5 {
 		return false, 0
 	}
-	
+
 	values := make([]int, 0, len(sortedCards))
 	for _, card := range sortedCards {
 		value := CardValue(card.Rank)
@@ -291,42 +422,29 @@ func checkStraight(sortedCards []Card) (bool, int) {
 			values = append(values, value)
 		}
 	}
-	
+
 	// Verificar escalera normal
 	for i := 0; i <= len(values)-5; i++ {
 		if values[i]-values[i+4] == 4 {
 			return true, values[i]
 		}
 	}
-	
-	// Verificar escalera baja A-2-3-4-5
+
+	// Verificar escalera baja con el As
 	if len(values) >= 5 {
-		hasAce := false
-		has2 := false
-		has3 := false
-		has4 := false
-		has5 := false
-		
+		present := make(map[int]bool, len(values))
 		for _, v := range values {
-			switch v {
-			case 14:
-				hasAce = true
-			case 2:
-				has2 = true
-			case 3:
-				has3 = true
-			case 4:
-				has4 = true
-			case 5:
-				has5 = true
-			}
+			present[v] = true
 		}
-		
-		if hasAce && has2 && has3 && has4 && has5 {
-			return true, 5 // El 5 es la carta alta en esta escalera
+
+		for _, v := range wheel {
+			if !present[v] {
+				return false, 0
+			}
 		}
+		return true, wheel[len(wheel)-1] // La última carta del wheel es la carta alta en esta escalera
 	}
-	
+
 	return false, 0
 }
 
@@ -335,31 +453,31 @@ func generateCombinations(cards []Card, k int) [][]Card {
 	if k > len(cards) {
 		return [][]Card{}
 	}
-	
+
 	if k == 0 {
 		return [][]Card{{}}
 	}
-	
+
 	if k == len(cards) {
 		return [][]Card{cards}
 	}
-	
+
 	var result [][]Card
-	
+
 	// Incluir el primer elemento
 	head := cards[0]
 	tail := cards[1:]
-	
+
 	for _, combo := range generateCombinations(tail, k-1) {
 		newCombo := make([]Card, 0, k)
 		newCombo = append(newCombo, head)
 		newCombo = append(newCombo, combo...)
 		result = append(result, newCombo)
 	}
-	
+
 	// No incluir el primer elemento
 	result = append(result, generateCombinations(tail, k)...)
-	
+
 	return result
 }
 
@@ -378,22 +496,22 @@ func DetermineWinners(table *PokerTable) []int {
 	if len(table.Players) == 0 {
 		return []int{}
 	}
-	
+
 	var winners []int
-	bestEvaluation := HandEvaluation{Value: -1}
-	
+	bestValue := -1
+
 	for i, player := range table.Players {
 		if player.IsActive && !player.HasFolded && len(player.Cards) > 0 {
-			evaluation := EvaluateHand(player.Cards, table.CommunityCards)
-			
-			if evaluation.Value > bestEvaluation.Value {
-				bestEvaluation = evaluation
+			value := showdownValue(table, player, table.CommunityCards)
+
+			if value > bestValue {
+				bestValue = value
 				winners = []int{i}
-			} else if evaluation.Value == bestEvaluation.Value {
+			} else if value == bestValue {
 				winners = append(winners, i)
 			}
 		}
 	}
-	
+
 	return winners
-}
\ No newline at end of file
+}
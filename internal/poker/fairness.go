@@ -0,0 +1,218 @@
+package poker
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ====== BARAJADO PROVABLY FAIR ======
+//
+// Commit-reveal por mano:
+//  1. Antes de la mano la mesa publica NextSeedHash = sha256(server seed).
+//  2. Los jugadores pueden cargar una client seed que se mezcla en el barajado.
+//  3. Al empezar la mano el deck se baraja con HMAC-SHA256 del server seed y las
+//     client seeds, y se publica el hash del deck barajado junto al server seed.
+//  4. Al terminar la mano se revela el server seed; VerifyDeal recalcula el deck
+//     sin depender del engine.
+//
+// Solo se publica la prueba con la fuente crypto: los decks con semilla o
+// programados son para tests y QA. El rebarajado de descartes en triple draw
+// no está cubierto por la prueba.
+
+const (
+	serverSeedBytes   = 32
+	maxClientSeedSize = 64
+)
+
+// ClientSeed es la semilla que aportó un jugador para una mano
+type ClientSeed struct {
+	PlayerID string `json:"player_id"`
+	Seed     string `json:"seed"`
+}
+
+// FairnessProof es la prueba de barajado de una mano
+type FairnessProof struct {
+	HandNumber     int          `json:"hand_number"`
+	Variant        string       `json:"variant"`
+	ServerSeedHash string       `json:"server_seed_hash"`      // Compromiso publicado antes de la mano
+	DeckHash       string       `json:"deck_hash"`             // sha256 del deck barajado + server seed
+	ClientSeeds    []ClientSeed `json:"client_seeds"`          // En orden de asiento
+	ServerSeed     string       `json:"server_seed,omitempty"` // Se revela al terminar la mano
+}
+
+// SetClientSeed guarda la client seed del jugador para las próximas manos
+func (pe *PokerEngine) SetClientSeed(tableID, playerID, seed string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if seed == "" || len(seed) > maxClientSeedSize {
+		return nil, fmt.Errorf("la client seed debe tener entre 1 y %d caracteres", maxClientSeedSize)
+	}
+
+	table.Players[seat].ClientSeed = seed
+	return table, nil
+}
+
+// commitNextSeed genera el server seed de la próxima mano y publica su hash
+func (pe *PokerEngine) commitNextSeed(table *PokerTable) {
+	seed := make([]byte, serverSeedBytes)
+	if _, err := rand.Read(seed); err != nil {
+		panic(fmt.Sprintf("no se pudo generar el server seed: %v", err))
+	}
+	table.NextServerSeed = hex.EncodeToString(seed)
+	table.NextSeedHash = hashHex(table.NextServerSeed)
+}
+
+// shuffleHandDeck baraja el deck de la mano con el server seed comprometido y
+// las client seeds de los jugadores que juegan la mano
+func (pe *PokerEngine) shuffleHandDeck(table *PokerTable, activePlayers []int) []Card {
+	if _, fair := pe.deckSource.(CryptoDeckSource); !fair {
+		table.Fairness = nil
+		table.ServerSeed = ""
+		return pe.createTableDeck(table)
+	}
+
+	if table.NextServerSeed == "" {
+		pe.commitNextSeed(table)
+	}
+
+	proof := &FairnessProof{
+		HandNumber:     table.HandNumber,
+		Variant:        table.Variant,
+		ServerSeedHash: table.NextSeedHash,
+	}
+	for _, seat := range activePlayers {
+		if seed := table.Players[seat].ClientSeed; seed != "" {
+			proof.ClientSeeds = append(proof.ClientSeeds, ClientSeed{PlayerID: table.Players[seat].ID, Seed: seed})
+		}
+	}
+
+	table.ServerSeed = table.NextServerSeed
+	deck := fairShuffle(table.Variant, table.ServerSeed, proof.ClientSeeds, proof.HandNumber)
+	proof.DeckHash = deckHash(deck, table.ServerSeed)
+	table.Fairness = proof
+
+	// El compromiso de la próxima mano se publica antes de que empiece
+	pe.commitNextSeed(table)
+	return deck
+}
+
+// revealServerSeed publica el server seed de la mano terminada
+func (pe *PokerEngine) revealServerSeed(table *PokerTable) {
+	if table.Fairness != nil {
+		table.Fairness.ServerSeed = table.ServerSeed
+	}
+}
+
+// VerifyDeal verifica una prueba revelada y retorna el deck que produjo.
+// No depende del engine: cualquiera puede recalcular el deck con los datos publicados.
+func VerifyDeal(proof FairnessProof) ([]Card, error) {
+	if proof.ServerSeed == "" {
+		return nil, fmt.Errorf("la prueba no tiene el server seed revelado")
+	}
+	if hashHex(proof.ServerSeed) != proof.ServerSeedHash {
+		return nil, fmt.Errorf("el server seed no coincide con el compromiso publicado")
+	}
+
+	deck := fairShuffle(proof.Variant, proof.ServerSeed, proof.ClientSeeds, proof.HandNumber)
+	if deckHash(deck, proof.ServerSeed) != proof.DeckHash {
+		return nil, fmt.Errorf("el deck no coincide con el hash publicado")
+	}
+	return deck, nil
+}
+
+// VerifyFairness verifica la prueba de la mano contra el deck que se repartió
+func (h *HandHistory) VerifyFairness() error {
+	if h.Fairness == nil {
+		return fmt.Errorf("la mano no tiene prueba de barajado")
+	}
+	deck, err := VerifyDeal(*h.Fairness)
+	if err != nil {
+		return err
+	}
+	if !sameCards(deck, h.Deck) {
+		return fmt.Errorf("el deck repartido no coincide con la prueba")
+	}
+	return nil
+}
+
+// fairShuffle baraja el deck ordenado de la variante con Fisher-Yates, tomando
+// los números de HMAC-SHA256(server seed, "mezcla:mano:contador")
+func fairShuffle(variant, serverSeed string, clientSeeds []ClientSeed, handNumber int) []Card {
+	deck := newDeck(variant)
+	stream := &seedStream{
+		key:    []byte(serverSeed),
+		prefix: fmt.Sprintf("%s:%d", mixClientSeeds(clientSeeds), handNumber),
+	}
+	for i := len(deck) - 1; i > 0; i-- {
+		j := stream.intn(i + 1)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return deck
+}
+
+// mixClientSeeds combina las client seeds en un solo hash
+func mixClientSeeds(clientSeeds []ClientSeed) string {
+	var b strings.Builder
+	for _, seed := range clientSeeds {
+		fmt.Fprintf(&b, "%s=%s\n", seed.PlayerID, seed.Seed)
+	}
+	return hashHex(b.String())
+}
+
+// deckHash es el hash del deck barajado (en formato "Ah Kd ...") junto al server seed
+func deckHash(deck []Card, serverSeed string) string {
+	return hashHex(formatCards(deck) + ":" + serverSeed)
+}
+
+// hashHex retorna el sha256 en hexadecimal
+func hashHex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// seedStream genera números a partir de bloques HMAC-SHA256 consecutivos
+type seedStream struct {
+	key     []byte
+	prefix  string
+	counter int
+	buf     []byte
+}
+
+// uint32 lee los próximos 4 bytes del stream
+func (s *seedStream) uint32() uint32 {
+	if len(s.buf) < 4 {
+		mac := hmac.New(sha256.New, s.key)
+		fmt.Fprintf(mac, "%s:%d", s.prefix, s.counter)
+		s.counter++
+		s.buf = mac.Sum(nil)
+	}
+	value := binary.BigEndian.Uint32(s.buf[:4])
+	s.buf = s.buf[4:]
+	return value
+}
+
+// intn retorna un número uniforme en [0, n) descartando los valores sesgados
+func (s *seedStream) intn(n int) int {
+	limit := ^uint32(0) - ^uint32(0)%uint32(n)
+	for {
+		if value := s.uint32(); value < limit {
+			return int(value % uint32(n))
+		}
+	}
+}
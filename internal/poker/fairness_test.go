@@ -0,0 +1,150 @@
+package poker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestFairShuffleCommitReveal verifica el ciclo completo: compromiso, mano y revelación
+func TestFairShuffleCommitReveal(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "fair_commit_reveal", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+	table.AutoRestart = false
+
+	commitment := table.NextSeedHash
+	if len(commitment) != 64 {
+		t.Fatalf("Expected a published commitment before the first hand, got %q", commitment)
+	}
+	if _, err := engine.SetClientSeed(table.ID, "seat0", "lucky"); err != nil {
+		t.Fatalf("Unexpected error setting client seed: %v", err)
+	}
+
+	engine.startHand(table)
+	proof := table.Fairness
+	if proof == nil || proof.ServerSeedHash != commitment || proof.DeckHash == "" {
+		t.Fatalf("Expected the hand to use the published commitment, got %+v", proof)
+	}
+	if len(proof.ClientSeeds) != 1 || proof.ClientSeeds[0] != (ClientSeed{PlayerID: "seat0", Seed: "lucky"}) {
+		t.Errorf("Expected seat0's client seed in the proof, got %v", proof.ClientSeeds)
+	}
+	if table.NextSeedHash == commitment {
+		t.Error("Next hand should have a new commitment")
+	}
+
+	// Durante la mano el server seed no se publica
+	data, _ := json.Marshal(table)
+	if proof.ServerSeed != "" || strings.Contains(string(data), table.ServerSeed) {
+		t.Fatal("Server seed should stay secret until the hand ends")
+	}
+	if _, err := VerifyDeal(*proof); err == nil {
+		t.Error("Verification should fail before the seed is revealed")
+	}
+
+	mustAct(t, engine, table, "fold", 0)
+
+	if table.Fairness.ServerSeed == "" {
+		t.Fatal("Server seed should be revealed when the hand ends")
+	}
+	history := table.HandHistories[0]
+	deck, err := VerifyDeal(*history.Fairness)
+	if err != nil {
+		t.Fatalf("Unexpected verification error: %v", err)
+	}
+	if !sameCards(deck, history.Deck) {
+		t.Error("Verified deck should match the dealt deck")
+	}
+	if err := history.VerifyFairness(); err != nil {
+		t.Errorf("Unexpected history verification error: %v", err)
+	}
+}
+
+// TestVerifyDealRejectsTampering verifica que una prueba alterada no se acepte
+func TestVerifyDealRejectsTampering(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "fair_tampering", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+	table.AutoRestart = false
+	engine.SetClientSeed(table.ID, "seat1", "seed")
+	engine.startHand(table)
+	mustAct(t, engine, table, "fold", 0)
+	history := table.HandHistories[0]
+	proof := *history.Fairness
+
+	wrongSeed := proof
+	wrongSeed.ServerSeed = strings.Repeat("0", 64)
+	if _, err := VerifyDeal(wrongSeed); err == nil || !strings.Contains(err.Error(), "compromiso") {
+		t.Errorf("Expected a commitment mismatch, got %v", err)
+	}
+
+	otherClient := proof
+	otherClient.ClientSeeds = []ClientSeed{{PlayerID: "seat1", Seed: "other"}}
+	if _, err := VerifyDeal(otherClient); err == nil || !strings.Contains(err.Error(), "hash") {
+		t.Errorf("Expected a deck hash mismatch, got %v", err)
+	}
+
+	// El deck grabado no es el de la prueba
+	tampered := *history
+	tampered.Deck = append([]Card(nil), history.Deck...)
+	tampered.Deck[0], tampered.Deck[1] = tampered.Deck[1], tampered.Deck[0]
+	if err := tampered.VerifyFairness(); err == nil {
+		t.Error("Expected a dealt deck mismatch")
+	}
+}
+
+// TestFairShuffleDeterministic verifica que el deck dependa solo del server seed, las client seeds y la mano
+func TestFairShuffleDeterministic(t *testing.T) {
+	seeds := []ClientSeed{{PlayerID: "a", Seed: "x"}}
+	deck := fairShuffle(VariantHoldem, "server", seeds, 1)
+
+	if !sameCards(deck, fairShuffle(VariantHoldem, "server", seeds, 1)) {
+		t.Fatal("Same inputs should produce the same deck")
+	}
+	if sameCards(deck, fairShuffle(VariantHoldem, "server", []ClientSeed{{PlayerID: "a", Seed: "y"}}, 1)) {
+		t.Error("Client seeds should change the deck")
+	}
+	if sameCards(deck, fairShuffle(VariantHoldem, "server", seeds, 2)) {
+		t.Error("Hand number should change the deck")
+	}
+
+	seen := make(map[Card]bool)
+	for _, card := range deck {
+		seen[card] = true
+	}
+	if len(deck) != 52 || len(seen) != 52 {
+		t.Errorf("Expected 52 unique cards, got %d (%d unique)", len(deck), len(seen))
+	}
+	if short := fairShuffle(VariantShortDeck, "server", nil, 1); len(short) != 36 {
+		t.Errorf("Expected a 36-card short deck, got %d", len(short))
+	}
+}
+
+// TestSetClientSeed verifica la validación de las client seeds y las fuentes sin prueba
+func TestSetClientSeed(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "fair_client_seed", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+
+	if _, err := engine.SetClientSeed(table.ID, "seat0", ""); err == nil {
+		t.Error("Empty seed should be rejected")
+	}
+	if _, err := engine.SetClientSeed(table.ID, "seat0", strings.Repeat("x", maxClientSeedSize+1)); err == nil {
+		t.Error("Long seed should be rejected")
+	}
+	if _, err := engine.SetClientSeed(table.ID, "nobody", "seed"); err == nil {
+		t.Error("Unknown player should be rejected")
+	}
+	if _, err := engine.SetClientSeed("missing", "seat0", "seed"); err == nil {
+		t.Error("Unknown table should be rejected")
+	}
+
+	// Los decks programados no publican prueba de barajado
+	scripted := NewPokerEngineWithDeckSource(NewScriptedDeckSource())
+	qaTable := newTestTable(t, scripted, "fair_scripted", seatConfig(6))
+	mustSeat(t, scripted, qaTable, 0, 1)
+	scripted.startHand(qaTable)
+	if qaTable.Fairness != nil {
+		t.Error("Scripted decks should not publish a fairness proof")
+	}
+}
@@ -3,6 +3,7 @@ package poker
 import (
 	"crypto/rand"
 	"math/big"
+	mathrand "math/rand"
 	"testing"
 )
 
@@ -82,6 +83,37 @@ func FuzzEvaluateHand(f *testing.F) {
 	})
 }
 
+// FuzzLookupEvaluate verifica que el evaluador con tablas ordene las manos igual que EvaluateHand
+func FuzzLookupEvaluate(f *testing.F) {
+	f.Add(int64(1), uint8(7))
+	f.Add(int64(42), uint8(5))
+	f.Add(int64(-7), uint8(6))
+	
+	f.Fuzz(func(t *testing.T, seed int64, size uint8) {
+		// Dos manos de 5 a 7 cartas que comparten el board, como en un showdown
+		n := 5 + int(size%3)
+		cards := randomCards(mathrand.New(mathrand.NewSource(seed)), n+2)
+		hand1 := cards[:n]
+		hand2 := append([]Card{cards[n], cards[n+1]}, cards[2:n]...)
+		
+		expected1 := EvaluateHand(hand1[:2], hand1[2:])
+		expected2 := EvaluateHand(hand2[:2], hand2[2:])
+		got1, err1 := LookupEvaluate(hand1)
+		got2, err2 := LookupEvaluate(hand2)
+		if err1 != nil || err2 != nil {
+			t.Fatalf("Unexpected error: %v / %v", err1, err2)
+		}
+		
+		if got1.Value != expected1.Value || got2.Value != expected2.Value {
+			t.Fatalf("Values differ: %s %d vs %d, %s %d vs %d", formatCards(hand1), got1.Value, expected1.Value,
+				formatCards(hand2), got2.Value, expected2.Value)
+		}
+		if CompareHands(got1, got2) != CompareHands(expected1, expected2) {
+			t.Fatalf("Ordering differs for %s vs %s", formatCards(hand1), formatCards(hand2))
+		}
+	})
+}
+
 // FuzzPlayerAction fuzzes las acciones de los jugadores
 func FuzzPlayerAction(f *testing.F) {
 	// Seed con acciones válidas
@@ -0,0 +1,817 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ====== HISTORIAL DE MANOS ======
+//
+// Cada mano registra los asientos, las apuestas forzadas, las cartas, las
+// acciones de cada calle, el board y el reparto. Al terminar la mano el
+// historial queda guardado en la mesa y se puede exportar en el formato de
+// texto de PokerStars para importarlo en software de tracking.
+
+// maxHandHistories es la cantidad de manos terminadas que guarda cada mesa
+const maxHandHistories = 100
+
+// HandHistory es el registro completo de una mano
+type HandHistory struct {
+	HandID           int64           `json:"hand_id"`
+	TableID          string          `json:"table_id"`
+	HandNumber       int             `json:"hand_number"`
+	Variant          string          `json:"variant"`
+	BettingStructure string          `json:"betting_structure"`
+	SmallBlind       int             `json:"small_blind"`
+	BigBlind         int             `json:"big_blind"`
+	MaxSeats         int             `json:"max_seats"`
+	Button           int             `json:"button"`           // Asiento del button (-1 en stud)
+	SmallBlindSeat   int             `json:"small_blind_seat"` // -1 si el small blind estuvo muerto
+	BigBlindSeat     int             `json:"big_blind_seat"`
+	StartTime        time.Time       `json:"start_time"`
+	EndTime          time.Time       `json:"end_time"`
+	Seats            []HistorySeat   `json:"seats"`   // Jugadores que recibieron cartas
+	Actions          []HistoryAction `json:"actions"` // En orden, incluyendo antes y blinds
+	Boards           [][]Card        `json:"boards"`  // Un board por runout
+	ShowdownOrder    []int           `json:"showdown_order"`
+	Result           *HandResult     `json:"result"`
+
+	// Datos para reproducir la mano (ver ReplayHand)
+	Config             TableConfig `json:"config"`                // Configuración de la mesa durante la mano
+	Deck               []Card      `json:"deck"`                  // Orden del deck antes de repartir
+	PrevButton         int         `json:"prev_button"`           // Button de la mano anterior
+	PrevSmallBlindSeat int         `json:"prev_small_blind_seat"` // Small blind de la mano anterior
+	PrevBigBlindSeat   int         `json:"prev_big_blind_seat"`   // Big blind de la mano anterior
+
+	Fairness *FairnessProof `json:"fairness,omitempty"` // Prueba de barajado con el server seed revelado
+}
+
+// HistorySeat es un jugador que jugó la mano
+type HistorySeat struct {
+	Seat       int    `json:"seat"`
+	PlayerID   string `json:"player_id"`
+	Name       string `json:"name"`
+	Stack      int    `json:"stack"`       // Fichas al comenzar la mano
+	FinalStack int    `json:"final_stack"` // Fichas al terminar la mano
+	HoleCards  []Card `json:"hole_cards"`  // Cartas privadas repartidas al comenzar
+	Cards      []Card `json:"cards"`       // Cartas tapadas al terminar (cambian en draw, crecen en stud)
+	UpCards    []Card `json:"up_cards"`    // Cartas descubiertas (stud)
+	Shown      bool   `json:"shown"`
+	Mucked     bool   `json:"mucked"`
+	Invested   int    `json:"invested"`            // Fichas que puso en la mano (antes incluidos)
+	HandName   string `json:"hand_name,omitempty"` // Mano mostrada en el showdown
+
+	StraddleRequested bool `json:"straddle_requested,omitempty"` // Había pedido straddle al comenzar la mano
+	OwedBlinds        int  `json:"owed_blinds,omitempty"`        // Blinds perdidos que debía al comenzar la mano
+}
+
+// HistoryAction es una acción registrada durante la mano
+type HistoryAction struct {
+	Street  string `json:"street"` // Fase de la mesa cuando ocurrió
+	Seat    int    `json:"seat"`
+	Action  string `json:"action"`             // ante, small_blind, big_blind, missed_blinds, straddle, bring_in, fold, check, call, bet, raise, draw
+	Amount  int    `json:"amount"`             // Fichas puestas; en un raise lo que sube, en draw las cartas cambiadas
+	RaiseTo int    `json:"raise_to,omitempty"` // Apuesta total después de un raise
+	AllIn   bool   `json:"all_in,omitempty"`
+	Cards   []Card `json:"cards,omitempty"`   // Cartas descartadas (draw)
+	Command string `json:"command,omitempty"` // Acción que pidió el jugador (fold, call, raise, all_in, draw, run_it_twice)
+}
+
+// lastHandID garantiza IDs de mano únicos y crecientes dentro del proceso
+var lastHandID int64
+
+// nextHandID genera el ID de una mano a partir de la hora de inicio
+func nextHandID(start time.Time) int64 {
+	for {
+		last := atomic.LoadInt64(&lastHandID)
+		id := start.UnixMicro()
+		if id <= last {
+			id = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastHandID, last, id) {
+			return id
+		}
+	}
+}
+
+// beginHandHistory empieza el registro de la mano con los stacks antes de antes y blinds
+func (pe *PokerEngine) beginHandHistory(table *PokerTable) {
+	start := time.Now()
+	history := &HandHistory{
+		HandID:           nextHandID(start),
+		TableID:          table.ID,
+		HandNumber:       table.HandNumber,
+		Variant:          normalizeVariant(table.Variant),
+		BettingStructure: normalizeBettingStructure(table.BettingStructure, table.Variant),
+		SmallBlind:       table.SmallBlind,
+		BigBlind:         table.BigBlind,
+		MaxSeats:         seatCount(table),
+		Button:           -1,
+		SmallBlindSeat:   -1,
+		BigBlindSeat:     -1,
+		StartTime:        start,
+
+		Config:             tableConfig(table),
+		Deck:               append([]Card(nil), table.Deck...),
+		PrevButton:         table.DealerPosition,
+		PrevSmallBlindSeat: table.SmallBlindSeat,
+		PrevBigBlindSeat:   table.BigBlindSeat,
+	}
+
+	for seat, player := range table.Players {
+		if !player.IsActive || isEmptySeat(player) {
+			continue
+		}
+		history.Seats = append(history.Seats, HistorySeat{
+			Seat:     seat,
+			PlayerID: player.ID,
+			Name:     player.Name,
+			Stack:    player.Stack,
+
+			StraddleRequested: player.StraddleRequested,
+			OwedBlinds:        player.OwedBlinds,
+		})
+	}
+
+	table.History = history
+}
+
+// recordHoleCards guarda las cartas repartidas y las posiciones de la mano
+func (pe *PokerEngine) recordHoleCards(table *PokerTable) {
+	history := table.History
+	if history == nil {
+		return
+	}
+
+	if table.Variant != VariantStud {
+		history.Button = table.DealerPosition
+		history.SmallBlindSeat = table.SmallBlindSeat
+		if !isActiveSeat(table, table.SmallBlindSeat) {
+			history.SmallBlindSeat = -1
+		}
+		history.BigBlindSeat = table.BigBlindSeat
+	}
+
+	for i := range history.Seats {
+		seat := &history.Seats[i]
+		seat.HoleCards = append([]Card(nil), table.Players[seat.Seat].Cards...)
+	}
+}
+
+// recordAction agrega una acción al historial de la mano en curso
+func (pe *PokerEngine) recordAction(table *PokerTable, seat int, action string, amount, raiseTo int) {
+	if table.History == nil {
+		return
+	}
+	player := table.Players[seat]
+	table.History.Actions = append(table.History.Actions, HistoryAction{
+		Street:  table.Phase,
+		Seat:    seat,
+		Action:  action,
+		Amount:  amount,
+		RaiseTo: raiseTo,
+		AllIn:   player.IsAllIn || player.Stack == 0,
+	})
+}
+
+// recordPlayerAction registra una acción de apuesta ya aplicada.
+// betBefore es la apuesta del jugador y facing la apuesta de la mesa antes de actuar.
+func (pe *PokerEngine) recordPlayerAction(table *PokerTable, seat int, action string, betBefore, facing int) {
+	if table.History == nil {
+		return
+	}
+	player := table.Players[seat]
+	put := player.CurrentBet - betBefore
+
+	switch {
+	case action == "fold" || action == "check":
+		pe.recordAction(table, seat, action, 0, 0)
+	case player.CurrentBet <= facing:
+		// Un call, o un all-in que no alcanza a igualar
+		pe.recordAction(table, seat, "call", put, 0)
+	case facing == 0:
+		pe.recordAction(table, seat, "bet", put, 0)
+	default:
+		pe.recordAction(table, seat, "raise", player.CurrentBet-facing, player.CurrentBet)
+	}
+	table.History.Actions[len(table.History.Actions)-1].Command = action
+}
+
+// recordDraw registra las cartas descartadas por un jugador en una ronda de draw
+func (pe *PokerEngine) recordDraw(table *PokerTable, seat int, discarded []Card) {
+	if table.History == nil {
+		return
+	}
+	pe.recordAction(table, seat, "draw", len(discarded), 0)
+	last := &table.History.Actions[len(table.History.Actions)-1]
+	last.Cards = discarded
+	last.Command = "draw"
+}
+
+// recordRunItTwiceVote registra cuántas veces pidió correr el board un jugador
+func (pe *PokerEngine) recordRunItTwiceVote(table *PokerTable, seat, runs int) {
+	if table.History == nil {
+		return
+	}
+	pe.recordAction(table, seat, "run_it_twice", runs, 0)
+	table.History.Actions[len(table.History.Actions)-1].Command = "run_it_twice"
+}
+
+// finishHandHistory cierra el registro de la mano y lo guarda en la mesa
+func (pe *PokerEngine) finishHandHistory(table *PokerTable) {
+	history := table.History
+	if history == nil {
+		return
+	}
+	table.History = nil
+
+	history.EndTime = time.Now()
+	history.Result = table.HandResult
+	history.ShowdownOrder = append([]int(nil), table.ShowdownOrder...)
+	if table.Fairness != nil {
+		proof := *table.Fairness
+		proof.ClientSeeds = append([]ClientSeed(nil), table.Fairness.ClientSeeds...)
+		history.Fairness = &proof
+	}
+	if usesBoard(table.Variant) {
+		for _, board := range showdownBoards(table) {
+			history.Boards = append(history.Boards, append([]Card(nil), board...))
+		}
+	}
+
+	// Solo se arma la mejor mano de quienes la mostraron en el showdown
+	showdown := len(table.ShowdownOrder) > 1
+	for i := range history.Seats {
+		seat := &history.Seats[i]
+		player := table.Players[seat.Seat]
+		seat.FinalStack = player.Stack
+		seat.Invested = playerInvestment(player)
+		seat.Cards = append([]Card(nil), player.Cards...)
+		seat.UpCards = append([]Card(nil), player.UpCards...)
+		seat.Shown = player.CardsRevealed
+		seat.Mucked = player.HasMucked
+		if showdown && seat.Shown && player.IsActive && !player.HasFolded {
+			if hand := showdownHand(table, seat.Seat, showdownBoards(table)[0]); hand != nil {
+				seat.HandName = hand.RankName
+			}
+		}
+	}
+
+	table.HandHistories = append(table.HandHistories, history)
+	if len(table.HandHistories) > maxHandHistories {
+		table.HandHistories = table.HandHistories[len(table.HandHistories)-maxHandHistories:]
+	}
+}
+
+// recordShownCards marca en el historial de la última mano que el jugador mostró sus cartas
+func (pe *PokerEngine) recordShownCards(table *PokerTable, seat int) {
+	if len(table.HandHistories) == 0 {
+		return
+	}
+	history := table.HandHistories[len(table.HandHistories)-1]
+	if history.HandNumber != table.HandNumber {
+		return
+	}
+	if played := history.seatAt(seat); played != nil {
+		played.Shown = true
+		played.Mucked = false
+		if played.HandName == "" && hasShowdownHand(table, table.Players[seat], table.CommunityCards) {
+			played.HandName = evaluatePlayerHand(table, table.Players[seat]).RankName
+		}
+	}
+}
+
+// GetHandHistories retorna las últimas manos terminadas en la mesa (la más vieja primero)
+func (pe *PokerEngine) GetHandHistories(tableID string) ([]*HandHistory, error) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	return append([]*HandHistory(nil), table.HandHistories...), nil
+}
+
+// HasPlayer indica si el jugador recibió cartas en la mano
+func (h *HandHistory) HasPlayer(playerID string) bool {
+	return h.seat(playerID) != nil
+}
+
+// seat retorna el jugador de la mano con ese ID (nil si no jugó)
+func (h *HandHistory) seat(playerID string) *HistorySeat {
+	for i := range h.Seats {
+		if h.Seats[i].PlayerID == playerID {
+			return &h.Seats[i]
+		}
+	}
+	return nil
+}
+
+// seatAt retorna el jugador de la mano sentado en el asiento
+func (h *HandHistory) seatAt(seat int) *HistorySeat {
+	for i := range h.Seats {
+		if h.Seats[i].Seat == seat {
+			return &h.Seats[i]
+		}
+	}
+	return nil
+}
+
+// ====== FORMATO POKERSTARS ======
+
+// FormatHandHistories exporta varias manos en un único archivo de texto
+func FormatHandHistories(histories []*HandHistory, heroID string) string {
+	texts := make([]string, 0, len(histories))
+	for _, history := range histories {
+		texts = append(texts, history.Format(heroID))
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// Format escribe la mano en el formato de texto de PokerStars. Con heroID solo se
+// muestran las cartas de ese jugador y las que se mostraron en el showdown; sin
+// heroID (resolución de disputas) se muestran todas las cartas repartidas.
+func (h *HandHistory) Format(heroID string) string {
+	var b strings.Builder
+	visible := func(seat *HistorySeat) bool {
+		return heroID == "" || seat.PlayerID == heroID
+	}
+
+	fmt.Fprintf(&b, "PokerStars Hand #%d: %s %s (%d/%d) - %s UTC\n", h.HandID,
+		historyGameName(h.Variant), historyLimitName(h.BettingStructure),
+		h.SmallBlind, h.BigBlind, h.StartTime.UTC().Format("2006/01/02 15:04:05"))
+	if h.Button >= 0 {
+		fmt.Fprintf(&b, "Table '%s' %d-max Seat #%d is the button\n", h.TableID, h.MaxSeats, h.Button+1)
+	} else {
+		fmt.Fprintf(&b, "Table '%s' %d-max\n", h.TableID, h.MaxSeats)
+	}
+	for _, seat := range h.Seats {
+		fmt.Fprintf(&b, "Seat %d: %s (%d in chips)\n", seat.Seat+1, seat.Name, seat.Stack)
+	}
+
+	// Antes, blinds y straddle van antes del reparto
+	actions := h.Actions
+	for len(actions) > 0 && isForcedBet(actions[0].Action) {
+		b.WriteString(h.formatAction(actions[0], heroID))
+		actions = actions[1:]
+	}
+
+	// Reparto inicial
+	firstStreet := "preflop"
+	switch h.Variant {
+	case VariantStud:
+		firstStreet = "third_street"
+	case VariantTripleDraw:
+		firstStreet = "predraw"
+	}
+	header := historyStreetHeader(firstStreet)
+	b.WriteString(h.formatStreet(firstStreet, heroID))
+
+	// Acciones de cada calle
+	printed := map[string]bool{header: true}
+	for _, action := range actions {
+		// El formato de PokerStars no registra la decisión de run it twice, solo los boards
+		if action.Action == "run_it_twice" {
+			continue
+		}
+		if next := historyStreetHeader(action.Street); next != header && !printed[next] {
+			header = next
+			printed[next] = true
+			b.WriteString(h.formatStreet(action.Street, heroID))
+		}
+		b.WriteString(h.formatAction(action, heroID))
+	}
+
+	// Calles que se repartieron sin acción (todos all-in), una vez por runout
+	for run, board := range h.Boards {
+		prefix := ""
+		if len(h.Boards) > 1 {
+			prefix = historyRunNames[run] + " "
+		}
+		for _, street := range []string{"flop", "turn", "river"} {
+			if !printed[historyStreetHeader(street)] {
+				b.WriteString(formatBoardStreet(prefix, street, board))
+			}
+		}
+	}
+
+	// Showdown y reparto
+	uncontested := h.Result == nil || h.Result.Uncontested
+	if !uncontested {
+		b.WriteString("*** SHOW DOWN ***\n")
+		for _, seat := range h.showdownSeats() {
+			if seat.Shown {
+				fmt.Fprintf(&b, "%s: shows %s (%s)\n", seat.Name, formatCards(seat.allCards()), seat.HandName)
+			} else if seat.Mucked {
+				fmt.Fprintf(&b, "%s: mucks hand\n", seat.Name)
+			}
+		}
+	}
+
+	// Lo que nadie igualó vuelve al jugador y no cuenta como parte del pot
+	uncalledSeat, uncalled := h.uncalledBet()
+	if uncalled > 0 {
+		fmt.Fprintf(&b, "Uncalled bet (%d) returned to %s\n", uncalled, h.seatAt(uncalledSeat).Name)
+	}
+
+	var pots []PotResult
+	if h.Result != nil {
+		pots = make([]PotResult, len(h.Result.Pots))
+		copy(pots, h.Result.Pots)
+	}
+	for i := len(pots) - 1; i >= 0 && uncalled > 0; i-- {
+		winners := append([]PotWinner(nil), pots[i].Winners...)
+		for j := range winners {
+			if winners[j].Seat != uncalledSeat {
+				continue
+			}
+			returned := winners[j].Amount
+			if returned > uncalled {
+				returned = uncalled
+			}
+			winners[j].Amount -= returned
+			pots[i].Amount -= returned
+			uncalled -= returned
+		}
+		pots[i].Winners = winners
+	}
+
+	totalPot, lastPot, rake := 0, 0, 0
+	potAmounts := make(map[int]int)
+	won := make(map[int]int)
+	for _, pot := range pots {
+		if pot.Amount > 0 && pot.PotIndex > lastPot {
+			lastPot = pot.PotIndex
+		}
+	}
+	for _, pot := range pots {
+		totalPot += pot.Amount + pot.Rake
+		rake += pot.Rake
+		potAmounts[pot.PotIndex] += pot.Amount
+		for _, winner := range pot.Winners {
+			if winner.Amount == 0 {
+				continue
+			}
+			won[winner.Seat] += winner.Amount
+			fmt.Fprintf(&b, "%s collected %d from %s\n", winner.Name, winner.Amount, historyPotName(pot.PotIndex, lastPot > 0))
+		}
+	}
+
+	// Resumen
+	b.WriteString("*** SUMMARY ***\n")
+	fmt.Fprintf(&b, "Total pot %d", totalPot)
+	if lastPot > 0 {
+		for index := 0; index <= lastPot; index++ {
+			if index == 0 {
+				fmt.Fprintf(&b, " Main pot %d.", potAmounts[index])
+			} else {
+				fmt.Fprintf(&b, " Side pot-%d %d.", index, potAmounts[index])
+			}
+		}
+	}
+	fmt.Fprintf(&b, " | Rake %d\n", rake)
+
+	switch len(h.Boards) {
+	case 0:
+	case 1:
+		if len(h.Boards[0]) > 0 {
+			fmt.Fprintf(&b, "Board %s\n", formatCards(h.Boards[0]))
+		}
+	default:
+		for run, board := range h.Boards {
+			fmt.Fprintf(&b, "%s Board %s\n", historyRunNames[run], formatCards(board))
+		}
+	}
+
+	for i := range h.Seats {
+		seat := &h.Seats[i]
+		fmt.Fprintf(&b, "Seat %d: %s%s %s\n", seat.Seat+1, seat.Name, h.positionLabel(seat.Seat), h.summaryOutcome(seat, won[seat.Seat], uncontested, visible(seat)))
+	}
+
+	return b.String()
+}
+
+// formatStreet escribe el encabezado de una calle y las cartas que se repartieron en ella
+func (h *HandHistory) formatStreet(street, heroID string) string {
+	var b strings.Builder
+
+	switch street {
+	case "flop", "turn", "river":
+		if len(h.Boards) > 0 {
+			return formatBoardStreet("", street, h.Boards[0])
+		}
+		return historyStreetHeader(street) + "\n"
+	}
+
+	b.WriteString(historyStreetHeader(street) + "\n")
+	for i := range h.Seats {
+		seat := &h.Seats[i]
+		mine := heroID == "" || seat.PlayerID == heroID
+
+		switch {
+		case street == "preflop" || street == "predraw":
+			if mine {
+				fmt.Fprintf(&b, "Dealt to %s %s\n", seat.Name, formatCards(seat.HoleCards))
+			}
+		case h.Variant == VariantStud:
+			b.WriteString(seat.studDeal(street, mine))
+		}
+	}
+	return b.String()
+}
+
+// studDeal escribe la carta que recibió un jugador en una calle de stud
+func (s *HistorySeat) studDeal(street string, mine bool) string {
+	switch street {
+	case "third_street":
+		if len(s.UpCards) == 0 {
+			return ""
+		}
+		if mine && len(s.Cards) >= 2 {
+			return fmt.Sprintf("Dealt to %s %s\n", s.Name, formatCards([]Card{s.Cards[0], s.Cards[1], s.UpCards[0]}))
+		}
+		return fmt.Sprintf("Dealt to %s %s\n", s.Name, formatCards(s.UpCards[:1]))
+	case "fourth_street", "fifth_street", "sixth_street":
+		index := map[string]int{"fourth_street": 1, "fifth_street": 2, "sixth_street": 3}[street]
+		if index < len(s.UpCards) {
+			return fmt.Sprintf("Dealt to %s %s %s\n", s.Name, formatCards(s.UpCards[:index]), formatCards(s.UpCards[index:index+1]))
+		}
+	case "seventh_street":
+		if mine && len(s.Cards) >= 3 {
+			return fmt.Sprintf("Dealt to %s %s\n", s.Name, formatCards(s.Cards[2:3]))
+		}
+	}
+	return ""
+}
+
+// formatAction escribe una acción en el formato de PokerStars
+func (h *HandHistory) formatAction(action HistoryAction, heroID string) string {
+	name := fmt.Sprintf("Seat %d", action.Seat+1)
+	seat := h.seatAt(action.Seat)
+	if seat != nil {
+		name = seat.Name
+	}
+
+	var line string
+	switch action.Action {
+	case "ante":
+		line = fmt.Sprintf("%s: posts the ante %d", name, action.Amount)
+	case "small_blind":
+		line = fmt.Sprintf("%s: posts small blind %d", name, action.Amount)
+	case "big_blind":
+		line = fmt.Sprintf("%s: posts big blind %d", name, action.Amount)
+	case "missed_blinds":
+		switch {
+		case action.Amount > h.BigBlind:
+			line = fmt.Sprintf("%s: posts small & big blinds %d", name, action.Amount)
+		case action.Amount == h.BigBlind:
+			line = fmt.Sprintf("%s: posts big blind %d", name, action.Amount)
+		default:
+			line = fmt.Sprintf("%s: posts small blind %d", name, action.Amount)
+		}
+	case "straddle":
+		line = fmt.Sprintf("%s: posts straddle %d", name, action.Amount)
+	case "bring_in":
+		line = fmt.Sprintf("%s: brings in for %d", name, action.Amount)
+	case "fold":
+		line = fmt.Sprintf("%s: folds", name)
+	case "check":
+		line = fmt.Sprintf("%s: checks", name)
+	case "call":
+		line = fmt.Sprintf("%s: calls %d", name, action.Amount)
+	case "bet":
+		line = fmt.Sprintf("%s: bets %d", name, action.Amount)
+	case "raise":
+		line = fmt.Sprintf("%s: raises %d to %d", name, action.Amount, action.RaiseTo)
+	case "draw":
+		if action.Amount == 0 {
+			return fmt.Sprintf("%s: stands pat\n", name)
+		}
+		line = fmt.Sprintf("%s: discards %d card", name, action.Amount)
+		if action.Amount > 1 {
+			line += "s"
+		}
+		if seat != nil && (heroID == "" || seat.PlayerID == heroID) {
+			line += " " + formatCards(action.Cards)
+		}
+		return line + "\n"
+	default:
+		line = fmt.Sprintf("%s: %s %d", name, action.Action, action.Amount)
+	}
+
+	if action.AllIn && action.Action != "fold" && action.Action != "check" {
+		line += " and is all-in"
+	}
+	return line + "\n"
+}
+
+// uncalledBet retorna el jugador que más puso y lo que nadie le igualó (0 si lo igualaron)
+func (h *HandHistory) uncalledBet() (int, int) {
+	top, highest, second := -1, 0, 0
+	for _, seat := range h.Seats {
+		switch {
+		case seat.Invested > highest:
+			top, highest, second = seat.Seat, seat.Invested, highest
+		case seat.Invested > second:
+			second = seat.Invested
+		}
+	}
+	if top == -1 {
+		return -1, 0
+	}
+	return top, highest - second
+}
+
+// showdownSeats retorna los jugadores que llegaron al showdown en el orden en que mostraron
+func (h *HandHistory) showdownSeats() []*HistorySeat {
+	seats := make([]*HistorySeat, 0, len(h.ShowdownOrder))
+	for _, index := range h.ShowdownOrder {
+		if seat := h.seatAt(index); seat != nil && (seat.Shown || seat.Mucked) {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// allCards retorna todas las cartas del jugador al terminar la mano
+func (s *HistorySeat) allCards() []Card {
+	cards := append([]Card(nil), s.Cards...)
+	return append(cards, s.UpCards...)
+}
+
+// positionLabel retorna la posición del asiento para el resumen
+func (h *HandHistory) positionLabel(seat int) string {
+	label := ""
+	if seat == h.Button {
+		label += " (button)"
+	}
+	if seat == h.SmallBlindSeat {
+		label += " (small blind)"
+	}
+	if seat == h.BigBlindSeat {
+		label += " (big blind)"
+	}
+	return label
+}
+
+// summaryOutcome describe cómo terminó la mano para un jugador en el resumen
+func (h *HandHistory) summaryOutcome(seat *HistorySeat, won int, uncontested, visible bool) string {
+	for _, action := range h.Actions {
+		if action.Seat == seat.Seat && action.Action == "fold" {
+			return "folded " + historyFoldStreet(action.Street)
+		}
+	}
+
+	switch {
+	case seat.Shown && won > 0:
+		return fmt.Sprintf("showed %s and won (%d) with %s", formatCards(seat.allCards()), won, seat.HandName)
+	case seat.Shown:
+		return fmt.Sprintf("showed %s and lost with %s", formatCards(seat.allCards()), seat.HandName)
+	case seat.Mucked:
+		if visible {
+			return fmt.Sprintf("mucked %s", formatCards(seat.allCards()))
+		}
+		return "mucked"
+	case won > 0:
+		return fmt.Sprintf("collected (%d)", won)
+	}
+	return "lost"
+}
+
+// isForcedBet indica si la acción es una apuesta obligatoria previa al reparto
+func isForcedBet(action string) bool {
+	return action == "ante" || action == "small_blind" || action == "big_blind" || action == "missed_blinds" || action == "straddle"
+}
+
+// historyStreetHeader retorna el encabezado de PokerStars para la fase
+func historyStreetHeader(street string) string {
+	switch street {
+	case "preflop":
+		return "*** HOLE CARDS ***"
+	case "flop":
+		return "*** FLOP ***"
+	case "turn":
+		return "*** TURN ***"
+	case "river", "seventh_street":
+		return "*** RIVER ***"
+	case "third_street":
+		return "*** 3rd STREET ***"
+	case "fourth_street":
+		return "*** 4th STREET ***"
+	case "fifth_street":
+		return "*** 5th STREET ***"
+	case "sixth_street":
+		return "*** 6th STREET ***"
+	case "predraw":
+		return "*** DEALING HANDS ***"
+	case "first_draw", "post_first_draw":
+		return "*** FIRST DRAW ***"
+	case "second_draw", "post_second_draw":
+		return "*** SECOND DRAW ***"
+	case "third_draw", "post_third_draw":
+		return "*** THIRD DRAW ***"
+	}
+	return "*** " + strings.ToUpper(street) + " ***"
+}
+
+// formatBoardStreet escribe el encabezado de flop, turn o river con las cartas del board
+func formatBoardStreet(prefix, street string, board []Card) string {
+	switch street {
+	case "flop":
+		if len(board) >= 3 {
+			return fmt.Sprintf("*** %sFLOP *** %s\n", prefix, formatCards(board[:3]))
+		}
+	case "turn":
+		if len(board) >= 4 {
+			return fmt.Sprintf("*** %sTURN *** %s %s\n", prefix, formatCards(board[:3]), formatCards(board[3:4]))
+		}
+	case "river":
+		if len(board) >= 5 {
+			return fmt.Sprintf("*** %sRIVER *** %s %s\n", prefix, formatCards(board[:4]), formatCards(board[4:5]))
+		}
+	}
+	return ""
+}
+
+// historyFoldStreet describe en qué calle se retiró un jugador
+func historyFoldStreet(street string) string {
+	switch street {
+	case "preflop":
+		return "before Flop"
+	case "flop":
+		return "on the Flop"
+	case "turn":
+		return "on the Turn"
+	case "river", "seventh_street":
+		return "on the River"
+	case "predraw":
+		return "before the Draw"
+	}
+	return "on " + strings.TrimSuffix(strings.TrimPrefix(historyStreetHeader(street), "*** "), " ***")
+}
+
+// historyRunNames nombra cada runout como lo hace PokerStars (hasta maxRunouts)
+var historyRunNames = []string{"FIRST", "SECOND", "THIRD"}
+
+// historyPotName nombra un pot como lo hace PokerStars
+func historyPotName(potIndex int, sidePots bool) string {
+	switch {
+	case !sidePots:
+		return "pot"
+	case potIndex == 0:
+		return "main pot"
+	}
+	return fmt.Sprintf("side pot-%d", potIndex)
+}
+
+// historyGameName retorna el nombre del juego en el encabezado de la mano
+func historyGameName(variant string) string {
+	switch variant {
+	case VariantOmaha:
+		return "Omaha"
+	case VariantShortDeck:
+		return "6+ Hold'em"
+	case VariantStud:
+		return "7 Card Stud"
+	case VariantTripleDraw:
+		return "Triple Draw 2-7 Lowball"
+	}
+	return "Hold'em"
+}
+
+// historyLimitName retorna la estructura de apuestas en el encabezado de la mano
+func historyLimitName(structure string) string {
+	switch structure {
+	case BettingPotLimit:
+		return "Pot Limit"
+	case BettingFixedLimit:
+		return "Limit"
+	}
+	return "No Limit"
+}
+
+// formatCards escribe cartas en notación corta: [Ah Td 2c]
+func formatCards(cards []Card) string {
+	short := make([]string, len(cards))
+	for i, card := range cards {
+		short[i] = formatCard(card)
+	}
+	return "[" + strings.Join(short, " ") + "]"
+}
+
+// formatCard escribe una carta en notación corta (Ah, Td, 2c)
+func formatCard(card Card) string {
+	rank := card.Rank
+	if rank == "10" {
+		rank = "T"
+	}
+	suit := ""
+	if card.Suit != "" {
+		suit = card.Suit[:1]
+	}
+	return rank + suit
+}
@@ -0,0 +1,200 @@
+package poker
+
+import (
+	"strings"
+	"testing"
+)
+
+// assertContains verifica que el historial contenga cada una de las líneas esperadas
+func assertContains(t *testing.T, text string, expected ...string) {
+	t.Helper()
+	for _, line := range expected {
+		if !strings.Contains(text, line) {
+			t.Errorf("Expected hand history to contain %q, got:\n%s", line, text)
+		}
+	}
+}
+
+// TestHandHistoryRecordsActions verifica el historial de una mano jugada hasta el river
+func TestHandHistoryRecordsActions(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "history_actions", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+	table.AutoRestart = false
+
+	// Heads-up: seat1 es el button (small blind) y actúa primero preflop
+	engine.startHand(table)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "check", 0)
+	mustAct(t, engine, table, "raise", 40) // seat0 apuesta el flop
+	mustAct(t, engine, table, "raise", 60) // seat1 sube a 100
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "check", 0)
+	mustAct(t, engine, table, "check", 0)
+	mustAct(t, engine, table, "raise", 50) // seat0 apuesta el river
+	mustAct(t, engine, table, "fold", 0)
+
+	if len(table.HandHistories) != 1 || table.History != nil {
+		t.Fatalf("Expected one finished hand history, got %d", len(table.HandHistories))
+	}
+	history := table.HandHistories[0]
+	if history.HandNumber != 1 || history.HandID == 0 || history.EndTime.Before(history.StartTime) {
+		t.Errorf("Unexpected hand header: %+v", history)
+	}
+
+	text := history.Format("")
+	assertContains(t, text,
+		"PokerStars Hand #",
+		"Hold'em No Limit (10/20)",
+		"Table 'history_actions' 6-max Seat #2 is the button",
+		"Seat 1: seat0 (1000 in chips)",
+		"Seat 2: seat1 (1000 in chips)",
+		"seat1: posts small blind 10",
+		"seat0: posts big blind 20",
+		"*** HOLE CARDS ***",
+		"Dealt to seat0 [",
+		"seat1: calls 10",
+		"seat0: checks",
+		"*** FLOP *** [",
+		"seat0: bets 40",
+		"seat1: raises 60 to 100",
+		"seat0: calls 60",
+		"*** TURN *** [",
+		"*** RIVER *** [",
+		"seat0: bets 50",
+		"seat1: folds",
+		"Uncalled bet (50) returned to seat0",
+		"seat0 collected 240 from pot",
+		"*** SUMMARY ***",
+		"Total pot 240 | Rake 0",
+		"Seat 1: seat0 (big blind) collected (240)",
+		"Seat 2: seat1 (button) (small blind) folded on the River",
+	)
+	if strings.Contains(text, "*** SHOW DOWN ***") {
+		t.Error("Uncontested hand should not have a showdown")
+	}
+
+	// El pot se cobra una sola vez aunque la mano termine al cerrar la calle
+	if totalChips(table) != 2000 {
+		t.Errorf("Chips not conserved: %d", totalChips(table))
+	}
+}
+
+// TestHandHistoryHeroView verifica que el historial de un jugador solo muestre sus cartas
+func TestHandHistoryHeroView(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "history_hero", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	engine.startHand(table)
+	mustAct(t, engine, table, "fold", 0)
+	mustAct(t, engine, table, "fold", 0)
+
+	history := table.HandHistories[0]
+	if !history.HasPlayer("seat0") || history.HasPlayer("nobody") {
+		t.Error("HasPlayer should match the players dealt in")
+	}
+
+	text := history.Format("seat2")
+	assertContains(t, text, "Dealt to seat2 [", "folded before Flop",
+		"Uncalled bet (10) returned to seat0", "seat0 collected 20 from pot")
+	if strings.Contains(text, "Dealt to seat0") || strings.Contains(text, "Dealt to seat1") {
+		t.Errorf("Hero view should hide other players' cards:\n%s", text)
+	}
+
+	all := history.Format("")
+	assertContains(t, all, "Dealt to seat0 [", "Dealt to seat1 [", "Dealt to seat2 [")
+}
+
+// TestHandHistoryShowdown verifica el showdown, las manos tiradas y el reparto en el historial
+func TestHandHistoryShowdown(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "history_showdown", seatConfig(3), "alice", "bob", "carol")
+	dealShowdown(table, 0, 1)
+	table.SmallBlindSeat, table.BigBlindSeat = 1, 2
+	engine.beginHandHistory(table)
+	engine.recordHoleCards(table)
+
+	engine.completeHand(table)
+
+	text := table.HandHistories[0].Format("bob")
+	assertContains(t, text,
+		"*** FLOP *** [2c 5d 9h]",
+		"*** TURN *** [2c 5d 9h] [Js]",
+		"*** RIVER *** [2c 5d 9h Js] [4s]",
+		"*** SHOW DOWN ***",
+		"bob: shows [7h 2d] (One Pair)\ncarol: shows [Ah Ac] (One Pair)\nalice: mucks hand\n",
+		"carol collected 300 from pot",
+		"Board [2c 5d 9h Js 4s]",
+		"Seat 1: alice (button) mucked",
+		"Seat 2: bob (small blind) showed [7h 2d] and lost with One Pair",
+		"Seat 3: carol (big blind) showed [Ah Ac] and won (300) with One Pair",
+	)
+	if strings.Contains(text, "Kh") {
+		t.Error("Mucked cards should stay hidden from other players")
+	}
+
+	// Si alice muestra después, el historial lo refleja
+	if _, err := engine.ShowCards(table.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error showing cards: %v", err)
+	}
+	assertContains(t, table.HandHistories[0].Format("bob"), "Seat 1: alice (button) showed [Kh Kc] and lost with One Pair")
+}
+
+// TestHandHistorySidePots verifica los nombres de los pots y el total en el resumen
+func TestHandHistorySidePots(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "history_side_pots", seatConfig(3), "alice", "bob", "carol")
+	dealShowdown(table, 0, -1)
+	table.Players[2].TotalBet = 50
+	table.Players[2].IsAllIn = true
+	engine.beginHandHistory(table)
+
+	engine.completeHand(table)
+
+	assertContains(t, table.HandHistories[0].Format(""),
+		"carol collected 150 from main pot",
+		"alice collected 100 from side pot-1",
+		"Total pot 250 Main pot 150. Side pot-1 100. | Rake 0",
+	)
+}
+
+// TestHandHistoryLimit verifica que la mesa solo guarde las últimas manos
+func TestHandHistoryLimit(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "history_limit", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+	table.AutoRestart = false
+
+	for hand := 0; hand < maxHandHistories+5; hand++ {
+		engine.startHand(table)
+		mustAct(t, engine, table, "fold", 0)
+	}
+
+	histories, err := engine.GetHandHistories(table.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(histories) != maxHandHistories {
+		t.Fatalf("Expected %d hand histories, got %d", maxHandHistories, len(histories))
+	}
+	if histories[0].HandNumber != 6 || histories[len(histories)-1].HandNumber != maxHandHistories+5 {
+		t.Errorf("Expected hands 6 to %d, got %d to %d", maxHandHistories+5,
+			histories[0].HandNumber, histories[len(histories)-1].HandNumber)
+	}
+	for i := 1; i < len(histories); i++ {
+		if histories[i].HandID <= histories[i-1].HandID {
+			t.Fatal("Hand IDs should be unique and increasing")
+		}
+	}
+
+	text := FormatHandHistories(histories[:2], "seat0")
+	if strings.Count(text, "PokerStars Hand #") != 2 {
+		t.Errorf("Expected two hands in the export, got:\n%s", text)
+	}
+
+	if _, err := engine.GetHandHistories("missing"); err == nil {
+		t.Error("Unknown table should be rejected")
+	}
+}
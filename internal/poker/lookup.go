@@ -0,0 +1,218 @@
+package poker
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ====== EVALUADOR CON TABLAS PRECALCULADAS ======
+//
+// LookupEvaluate da el mismo Value que EvaluateHand para 5, 6 o 7 cartas sin
+// generar combinaciones: el valor sale de una tabla indexada por cuántas
+// cartas hay de cada rank o, si hay color, de una tabla indexada por los
+// ranks del palo del color.
+//
+// Sin color el valor solo depende de los ranks, así que hay una tabla por
+// cantidad de cartas con una entrada por cada multiconjunto de ranks (6175
+// de 5 cartas, 18395 de 6 y 49205 de 7). El índice de un multiconjunto es
+// su posición en orden lexicográfico y se calcula sumando un offset por rank.
+// Con 5 o más cartas de un palo no puede haber póker ni full, así que el
+// color (o la escalera de color) es la mejor mano y sale de una tabla de 8192
+// entradas indexada por la máscara de bits de los ranks del palo.
+//
+// Las tablas se arman la primera vez que se usan, con evaluateFiveCards
+// para las manos de 5 cartas y tomando el máximo al sacar una carta para
+// las de 6 y 7.
+
+const (
+	lookupRanks    = 13 // Ranks del 2 al As
+	lookupMaxCount = 4  // Cartas de un mismo rank
+	lookupMaxCards = 7
+)
+
+var (
+	lookupOnce sync.Once
+
+	// lookupWays[i][n]: formas de repartir n cartas entre los ranks i..12
+	lookupWays [lookupRanks + 1][lookupMaxCards + 1]int
+	// lookupOffsets[i][n][c]: posiciones que se saltean con c cartas del rank i
+	// cuando quedan n cartas para los ranks i..12
+	lookupOffsets [lookupRanks][lookupMaxCards + 1][lookupMaxCount + 1]int
+
+	lookupTables [lookupMaxCards + 1][]int32 // Valor sin color por cantidad de cartas (5, 6 y 7)
+	flushTable   [1 << lookupRanks]int32     // Valor del color por máscara de ranks (0 si hay menos de 5)
+)
+
+// LookupEvaluate evalúa 5, 6 o 7 cartas con las tablas precalculadas. Retorna
+// el mismo Rank, Value y RankName que EvaluateHand, sin las cartas de la mano.
+func LookupEvaluate(cards []Card) (HandEvaluation, error) {
+	value, err := lookupValue(cards)
+	if err != nil {
+		return HandEvaluation{}, err
+	}
+	rank := lookupHandRank(value)
+	return HandEvaluation{Rank: rank, Value: value, RankName: handRankNames[rank]}, nil
+}
+
+// lookupHandRank deduce la categoría del valor (cada categoría ocupa su centena de mil)
+func lookupHandRank(value int) HandRank {
+	return HandRank(value/100000 - 1)
+}
+
+// lookupValue calcula el Value de 5, 6 o 7 cartas
+func lookupValue(cards []Card) (int, error) {
+	if len(cards) < 5 || len(cards) > lookupMaxCards {
+		return 0, fmt.Errorf("se evalúan manos de 5 a 7 cartas: %d", len(cards))
+	}
+	lookupOnce.Do(buildLookupTables)
+
+	var counts [lookupRanks]int
+	var suitCounts [4]int
+	var suitMasks [4]int
+	for _, card := range cards {
+		rank := CardValue(card.Rank) - 2
+		suit := suitIndex(card.Suit)
+		if rank < 0 || suit < 0 {
+			return 0, fmt.Errorf("carta inválida: %s de %s", card.Rank, card.Suit)
+		}
+		if suitMasks[suit]&(1<<rank) != 0 {
+			return 0, fmt.Errorf("carta repetida: %s", formatCard(card))
+		}
+		counts[rank]++
+		suitCounts[suit]++
+		suitMasks[suit] |= 1 << rank
+	}
+
+	for suit, count := range suitCounts {
+		if count >= 5 {
+			return int(flushTable[suitMasks[suit]]), nil
+		}
+	}
+	return int(lookupTables[len(cards)][lookupIndex(&counts, len(cards))]), nil
+}
+
+// suitIndex retorna la posición del palo (-1 si no existe)
+func suitIndex(suit string) int {
+	switch suit {
+	case "hearts":
+		return 0
+	case "diamonds":
+		return 1
+	case "clubs":
+		return 2
+	case "spades":
+		return 3
+	default:
+		return -1
+	}
+}
+
+// lookupIndex retorna la posición del multiconjunto de ranks entre los de n cartas
+func lookupIndex(counts *[lookupRanks]int, n int) int {
+	index := 0
+	for rank, count := range counts {
+		index += lookupOffsets[rank][n][count]
+		n -= count
+	}
+	return index
+}
+
+// buildLookupTables arma los offsets del índice y las tablas de valores
+func buildLookupTables() {
+	lookupWays[lookupRanks][0] = 1
+	for rank := lookupRanks - 1; rank >= 0; rank-- {
+		for n := 0; n <= lookupMaxCards; n++ {
+			for count := 0; count <= lookupMaxCount && count <= n; count++ {
+				lookupOffsets[rank][n][count] = lookupWays[rank][n]
+				lookupWays[rank][n] += lookupWays[rank+1][n-count]
+			}
+		}
+	}
+
+	for n := 5; n <= lookupMaxCards; n++ {
+		lookupTables[n] = make([]int32, lookupWays[0][n])
+		var counts [lookupRanks]int
+		fillLookupTable(&counts, 0, n, n)
+	}
+
+	for mask := 0; mask < len(flushTable); mask++ {
+		flushTable[mask] = flushValue(mask)
+	}
+}
+
+// fillLookupTable recorre los multiconjuntos de n cartas asignando el rank
+// rank y los siguientes con las remaining cartas que faltan
+func fillLookupTable(counts *[lookupRanks]int, rank, remaining, n int) {
+	if rank == lookupRanks {
+		if remaining == 0 {
+			lookupTables[n][lookupIndex(counts, n)] = rankMultisetValue(counts, n)
+		}
+		return
+	}
+	for count := 0; count <= lookupMaxCount && count <= remaining; count++ {
+		counts[rank] = count
+		fillLookupTable(counts, rank+1, remaining-count, n)
+	}
+	counts[rank] = 0
+}
+
+// rankMultisetValue calcula el valor de un multiconjunto de ranks sin color:
+// con 5 cartas lo evalúa, con más es el mejor valor al sacar una carta
+func rankMultisetValue(counts *[lookupRanks]int, n int) int32 {
+	if n == 5 {
+		suits := []string{"hearts", "diamonds", "clubs", "spades"}
+		cards := make([]Card, 0, 5)
+		for rank, count := range counts {
+			for i := 0; i < count; i++ {
+				// Palos alternados: 5 cartas nunca quedan del mismo palo
+				cards = append(cards, Card{Suit: suits[len(cards)%len(suits)], Rank: lookupRankNames[rank]})
+			}
+		}
+		return int32(evaluateFiveCards(cards).Value)
+	}
+
+	var best int32
+	for rank := range counts {
+		if counts[rank] == 0 {
+			continue
+		}
+		counts[rank]--
+		if value := lookupTables[n-1][lookupIndex(counts, n-1)]; value > best {
+			best = value
+		}
+		counts[rank]++
+	}
+	return best
+}
+
+// flushValue calcula el mejor color con los ranks de la máscara
+func flushValue(mask int) int32 {
+	bits := 0
+	for m := mask; m != 0; m &= m - 1 {
+		bits++
+	}
+	switch {
+	case bits < 5:
+		return 0
+	case bits == 5:
+		cards := make([]Card, 0, 5)
+		for rank := 0; rank < lookupRanks; rank++ {
+			if mask&(1<<rank) != 0 {
+				cards = append(cards, Card{Suit: "hearts", Rank: lookupRankNames[rank]})
+			}
+		}
+		return int32(evaluateFiveCards(cards).Value)
+	}
+
+	// Las máscaras menores ya están calculadas: el mejor color sin cada carta
+	var best int32
+	for m := mask; m != 0; m &= m - 1 {
+		if value := flushTable[mask&^(m&-m)]; value > best {
+			best = value
+		}
+	}
+	return best
+}
+
+// lookupRankNames es el rank de cada posición de las tablas
+var lookupRankNames = [lookupRanks]string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
@@ -0,0 +1,106 @@
+package poker
+
+import (
+	mathrand "math/rand"
+	"testing"
+)
+
+// randomCards saca n cartas distintas de un deck barajado con el PRNG
+func randomCards(rng *mathrand.Rand, n int) []Card {
+	deck := newDeck(VariantHoldem)
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	return deck[:n]
+}
+
+// assertLookupMatches verifica que el evaluador con tablas dé lo mismo que EvaluateHand
+func assertLookupMatches(t *testing.T, cards []Card) {
+	t.Helper()
+	expected := EvaluateHand(cards[:2], cards[2:])
+	got, err := LookupEvaluate(cards)
+	if err != nil {
+		t.Fatalf("Unexpected error evaluating %s: %v", formatCards(cards), err)
+	}
+	if got.Value != expected.Value || got.Rank != expected.Rank || got.RankName != expected.RankName {
+		t.Fatalf("%s: expected %s (%d), got %s (%d)", formatCards(cards),
+			expected.RankName, expected.Value, got.RankName, got.Value)
+	}
+}
+
+// TestLookupEvaluateMatchesEvaluateHand compara los dos evaluadores en manos al azar de 5, 6 y 7 cartas
+func TestLookupEvaluateMatchesEvaluateHand(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(1))
+	for n := 5; n <= 7; n++ {
+		for i := 0; i < 3000; i++ {
+			assertLookupMatches(t, randomCards(rng, n))
+		}
+	}
+}
+
+// TestLookupEvaluateKnownHands verifica manos con casos borde de escaleras y colores
+func TestLookupEvaluateKnownHands(t *testing.T) {
+	hands := map[string][]Card{
+		"royal flush": lowballHand("A", "spades", "K", "spades", "Q", "spades", "J", "spades", "10", "spades", "2", "hearts", "2", "clubs"),
+		"steel wheel": lowballHand("A", "hearts", "2", "hearts", "3", "hearts", "4", "hearts", "5", "hearts", "K", "hearts"),
+		"wheel":       lowballHand("A", "hearts", "2", "clubs", "3", "hearts", "4", "spades", "5", "diamonds"),
+		"six to ten":  lowballHand("6", "hearts", "7", "clubs", "8", "hearts", "9", "spades", "10", "diamonds", "A", "clubs", "2", "clubs"),
+		"quads":       lowballHand("9", "hearts", "9", "clubs", "9", "diamonds", "9", "spades", "A", "hearts", "A", "clubs", "A", "diamonds"),
+		"two trips":   lowballHand("9", "hearts", "9", "clubs", "9", "diamonds", "K", "spades", "K", "hearts", "K", "clubs", "2", "diamonds"),
+		"six flush":   lowballHand("2", "clubs", "4", "clubs", "6", "clubs", "8", "clubs", "10", "clubs", "Q", "clubs", "Q", "hearts"),
+		"three pairs": lowballHand("3", "clubs", "3", "hearts", "7", "clubs", "7", "hearts", "J", "clubs", "J", "hearts", "A", "spades"),
+	}
+	for name, cards := range hands {
+		t.Run(name, func(t *testing.T) {
+			assertLookupMatches(t, cards)
+		})
+	}
+}
+
+// TestLookupEvaluateRejectsInvalidHands verifica la validación de las cartas
+func TestLookupEvaluateRejectsInvalidHands(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(2))
+	invalid := map[string][]Card{
+		"cuatro cartas":  randomCards(rng, 4),
+		"ocho cartas":    randomCards(rng, 8),
+		"carta repetida": append(randomCards(rng, 4), Card{Suit: "hearts", Rank: "A"}, Card{Suit: "hearts", Rank: "A"}),
+		"carta inválida": append(randomCards(rng, 4), Card{Suit: "hearts", Rank: "1"}),
+	}
+	for name, cards := range invalid {
+		if _, err := LookupEvaluate(cards); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestShowdownValueMatchesVariantEvaluator verifica que el showdown rankee con las tablas
+// igual que el evaluador de cada variante, también en stud y con menos de 5 cartas
+func TestShowdownValueMatchesVariantEvaluator(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(3))
+	for _, variant := range []string{VariantHoldem, VariantStud, VariantOmaha} {
+		table := &PokerTable{Variant: variant}
+		for i := 0; i < 500; i++ {
+			cards := randomCards(rng, 9)
+			player := PokerPlayer{Cards: cards[:2], UpCards: cards[2:4]}
+			board := cards[4:9]
+			switch variant {
+			case VariantHoldem:
+				player.UpCards = nil
+			case VariantStud:
+				board = board[:3]
+			case VariantOmaha:
+				player.Cards, player.UpCards = cards[:4], nil
+			}
+
+			expected := evaluatePlayerHandOnBoard(table, player, board).Value
+			if got := showdownValue(table, player, board); got != expected {
+				t.Fatalf("%s %s / %s: expected %d, got %d", variant,
+					formatCards(player.Cards), formatCards(board), expected, got)
+			}
+		}
+	}
+
+	short := &PokerTable{Variant: VariantHoldem}
+	player := PokerPlayer{Cards: []Card{{"hearts", "A"}, {"clubs", "A"}}}
+	if got := showdownValue(short, player, nil); got != EvaluateHand(player.Cards, nil).Value {
+		t.Errorf("Hands under 5 cards should use EvaluateHand, got %d", got)
+	}
+}
@@ -0,0 +1,143 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ====== EVALUADOR DEUCE-TO-SEVEN LOWBALL ======
+//
+// En 2-7 gana la peor mano de poker: el As siempre es alto, A-2-3-4-5 no es
+// escalera, y las escaleras y los colores cuentan en contra. La mejor mano
+// posible es 7-5-4-3-2 de palos distintos.
+
+// lowballBase es la base usada para codificar una mano en un único entero
+const lowballBase = 15
+
+// lowballMaxScore es mayor que cualquier puntaje de mano posible
+var lowballMaxScore = 9 * pow(lowballBase, 5)
+
+// EvaluateDeuceToSevenLow evalúa una mano de 5 cartas con las reglas de 2-7 lowball.
+// Value es mayor cuanto mejor (más baja) es la mano, igual que en EvaluateHand.
+func EvaluateDeuceToSevenLow(cards []Card) HandEvaluation {
+	if len(cards) == 0 {
+		return HandEvaluation{Rank: HighCard, Value: 0, RankName: "High Card"}
+	}
+
+	sortedCards := make([]Card, len(cards))
+	copy(sortedCards, cards)
+	for i := 0; i < len(sortedCards)-1; i++ {
+		for j := i + 1; j < len(sortedCards); j++ {
+			if CardValue(sortedCards[i].Rank) < CardValue(sortedCards[j].Rank) {
+				sortedCards[i], sortedCards[j] = sortedCards[j], sortedCards[i]
+			}
+		}
+	}
+
+	rank, tiebreak := classifyLowballHand(sortedCards)
+
+	// Puntaje "alto es peor": categoría primero y luego cada valor de desempate
+	score := int(rank)
+	for i := 0; i < 5; i++ {
+		score *= lowballBase
+		if i < len(tiebreak) {
+			score += tiebreak[i]
+		}
+	}
+
+	return HandEvaluation{
+		Rank:     rank,
+		Value:    lowballMaxScore - score,
+		Cards:    sortedCards,
+		RankName: lowballRankName(rank, sortedCards),
+	}
+}
+
+// classifyLowballHand retorna la categoría de la mano (sin escalera baja con As)
+// y los valores de desempate en orden de importancia
+func classifyLowballHand(sortedCards []Card) (HandRank, []int) {
+	counts := make(map[int]int)
+	suits := make(map[string]bool)
+	for _, card := range sortedCards {
+		counts[CardValue(card.Rank)]++
+		suits[card.Suit] = true
+	}
+
+	// Grupos ordenados por cantidad y luego por valor (ej: full house = trío, par)
+	groups := make([]int, 0, len(counts))
+	for value := range counts {
+		groups = append(groups, value)
+	}
+	for i := 0; i < len(groups)-1; i++ {
+		for j := i + 1; j < len(groups); j++ {
+			ci, cj := counts[groups[i]], counts[groups[j]]
+			if cj > ci || (cj == ci && groups[j] > groups[i]) {
+				groups[i], groups[j] = groups[j], groups[i]
+			}
+		}
+	}
+
+	isFlush := len(sortedCards) == 5 && len(suits) == 1
+	isStraight := len(sortedCards) == 5 && len(groups) == 5 &&
+		CardValue(sortedCards[0].Rank)-CardValue(sortedCards[4].Rank) == 4
+
+	var rank HandRank
+	switch {
+	case isStraight && isFlush:
+		rank = StraightFlush
+	case counts[groups[0]] == 4:
+		rank = FourOfAKind
+	case counts[groups[0]] == 3 && len(groups) > 1 && counts[groups[1]] == 2:
+		rank = FullHouse
+	case isFlush:
+		rank = Flush
+	case isStraight:
+		rank = Straight
+	case counts[groups[0]] == 3:
+		rank = ThreeOfAKind
+	case counts[groups[0]] == 2 && len(groups) > 1 && counts[groups[1]] == 2:
+		rank = TwoPair
+	case counts[groups[0]] == 2:
+		rank = OnePair
+	default:
+		rank = HighCard
+	}
+
+	return rank, groups
+}
+
+// lowballRankName describe la mano: "7-5-4-3-2" para manos sin par, o la categoría
+func lowballRankName(rank HandRank, sortedCards []Card) string {
+	if rank != HighCard {
+		return handRankNames[rank]
+	}
+
+	ranks := make([]string, len(sortedCards))
+	for i, card := range sortedCards {
+		ranks[i] = card.Rank
+	}
+	return fmt.Sprintf("%s Low", strings.Join(ranks, "-"))
+}
+
+// handRankNames nombres de cada categoría de mano
+var handRankNames = map[HandRank]string{
+	HighCard:      "High Card",
+	OnePair:       "One Pair",
+	TwoPair:       "Two Pair",
+	ThreeOfAKind:  "Three of a Kind",
+	Straight:      "Straight",
+	Flush:         "Flush",
+	FullHouse:     "Full House",
+	FourOfAKind:   "Four of a Kind",
+	StraightFlush: "Straight Flush",
+	RoyalFlush:    "Royal Flush",
+}
+
+// pow calcula base^exp para enteros pequeños
+func pow(base, exp int) int {
+	result := 1
+	for i := 0; i < exp; i++ {
+		result *= base
+	}
+	return result
+}
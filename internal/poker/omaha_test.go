@@ -0,0 +1,195 @@
+package poker
+
+import (
+	"testing"
+	"time"
+)
+
+// omahaConfig es la configuración de las mesas de Omaha de los tests
+var omahaConfig = TableConfig{
+	Variant:      VariantOmaha,
+	SmallBlind:   10,
+	BigBlind:     20,
+	BuyInAmount:  1000,
+	MinBuyIn:     500,
+	MaxBuyIn:     2000,
+	IsCashGame:   true,
+	AutoRestart:  false,
+	RestartDelay: time.Second,
+}
+
+// TestOmahaDealsFourCards verifica que cada jugador reciba 4 cartas privadas
+func TestOmahaDealsFourCards(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "omaha_deal", omahaConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+
+	if table.Variant != VariantOmaha {
+		t.Fatalf("Expected variant %s, got %s", VariantOmaha, table.Variant)
+	}
+
+	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if len(player.Cards) != 4 {
+			t.Errorf("Player %s has %d cards, expected 4", player.Name, len(player.Cards))
+		}
+	}
+
+	// 52 - 8 cartas repartidas
+	if len(table.Deck) != 44 {
+		t.Errorf("Expected 44 cards left in deck, got %d", len(table.Deck))
+	}
+}
+
+// TestOmahaHiddenCards verifica que las 4 cartas de los oponentes estén ocultas
+func TestOmahaHiddenCards(t *testing.T) {
+	engine := NewPokerEngine()
+	startTestGame(t, engine, newTestTable(t, engine, "omaha_hidden", omahaConfig, "alice", "bob"))
+
+	filtered, err := engine.GetTableForPlayer("omaha_hidden", "alice")
+	if err != nil {
+		t.Fatalf("Error getting filtered table: %v", err)
+	}
+
+	for _, player := range filtered.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if len(player.Cards) != 4 {
+			t.Fatalf("Player %s has %d cards in filtered view, expected 4", player.Name, len(player.Cards))
+		}
+		for _, card := range player.Cards {
+			hidden := card.Suit == "hidden" && card.Rank == "?"
+			if player.ID == "alice" && hidden {
+				t.Errorf("Alice should see her own cards")
+			}
+			if player.ID != "alice" && !hidden {
+				t.Errorf("Opponent card should be hidden, got %+v", card)
+			}
+		}
+	}
+}
+
+// TestOmahaExactlyTwoFromHand verifica la regla "2 de la mano + 3 del board"
+func TestOmahaExactlyTwoFromHand(t *testing.T) {
+	tests := []struct {
+		name           string
+		playerCards    []Card
+		communityCards []Card
+		expectedRank   HandRank
+	}{
+		{
+			name: "Un solo corazón en mano no hace flush",
+			playerCards: []Card{
+				{Suit: "hearts", Rank: "A"}, {Suit: "clubs", Rank: "K"},
+				{Suit: "diamonds", Rank: "8"}, {Suit: "spades", Rank: "3"},
+			},
+			communityCards: []Card{
+				{Suit: "hearts", Rank: "2"}, {Suit: "hearts", Rank: "7"}, {Suit: "hearts", Rank: "9"},
+				{Suit: "hearts", Rank: "J"}, {Suit: "clubs", Rank: "4"},
+			},
+			expectedRank: HighCard,
+		},
+		{
+			name: "Dos corazones en mano hacen flush",
+			playerCards: []Card{
+				{Suit: "hearts", Rank: "A"}, {Suit: "hearts", Rank: "K"},
+				{Suit: "diamonds", Rank: "8"}, {Suit: "spades", Rank: "3"},
+			},
+			communityCards: []Card{
+				{Suit: "hearts", Rank: "2"}, {Suit: "hearts", Rank: "7"}, {Suit: "hearts", Rank: "9"},
+				{Suit: "clubs", Rank: "J"}, {Suit: "clubs", Rank: "4"},
+			},
+			expectedRank: Flush,
+		},
+		{
+			name: "Cuatro ases en mano solo cuentan como par",
+			playerCards: []Card{
+				{Suit: "hearts", Rank: "A"}, {Suit: "clubs", Rank: "A"},
+				{Suit: "diamonds", Rank: "A"}, {Suit: "spades", Rank: "A"},
+			},
+			communityCards: []Card{
+				{Suit: "hearts", Rank: "2"}, {Suit: "clubs", Rank: "7"}, {Suit: "diamonds", Rank: "9"},
+				{Suit: "spades", Rank: "J"}, {Suit: "clubs", Rank: "4"},
+			},
+			expectedRank: OnePair,
+		},
+		{
+			name: "Trío en el board más par en mano es full house",
+			playerCards: []Card{
+				{Suit: "hearts", Rank: "Q"}, {Suit: "clubs", Rank: "Q"},
+				{Suit: "diamonds", Rank: "3"}, {Suit: "spades", Rank: "4"},
+			},
+			communityCards: []Card{
+				{Suit: "hearts", Rank: "8"}, {Suit: "clubs", Rank: "8"}, {Suit: "diamonds", Rank: "8"},
+				{Suit: "spades", Rank: "K"}, {Suit: "clubs", Rank: "2"},
+			},
+			expectedRank: FullHouse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EvaluateOmahaHand(tt.playerCards, tt.communityCards)
+			if result.Rank != tt.expectedRank {
+				t.Errorf("Expected %v, got %v (%s)", tt.expectedRank, result.Rank, result.RankName)
+			}
+			if len(result.Cards) != 5 {
+				t.Errorf("Expected 5 best cards, got %d", len(result.Cards))
+			}
+		})
+	}
+}
+
+// TestOmahaPotLimitRaise verifica que el raise no supere el tamaño del pot
+func TestOmahaPotLimitRaise(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "omaha_pot_limit", omahaConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+
+	current := table.Players[table.CurrentPlayer]
+	callAmount := table.CurrentBet - current.CurrentBet
+	maxRaise := table.Pot + callAmount
+
+	if _, err := engine.PlayerAction(table.ID, current.ID, "raise", maxRaise+1); err == nil {
+		t.Errorf("Expected error for raise above pot limit (%d)", maxRaise)
+	}
+
+	if _, err := engine.PlayerAction(table.ID, current.ID, "all_in", 0); err == nil {
+		t.Errorf("Expected error for all-in above pot limit")
+	}
+
+	if _, err := engine.PlayerAction(table.ID, current.ID, "raise", maxRaise); err != nil {
+		t.Errorf("Unexpected error for pot-sized raise: %v", err)
+	}
+}
+
+// TestOmahaShowdownUsesOmahaRules verifica que el showdown aplique la regla 2+3
+func TestOmahaShowdownUsesOmahaRules(t *testing.T) {
+	engine := NewPokerEngine()
+	table := mustCreateTable(t, engine, "omaha_showdown", TableConfig{
+		Variant: VariantOmaha, SmallBlind: 10, BigBlind: 20,
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+	})
+
+	// Alice tiene un solo corazón (sin flush en Omaha), Bob tiene un par de reyes
+	table.Players = []PokerPlayer{
+		{ID: "alice", Name: "Alice", Stack: 0, IsActive: true, CurrentBet: 100,
+			Cards: []Card{{Suit: "hearts", Rank: "A"}, {Suit: "clubs", Rank: "3"}, {Suit: "diamonds", Rank: "4"}, {Suit: "spades", Rank: "5"}}},
+		{ID: "bob", Name: "Bob", Stack: 0, IsActive: true, CurrentBet: 100,
+			Cards: []Card{{Suit: "spades", Rank: "K"}, {Suit: "clubs", Rank: "K"}, {Suit: "diamonds", Rank: "10"}, {Suit: "spades", Rank: "6"}}},
+	}
+	table.CommunityCards = []Card{
+		{Suit: "hearts", Rank: "2"}, {Suit: "hearts", Rank: "7"}, {Suit: "hearts", Rank: "9"},
+		{Suit: "hearts", Rank: "J"}, {Suit: "clubs", Rank: "Q"},
+	}
+
+	engine.distributeSidePots(table)
+
+	if table.Players[1].Stack != 200 {
+		t.Errorf("Expected Bob to win 200 with pair of kings, got stacks Alice=%d Bob=%d",
+			table.Players[0].Stack, table.Players[1].Stack)
+	}
+}
@@ -0,0 +1,139 @@
+package poker
+
+import (
+	"fmt"
+	"math"
+)
+
+// ====== RAKE ======
+//
+// Las mesas de cash pueden cobrar un rake: un porcentaje de cada pot con un
+// tope por mano y, opcionalmente, sin rake si la mano terminó antes del flop
+// (no flop, no drop). Las fichas que nadie igualó vuelven al jugador sin
+// rake. El rake se descuenta en distributeSidePots antes de pagarle a los
+// ganadores, queda registrado en el resultado de la mano y se avisa al
+// handler registrado con SetRakeHandler.
+
+// Rake es lo que la casa cobró en una mano
+type Rake struct {
+	TableID    string `json:"table_id"`
+	HandNumber int    `json:"hand_number"`
+	Amount     int    `json:"amount"`
+}
+
+// SetRakeHandler registra una función que recibe el rake de cada mano. Se llama
+// con la mesa bloqueada, así que no debe volver a llamar al engine.
+func (pe *PokerEngine) SetRakeHandler(handler func(Rake)) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.onRake = handler
+}
+
+// validateRake verifica la configuración de rake de una mesa
+func validateRake(config TableConfig) error {
+	if config.RakePercent < 0 || config.RakePercent > 100 {
+		return fmt.Errorf("el rake debe estar entre 0 y 100%%: %v", config.RakePercent)
+	}
+	if config.RakeCap < 0 {
+		return fmt.Errorf("el tope de rake no puede ser negativo: %d", config.RakeCap)
+	}
+	if config.RakePercent > 0 && !config.IsCashGame {
+		return fmt.Errorf("el rake es solo para cash games")
+	}
+	return nil
+}
+
+// sidePotRakes calcula el rake de la mano y cuánto sale de cada side pot:
+// se cobra primero del pot principal y nunca de las fichas que nadie igualó
+func (pe *PokerEngine) sidePotRakes(table *PokerTable, board []Card) []int {
+	rakes := make([]int, len(table.SidePots))
+	if !table.IsCashGame || table.RakePercent <= 0 || len(table.SidePots) == 0 {
+		return rakes
+	}
+	if table.NoFlopNoDrop && !sawFlop(table, board) {
+		return rakes
+	}
+
+	uncalled := uncalledChips(table)
+	rakeable := pe.getTotalPot(table) - uncalled
+	if rakeable <= 0 {
+		return rakes
+	}
+	rake := rakeable * int(math.Round(table.RakePercent*100)) / 10000
+	if table.RakeCap > 0 && rake > table.RakeCap {
+		rake = table.RakeCap
+	}
+
+	last := len(table.SidePots) - 1
+	for i := 0; i < len(table.SidePots) && rake > 0; i++ {
+		available := table.SidePots[i].Amount
+		if i == last {
+			available -= uncalled
+		}
+		if available <= 0 {
+			continue
+		}
+		if available > rake {
+			available = rake
+		}
+		rakes[i] = available
+		rake -= available
+	}
+	return rakes
+}
+
+// uncalledChips retorna lo que el que más puso apostó sin que nadie lo igualara
+// (0 si lo igualaron o si se retiró)
+func uncalledChips(table *PokerTable) int {
+	top, highest, second := -1, 0, 0
+	for i, player := range table.Players {
+		contribution := playerContribution(player)
+		switch {
+		case contribution > highest:
+			top, highest, second = i, contribution, highest
+		case contribution > second:
+			second = contribution
+		}
+	}
+	if top == -1 || table.Players[top].HasFolded {
+		return 0
+	}
+	return highest - second
+}
+
+// sawFlop indica si la mano pasó de la primera ronda de apuestas: en las
+// variantes con board, si se repartió el flop; en stud, la cuarta carta;
+// en triple draw, el primer descarte
+func sawFlop(table *PokerTable, board []Card) bool {
+	switch table.Variant {
+	case VariantStud:
+		for _, player := range table.Players {
+			if player.IsActive && len(player.Cards)+len(player.UpCards) > 3 {
+				return true
+			}
+		}
+		return false
+	case VariantTripleDraw:
+		if table.History == nil {
+			return false
+		}
+		for _, action := range table.History.Actions {
+			if action.Action == "draw" {
+				return true
+			}
+		}
+		return false
+	}
+	return len(board) >= 3
+}
+
+// collectRake suma el rake de la mano a la mesa y avisa al handler
+func (pe *PokerEngine) collectRake(table *PokerTable) {
+	if table.HandResult == nil || table.HandResult.Rake == 0 {
+		return
+	}
+	table.RakeTotal += table.HandResult.Rake
+	if pe.onRake != nil {
+		pe.onRake(Rake{TableID: table.ID, HandNumber: table.HandNumber, Amount: table.HandResult.Rake})
+	}
+}
@@ -0,0 +1,113 @@
+package poker
+
+import (
+	"testing"
+)
+
+// TestRakeTakenBeforeWinnersPaid verifica que el rake salga del pot antes de pagarle al ganador
+func TestRakeTakenBeforeWinnersPaid(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "rake_taken", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+	table.RakePercent = 5
+
+	var rakes []Rake
+	engine.SetRakeHandler(func(rake Rake) { rakes = append(rakes, rake) })
+
+	// Button 1, small blind 2, big blind 0: todos ven el flop por 20
+	engine.startHand(table)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "check", 0)
+
+	// seat2 apuesta 40 y nadie paga: esas fichas vuelven sin rake
+	mustAct(t, engine, table, "raise", 40)
+	foldToShowdown(t, engine, table)
+
+	if table.HandResult.Rake != 3 || table.RakeTotal != 3 {
+		t.Fatalf("Expected 5%% of the 60 pot as rake, got %d (total %d)", table.HandResult.Rake, table.RakeTotal)
+	}
+	if table.Players[2].Stack != 1037 {
+		t.Errorf("Expected seat2 to collect the pot minus the rake, got stack %d", table.Players[2].Stack)
+	}
+	if totalChips(table)+table.RakeTotal != 3000 {
+		t.Errorf("Chips not conserved: %d at the table, %d raked", totalChips(table), table.RakeTotal)
+	}
+	if len(rakes) != 1 || rakes[0] != (Rake{TableID: table.ID, HandNumber: 1, Amount: 3}) {
+		t.Errorf("Expected the rake in the handler, got %+v", rakes)
+	}
+
+	history := table.HandHistories[0]
+	assertContains(t, history.Format(""), "Uncalled bet (40) returned to seat2", "seat2 collected 57 from pot", "Total pot 60 | Rake 3")
+	if _, err := ReplayHand(history); err != nil {
+		t.Errorf("Raked hand should replay: %v", err)
+	}
+}
+
+// TestRakeCapAndNoFlopNoDrop verifica el tope por mano y la opción de no cobrar sin flop
+func TestRakeCapAndNoFlopNoDrop(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "rake_cap", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+	table.RakePercent = 10
+	table.RakeCap = 4
+
+	// Mano 1: pot de 60 en el flop, el 10% pasa el tope
+	engine.startHand(table)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "call", 0)
+	mustAct(t, engine, table, "check", 0)
+	foldToShowdown(t, engine, table)
+	if table.HandResult.Rake != 4 || table.Players[1].Stack != 1036 {
+		t.Fatalf("Expected the rake capped at 4, got %d (winner stack %d)", table.HandResult.Rake, table.Players[1].Stack)
+	}
+
+	// Mano 2: todos se retiran preflop y no se cobra
+	table.NoFlopNoDrop = true
+	engine.startHand(table)
+	foldToShowdown(t, engine, table)
+	if table.HandResult.Rake != 0 {
+		t.Errorf("Expected no rake without a flop, got %d", table.HandResult.Rake)
+	}
+
+	// Mano 3: sin la opción el pot de los blinds paga rake, menos lo que nadie igualó
+	table.NoFlopNoDrop = false
+	engine.startHand(table)
+	foldToShowdown(t, engine, table)
+	if table.HandResult.Rake != 2 {
+		t.Errorf("Expected 10%% of the 20 called, got %d", table.HandResult.Rake)
+	}
+	if table.RakeTotal != 6 || totalChips(table)+table.RakeTotal != 3000 {
+		t.Errorf("Expected 6 raked over the session, got %d (%d at the table)", table.RakeTotal, totalChips(table))
+	}
+}
+
+// TestUpdateTableConfigValidatesRake verifica la validación de la configuración de rake
+func TestUpdateTableConfigValidatesRake(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "rake_config", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+
+	config := tableConfig(table)
+	config.RakePercent = 5
+	config.RakeCap = 30
+	config.NoFlopNoDrop = true
+	if err := engine.UpdateTableConfig(table.ID, config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if table.RakePercent != 5 || table.RakeCap != 30 || !table.NoFlopNoDrop {
+		t.Errorf("Rake configuration not applied: %+v", tableConfig(table))
+	}
+
+	invalid := []TableConfig{config, config, config}
+	invalid[0].RakePercent = 120
+	invalid[1].RakeCap = -1
+	invalid[2].IsCashGame = false
+	for _, c := range invalid {
+		if err := engine.UpdateTableConfig(table.ID, c); err == nil {
+			t.Errorf("Expected error for rake %v%% cap %d cash %v", c.RakePercent, c.RakeCap, c.IsCashGame)
+		}
+	}
+}
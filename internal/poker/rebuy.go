@@ -0,0 +1,75 @@
+package poker
+
+import (
+	"fmt"
+)
+
+// ====== RECOMPRAS Y TOP-UP ======
+//
+// En cash games un jugador sentado puede comprar más fichas entre manos: una
+// recompra si se quedó sin fichas o un top-up para completar su stack. El
+// stack resultante tiene que respetar MinBuyIn y MaxBuyIn como un buy-in. Si
+// el jugador está jugando una mano, las fichas se acreditan al empezar la
+// próxima; si no, en el momento.
+
+// Rebuy compra amount fichas más para el jugador y las suma a su buy-in
+func (pe *PokerEngine) Rebuy(tableID, playerID string, amount int) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if !table.IsCashGame {
+		return nil, fmt.Errorf("las recompras son solo para cash games")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("el monto de la recompra debe ser positivo")
+	}
+
+	player := &table.Players[seat]
+	if player.Leaving {
+		return nil, fmt.Errorf("el jugador está dejando la mesa")
+	}
+
+	// El stack después de la recompra se valida como un buy-in
+	// (durante una mano cuentan también las fichas que ya puso en el pot)
+	total := player.Stack + player.PendingBuyIn + amount
+	inHand := playingHand(table, seat)
+	if inHand {
+		total += playerInvestment(*player)
+	}
+	if err := checkBuyIn(table, total); err != nil {
+		return nil, fmt.Errorf("la recompra deja el stack en %d: %w", total, err)
+	}
+
+	player.PendingBuyIn += amount
+	if !inHand {
+		applyPendingBuyIn(player)
+	}
+	return table, nil
+}
+
+// applyPendingBuyIns acredita las recompras pendientes al empezar la mano
+func (pe *PokerEngine) applyPendingBuyIns(table *PokerTable) {
+	for i := range table.Players {
+		if table.Players[i].PendingBuyIn > 0 {
+			applyPendingBuyIn(&table.Players[i])
+		}
+	}
+}
+
+// applyPendingBuyIn suma la recompra pendiente al stack y al buy-in del jugador
+func applyPendingBuyIn(player *PokerPlayer) {
+	player.Stack += player.PendingBuyIn
+	player.BuyIn += player.PendingBuyIn
+	player.PendingBuyIn = 0
+	player.Rebuys++
+}
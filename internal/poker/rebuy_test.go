@@ -0,0 +1,84 @@
+package poker
+
+import (
+	"testing"
+)
+
+// TestRebuyBetweenHands verifica los límites de buy-in de las recompras y top-ups
+func TestRebuyBetweenHands(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "rebuy_between_hands", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1)
+
+	// Top-up hasta el máximo de 2000
+	if _, err := engine.Rebuy(table.ID, "seat0", 500); err != nil {
+		t.Fatalf("Unexpected error topping up: %v", err)
+	}
+	player := table.Players[0]
+	if player.Stack != 1500 || player.BuyIn != 1500 || player.Rebuys != 1 || player.PendingBuyIn != 0 {
+		t.Errorf("Expected the top-up credited right away, got %+v", player)
+	}
+	if _, err := engine.Rebuy(table.ID, "seat0", 600); err == nil {
+		t.Error("Top-up above the maximum buy-in should be rejected")
+	}
+	if _, err := engine.Rebuy(table.ID, "seat0", 0); err == nil {
+		t.Error("Empty rebuy should be rejected")
+	}
+
+	// Un jugador sin fichas recompra al menos el mínimo
+	table.Players[1].Stack = 0
+	if _, err := engine.Rebuy(table.ID, "seat1", 400); err == nil {
+		t.Error("Rebuy below the minimum buy-in should be rejected")
+	}
+	if _, err := engine.Rebuy(table.ID, "seat1", 500); err != nil {
+		t.Fatalf("Unexpected error rebuying: %v", err)
+	}
+	if table.Players[1].Stack != 500 || table.Players[1].BuyIn != 1500 {
+		t.Errorf("Expected 500 chips over a 1500 buy-in, got %+v", table.Players[1])
+	}
+
+	if _, err := engine.Rebuy(table.ID, "nobody", 500); err == nil {
+		t.Error("Unknown player should be rejected")
+	}
+	table.IsCashGame = false
+	if _, err := engine.Rebuy(table.ID, "seat1", 500); err == nil {
+		t.Error("Tournaments should not allow rebuys")
+	}
+}
+
+// TestRebuyAppliedAtNextHand verifica que la recompra pedida durante una mano se acredite en la siguiente
+func TestRebuyAppliedAtNextHand(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "rebuy_next_hand", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	// Button 1, small blind 2, big blind 0
+	engine.startHand(table)
+	if _, err := engine.Rebuy(table.ID, "seat0", 1000); err != nil {
+		t.Fatalf("Unexpected error rebuying mid-hand: %v", err)
+	}
+	if _, err := engine.Rebuy(table.ID, "seat0", 10); err == nil {
+		t.Error("Pending chips should count towards the maximum buy-in")
+	}
+	if table.Players[0].Stack != 980 || table.Players[0].PendingBuyIn != 1000 {
+		t.Fatalf("Chips should not reach the stack during the hand, got %+v", table.Players[0])
+	}
+	foldToShowdown(t, engine, table)
+
+	// El big blind ganó los blinds (1010) y suma la recompra; ahora pone el small blind
+	engine.startHand(table)
+	player := table.Players[0]
+	if player.Stack+player.CurrentBet != 2010 || player.BuyIn != 2000 || player.PendingBuyIn != 0 {
+		t.Errorf("Expected the rebuy credited at the next hand, got %+v", player)
+	}
+
+	// Si deja la mesa antes de acreditarla, la recompra se devuelve en el cash out
+	engine.Rebuy(table.ID, "seat1", 500)
+	engine.LeaveTable(table.ID, "seat1")
+	foldToShowdown(t, engine, table)
+	record := table.CashOuts[0]
+	if record.PlayerID != "seat1" || record.Amount != table.HandHistories[1].Seats[1].FinalStack+500 || record.BuyIn != 1500 {
+		t.Errorf("Expected the pending rebuy returned in the cash out, got %+v", record)
+	}
+}
@@ -0,0 +1,179 @@
+package poker
+
+import "fmt"
+
+// ====== REPRODUCCIÓN DE MANOS ======
+//
+// ReplayHand vuelve a jugar una mano con el orden del deck y las acciones
+// grabadas en su historial, guardando el estado de la mesa después de cada
+// paso. Sirve para reproducir exactamente un reporte de bug y para armar un
+// corpus de regresión con manos reales.
+
+// ReplayStep es el estado de la mesa después de un paso de la reproducción
+type ReplayStep struct {
+	Action *HistoryAction `json:"action"` // nil en el estado inicial (cartas repartidas y blinds puestos)
+	Table  *PokerTable    `json:"table"`
+}
+
+// ReplayHand reproduce la mano y verifica que los stacks finales coincidan con los grabados.
+// Si la reproducción se desvía del historial retorna los pasos hasta ese punto y el error.
+func ReplayHand(history *HandHistory) ([]ReplayStep, error) {
+	if history == nil || len(history.Deck) == 0 {
+		return nil, fmt.Errorf("el historial no tiene el deck grabado")
+	}
+
+	engine := NewPokerEngineWithDeckSource(NewScriptedDeckSource(history.Deck))
+
+	config := history.Config
+	config.AutoRestart = false
+	config.ActionTimeout = 0
+	table := engine.createTableWithConfigInternal(history.TableID, config)
+
+	for _, seat := range history.Seats {
+		if !isValidSeat(table, seat.Seat) {
+			return nil, fmt.Errorf("asiento inválido en el historial: %d", seat.Seat)
+		}
+		table.Players[seat.Seat] = PokerPlayer{
+			ID:                seat.PlayerID,
+			Name:              seat.Name,
+			Stack:             seat.Stack,
+			IsActive:          true,
+			IsConnected:       true,
+			StraddleRequested: seat.StraddleRequested,
+			OwedBlinds:        seat.OwedBlinds,
+		}
+	}
+
+	// Posiciones de la mano anterior para que el button se mueva igual
+	table.DealerPosition = history.PrevButton
+	table.SmallBlindSeat = history.PrevSmallBlindSeat
+	table.BigBlindSeat = history.PrevBigBlindSeat
+	table.HandNumber = history.HandNumber - 1
+
+	engine.startHand(table)
+	for _, seat := range history.Seats {
+		if !sameCards(table.Players[seat.Seat].Cards, seat.HoleCards) {
+			return nil, fmt.Errorf("el reparto no coincide con el historial (asiento %d)", seat.Seat)
+		}
+	}
+
+	steps := []ReplayStep{{Table: cloneTable(table)}}
+	for i := range history.Actions {
+		action := &history.Actions[i]
+		// Antes, blinds, straddle y bring-in los vuelve a poner startHand
+		if action.Command == "" {
+			continue
+		}
+		if err := engine.replayAction(table, action); err != nil {
+			return steps, fmt.Errorf("acción %d (%s del asiento %d): %w", i, action.Command, action.Seat, err)
+		}
+		steps = append(steps, ReplayStep{Action: action, Table: cloneTable(table)})
+	}
+
+	if table.Phase != "showdown" {
+		return steps, fmt.Errorf("la mano no terminó al reproducirla (fase %s)", table.Phase)
+	}
+	for _, seat := range history.Seats {
+		if stack := table.Players[seat.Seat].Stack; stack != seat.FinalStack {
+			return steps, fmt.Errorf("el stack final de %s no coincide: grabado %d, reproducido %d",
+				seat.Name, seat.FinalStack, stack)
+		}
+	}
+
+	return steps, nil
+}
+
+// replayAction aplica una acción grabada con la misma API que usan los jugadores
+func (pe *PokerEngine) replayAction(table *PokerTable, action *HistoryAction) error {
+	if !isValidSeat(table, action.Seat) || isEmptySeat(table.Players[action.Seat]) {
+		return fmt.Errorf("asiento vacío")
+	}
+	playerID := table.Players[action.Seat].ID
+
+	var err error
+	switch action.Command {
+	case "raise":
+		_, err = pe.PlayerAction(table.ID, playerID, action.Command, action.Amount)
+	case "draw":
+		var indices []int
+		indices, err = discardIndices(table.Players[action.Seat].Cards, action.Cards)
+		if err == nil {
+			_, err = pe.PlayerDraw(table.ID, playerID, indices)
+		}
+	case "run_it_twice":
+		_, err = pe.VoteRunItTwice(table.ID, playerID, action.Amount)
+	default:
+		_, err = pe.PlayerAction(table.ID, playerID, action.Command, 0)
+	}
+	return err
+}
+
+// discardIndices busca en la mano las cartas descartadas y retorna sus índices
+func discardIndices(hand, discarded []Card) ([]int, error) {
+	indices := make([]int, 0, len(discarded))
+	for _, card := range discarded {
+		found := -1
+		for i, held := range hand {
+			if held == card {
+				found = i
+				break
+			}
+		}
+		if found == -1 {
+			return nil, fmt.Errorf("la carta %s no está en la mano", formatCard(card))
+		}
+		indices = append(indices, found)
+	}
+	return indices, nil
+}
+
+// sameCards indica si dos listas tienen las mismas cartas en el mismo orden
+func sameCards(a, b []Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// cloneTable copia el estado de la mesa sin compartir slices con el original
+func cloneTable(table *PokerTable) *PokerTable {
+	clone := *table
+	clone.History = nil
+	clone.HandHistories = nil
+
+	clone.Players = make([]PokerPlayer, len(table.Players))
+	for i, player := range table.Players {
+		player.Cards = append([]Card(nil), player.Cards...)
+		player.UpCards = append([]Card(nil), player.UpCards...)
+		clone.Players[i] = player
+	}
+
+	clone.CommunityCards = append([]Card(nil), table.CommunityCards...)
+	clone.Deck = append([]Card(nil), table.Deck...)
+	clone.Discards = append([]Card(nil), table.Discards...)
+	clone.Boards = nil
+	for _, board := range table.Boards {
+		clone.Boards = append(clone.Boards, append([]Card(nil), board...))
+	}
+	clone.SidePots = make([]SidePot, len(table.SidePots))
+	for i, sidePot := range table.SidePots {
+		sidePot.EligiblePlayers = append([]int(nil), sidePot.EligiblePlayers...)
+		clone.SidePots[i] = sidePot
+	}
+	clone.PlayersToAct = append([]bool(nil), table.PlayersToAct...)
+	clone.RaiseLocked = append([]bool(nil), table.RaiseLocked...)
+	clone.ShowdownOrder = append([]int(nil), table.ShowdownOrder...)
+	clone.CashOuts = append([]CashOut(nil), table.CashOuts...)
+	if table.Fairness != nil {
+		proof := *table.Fairness
+		proof.ClientSeeds = append([]ClientSeed(nil), table.Fairness.ClientSeeds...)
+		clone.Fairness = &proof
+	}
+
+	return &clone
+}
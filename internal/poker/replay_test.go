@@ -0,0 +1,186 @@
+package poker
+
+import (
+	"strings"
+	"testing"
+)
+
+// playRecordedHand juega una mano de tres jugadores con apuestas en todas las calles
+// y retorna el estado de la mesa después de cada acción
+func playRecordedHand(t *testing.T, engine *PokerEngine, table *PokerTable) []*PokerTable {
+	t.Helper()
+
+	engine.startHand(table)
+	snapshots := []*PokerTable{cloneTable(table)}
+	act := func(action string, amount int) {
+		mustAct(t, engine, table, action, amount)
+		snapshots = append(snapshots, cloneTable(table))
+	}
+
+	act("raise", 40) // UTG sube a 60
+	act("call", 0)
+	act("call", 0)
+	act("check", 0) // flop
+	act("raise", 100)
+	act("fold", 0)
+	act("call", 0)
+	act("raise", 200) // turn
+	act("all_in", 0)
+	act("call", 0)
+
+	// Quien todavía tiene fichas pasa hasta el showdown
+	for table.Phase != "showdown" {
+		act("check", 0)
+	}
+	return snapshots
+}
+
+// TestReplayHandMatchesLiveStates verifica que la reproducción pase por los mismos estados que la mano real
+func TestReplayHandMatchesLiveStates(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "replay_live", seatConfig(6))
+	mustSeat(t, engine, table, 0, 2, 4)
+	table.AutoRestart = false
+
+	// La segunda mano empieza con el button movido y stacks distintos
+	engine.startHand(table)
+	mustAct(t, engine, table, "fold", 0)
+	mustAct(t, engine, table, "fold", 0)
+	live := playRecordedHand(t, engine, table)
+
+	history := table.HandHistories[1]
+	steps, err := ReplayHand(history)
+	if err != nil {
+		t.Fatalf("Unexpected replay error: %v", err)
+	}
+	if len(steps) != len(live) {
+		t.Fatalf("Expected %d replay steps, got %d", len(live), len(steps))
+	}
+
+	for i, step := range steps {
+		expected := live[i]
+		if step.Table.Phase != expected.Phase || step.Table.Pot != expected.Pot ||
+			step.Table.CurrentPlayer != expected.CurrentPlayer || !sameCards(step.Table.CommunityCards, expected.CommunityCards) {
+			t.Fatalf("Step %d differs: phase %s/%s pot %d/%d current %d/%d", i,
+				step.Table.Phase, expected.Phase, step.Table.Pot, expected.Pot, step.Table.CurrentPlayer, expected.CurrentPlayer)
+		}
+		for seat := range expected.Players {
+			if step.Table.Players[seat].Stack != expected.Players[seat].Stack {
+				t.Fatalf("Step %d: seat %d stack %d, expected %d", i, seat,
+					step.Table.Players[seat].Stack, expected.Players[seat].Stack)
+			}
+		}
+	}
+
+	if steps[0].Action != nil || steps[1].Action == nil || steps[1].Action.Command != "raise" {
+		t.Error("First step should be the dealt hand and the second the first action")
+	}
+}
+
+// TestReplayHandDetectsMismatch verifica que una reproducción distinta a lo grabado falle
+func TestReplayHandDetectsMismatch(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "replay_mismatch", seatConfig(6))
+	mustSeat(t, engine, table, 0, 2, 4)
+	table.AutoRestart = false
+	playRecordedHand(t, engine, table)
+	history := table.HandHistories[0]
+
+	// Stack final alterado
+	tampered := *history
+	tampered.Seats = append([]HistorySeat(nil), history.Seats...)
+	tampered.Seats[0].FinalStack++
+	if _, err := ReplayHand(&tampered); err == nil || !strings.Contains(err.Error(), "no coincide") {
+		t.Errorf("Expected final stack mismatch, got %v", err)
+	}
+
+	// Deck alterado: el reparto no coincide
+	tampered = *history
+	tampered.Deck = append([]Card(nil), history.Deck...)
+	tampered.Deck[0], tampered.Deck[len(tampered.Deck)-1] = tampered.Deck[len(tampered.Deck)-1], tampered.Deck[0]
+	if _, err := ReplayHand(&tampered); err == nil {
+		t.Error("Expected a deal mismatch with a different deck")
+	}
+
+	// Acción imposible: los pasos previos se conservan
+	tampered = *history
+	tampered.Actions = append([]HistoryAction(nil), history.Actions...)
+	for i := range tampered.Actions {
+		if tampered.Actions[i].Command == "check" {
+			tampered.Actions[i].Command = "call"
+			break
+		}
+	}
+	steps, err := ReplayHand(&tampered)
+	if err == nil || len(steps) == 0 {
+		t.Errorf("Expected an invalid action error with partial steps, got %v", err)
+	}
+
+	if _, err := ReplayHand(&HandHistory{}); err == nil {
+		t.Error("History without a deck should be rejected")
+	}
+}
+
+// TestReplayRunItTwice verifica la reproducción de los votos de run it twice
+func TestReplayRunItTwice(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "replay_run_it_twice", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+	table.AutoRestart = false
+	engine.VoteRunItTwice(table.ID, "alice", 2)
+	engine.VoteRunItTwice(table.ID, "bob", 2)
+
+	steps, err := ReplayHand(table.HandHistories[0])
+	if err != nil {
+		t.Fatalf("Unexpected replay error: %v", err)
+	}
+	final := steps[len(steps)-1].Table
+	if final.Runouts != 2 || len(final.Boards) != 2 {
+		t.Errorf("Expected 2 runouts in the replay, got %d", final.Runouts)
+	}
+	for run := range final.Boards {
+		if !sameCards(final.Boards[run], table.Boards[run]) {
+			t.Errorf("Board %d differs from the live hand", run)
+		}
+	}
+}
+
+// TestReplayTripleDraw verifica la reproducción de los descartes
+func TestReplayTripleDraw(t *testing.T) {
+	engine := NewPokerEngine()
+	table := mustCreateTable(t, engine, "replay_draw", TableConfig{
+		Variant: VariantTripleDraw, SmallBlind: 10, BigBlind: 20, BuyInAmount: 1000,
+	})
+	table.AutoRestart = false
+	engine.AddPlayer(table.ID, "a", "a")
+	engine.AddPlayer(table.ID, "b", "b")
+	engine.startHand(table)
+
+	for table.Phase != "showdown" {
+		player := table.Players[table.CurrentPlayer]
+		var err error
+		switch {
+		case isDrawPhase(table.Phase):
+			_, err = engine.PlayerDraw(table.ID, player.ID, []int{0, 2})
+		case table.CurrentBet > player.CurrentBet:
+			_, err = engine.PlayerAction(table.ID, player.ID, "call", 0)
+		default:
+			_, err = engine.PlayerAction(table.ID, player.ID, "check", 0)
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error in phase %s: %v", table.Phase, err)
+		}
+	}
+
+	steps, err := ReplayHand(table.HandHistories[0])
+	if err != nil {
+		t.Fatalf("Unexpected replay error: %v", err)
+	}
+	final := steps[len(steps)-1].Table
+	for seat, player := range table.Players {
+		if !sameCards(final.Players[seat].Cards, player.Cards) {
+			t.Errorf("Seat %d finished with different cards in the replay", seat)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package poker
+
+// ====== RESULTADO DE LA MANO ======
+//
+// Al terminar la mano el engine registra cómo se repartió cada side pot, para
+// que los clientes puedan animar el showdown sin comparar stacks.
+
+// HandResult resume el reparto de una mano terminada
+type HandResult struct {
+	HandNumber  int         `json:"hand_number"`
+	Boards      [][]Card    `json:"boards"`      // Board de cada runout (uno solo si no hubo run it twice)
+	Pots        []PotResult `json:"pots"`        // Un resultado por side pot y runout
+	Uncontested bool        `json:"uncontested"` // Todos los demás se retiraron: no hubo showdown
+	Rake        int         `json:"rake"`        // Fichas que se llevó la casa (ver rake.go)
+}
+
+// PotResult es el reparto de un side pot (o de su parte en un runout)
+type PotResult struct {
+	PotIndex        int         `json:"pot_index"`        // 0 = pot principal
+	Run             int         `json:"run"`              // Runout en el que se jugó esta parte
+	Amount          int         `json:"amount"`           // Fichas repartidas
+	Rake            int         `json:"rake"`             // Fichas que se descontaron de esta parte para la casa
+	EligiblePlayers []int       `json:"eligible_players"` // Asientos que podían ganarlo
+	Winners         []PotWinner `json:"winners"`
+}
+
+// PotWinner es un ganador de un side pot
+type PotWinner struct {
+	Seat     int    `json:"seat"`
+	PlayerID string `json:"player_id"`
+	Name     string `json:"name"`
+	Amount   int    `json:"amount"`              // Fichas que recibió de este pot
+	RankName string `json:"rank_name,omitempty"` // Vacío si ganó sin mostrar
+	BestHand []Card `json:"best_hand,omitempty"` // Las 5 mejores cartas
+}
+
+// newHandResult crea el resultado vacío de la mano actual
+func newHandResult(table *PokerTable, boards [][]Card) *HandResult {
+	inHand := 0
+	for _, player := range table.Players {
+		if player.IsActive && !player.HasFolded {
+			inHand++
+		}
+	}
+
+	result := &HandResult{
+		HandNumber:  table.HandNumber,
+		Boards:      make([][]Card, len(boards)),
+		Pots:        make([]PotResult, 0, len(table.SidePots)*len(boards)),
+		Uncontested: inHand <= 1,
+	}
+	for run, board := range boards {
+		result.Boards[run] = append([]Card(nil), board...)
+	}
+	return result
+}
+
+// newPotWinner arma el ganador de un pot con su mano (si llegó al showdown)
+func newPotWinner(table *PokerTable, seat, amount int, hand *HandEvaluation, uncontested bool) PotWinner {
+	player := table.Players[seat]
+	winner := PotWinner{
+		Seat:     seat,
+		PlayerID: player.ID,
+		Name:     player.Name,
+		Amount:   amount,
+	}
+	if hand != nil && !uncontested {
+		winner.RankName = hand.RankName
+		winner.BestHand = append([]Card(nil), hand.Cards...)
+	}
+	return winner
+}
@@ -0,0 +1,132 @@
+package poker
+
+import (
+	"testing"
+)
+
+// TestHandResultUncontested verifica el resultado cuando todos se retiran antes del showdown
+func TestHandResultUncontested(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "result_uncontested", seatConfig(10))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	// Button 1, small blind 2, big blind 0: UTG (1) y el small blind se retiran
+	engine.startHand(table)
+	mustAct(t, engine, table, "fold", 0)
+	mustAct(t, engine, table, "fold", 0)
+
+	result := table.HandResult
+	if result == nil {
+		t.Fatal("Expected a hand result after the hand ended")
+	}
+	if !result.Uncontested || result.HandNumber != 1 {
+		t.Errorf("Expected uncontested result for hand 1, got %+v", result)
+	}
+	if len(result.Pots) != 1 || len(result.Pots[0].Winners) != 1 {
+		t.Fatalf("Expected one pot with one winner, got %+v", result.Pots)
+	}
+
+	winner := result.Pots[0].Winners[0]
+	if winner.Seat != 0 || winner.Name != "seat0" || winner.Amount != 30 {
+		t.Errorf("Big blind should win 30, got %+v", winner)
+	}
+	if winner.RankName != "" || len(winner.BestHand) != 0 {
+		t.Error("Uncontested winner should not show a hand")
+	}
+
+	// La próxima mano limpia el resultado
+	engine.startHand(table)
+	if table.HandResult != nil {
+		t.Error("Hand result should be cleared when a new hand starts")
+	}
+}
+
+// TestHandResultSidePots verifica ganadores, montos y manos de cada side pot
+func TestHandResultSidePots(t *testing.T) {
+	engine := NewPokerEngine()
+	table := &PokerTable{
+		Players: []PokerPlayer{
+			// El short stack gana el pot principal con escalera al As
+			{ID: "short", Name: "Short", IsActive: true, IsAllIn: true, TotalBet: 50,
+				Cards: []Card{{"hearts", "A"}, {"clubs", "K"}}},
+			// bob y carol empatan el side pot con la misma escalera del board
+			{ID: "bob", Name: "Bob", IsActive: true, TotalBet: 151,
+				Cards: []Card{{"hearts", "2"}, {"clubs", "3"}}},
+			{ID: "carol", Name: "Carol", IsActive: true, TotalBet: 151,
+				Cards: []Card{{"diamonds", "2"}, {"spades", "3"}}},
+		},
+		CommunityCards: []Card{{"spades", "Q"}, {"hearts", "J"}, {"clubs", "10"}, {"diamonds", "9"}, {"spades", "8"}},
+		Phase:          "river",
+		HandNumber:     7,
+	}
+
+	engine.completeHand(table)
+
+	result := table.HandResult
+	if result == nil || result.Uncontested || result.HandNumber != 7 {
+		t.Fatalf("Expected a showdown result for hand 7, got %+v", result)
+	}
+	if len(result.Pots) != 2 {
+		t.Fatalf("Expected 2 pots, got %d", len(result.Pots))
+	}
+
+	mainPot := result.Pots[0]
+	if mainPot.Amount != 150 || len(mainPot.Winners) != 1 || mainPot.Winners[0].PlayerID != "short" {
+		t.Fatalf("Short stack should win the main pot of 150, got %+v", mainPot)
+	}
+	if mainPot.Winners[0].RankName != "Straight" || len(mainPot.Winners[0].BestHand) != 5 {
+		t.Errorf("Expected a straight with 5 cards, got %s %v", mainPot.Winners[0].RankName, mainPot.Winners[0].BestHand)
+	}
+
+	side := result.Pots[1]
+	if side.PotIndex != 1 || side.Amount != 202 || len(side.Winners) != 2 {
+		t.Fatalf("Expected side pot of 202 split in two, got %+v", side)
+	}
+	if side.Winners[0].Amount != 101 || side.Winners[1].Amount != 101 {
+		t.Errorf("Expected 101 each, got %d and %d", side.Winners[0].Amount, side.Winners[1].Amount)
+	}
+	if len(side.EligiblePlayers) != 2 {
+		t.Errorf("Expected 2 eligible players for the side pot, got %v", side.EligiblePlayers)
+	}
+
+	// Los montos del resultado coinciden con los stacks
+	for _, player := range table.Players {
+		won := 0
+		for _, pot := range result.Pots {
+			for _, winner := range pot.Winners {
+				if winner.PlayerID == player.ID {
+					won += winner.Amount
+				}
+			}
+		}
+		if won != player.Stack {
+			t.Errorf("%s received %d but result reports %d", player.Name, player.Stack, won)
+		}
+	}
+}
+
+// TestHandResultRunouts verifica un resultado por runout cuando se corre el board dos veces
+func TestHandResultRunouts(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "result_runouts", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+	table.AutoRestart = false
+
+	engine.VoteRunItTwice(table.ID, "alice", 2)
+	engine.VoteRunItTwice(table.ID, "bob", 2)
+
+	result := table.HandResult
+	if result == nil || len(result.Boards) != 2 {
+		t.Fatalf("Expected a result with 2 boards, got %+v", result)
+	}
+
+	runs := make(map[int]int)
+	for _, pot := range result.Pots {
+		runs[pot.Run] += pot.Amount
+	}
+	if runs[0] != 1000 || runs[1] != 1000 {
+		t.Errorf("Expected 1000 played on each runout, got %v", runs)
+	}
+}
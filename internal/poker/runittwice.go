@@ -0,0 +1,225 @@
+package poker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ====== RUN IT TWICE ======
+//
+// Cuando todos los jugadores que siguen en la mano están all-in antes del river
+// (o solo uno conserva fichas), la mesa puede ofrecer correr el board restante
+// varias veces. Si todos aceptan, cada side pot se reparte en partes iguales
+// entre los runouts y cada runout se evalúa por separado. Quien no decide antes
+// de RunItTwiceDeadline, o no está en la mesa para decidir, lo corre una vez.
+
+// PhaseRunItTwice es la fase en la que se espera la decisión de los jugadores
+const PhaseRunItTwice = "run_it_twice"
+
+// maxRunouts es la cantidad máxima de veces que se puede correr el board
+const maxRunouts = 3
+
+// runItTwiceTimeout es el plazo para decidir cuántas veces se corre el board
+const runItTwiceTimeout = 15 * time.Second
+
+// usesBoard indica si la variante juega con cartas comunitarias
+func usesBoard(variant string) bool {
+	return variant != VariantStud && variant != VariantTripleDraw
+}
+
+// offerRunItTwice pasa a la fase de consentimiento si ya no quedan apuestas posibles
+// antes del river. Retorna true si la mano queda esperando la decisión de los jugadores.
+func (pe *PokerEngine) offerRunItTwice(table *PokerTable) bool {
+	if !table.RunItTwice || !usesBoard(table.Variant) {
+		return false
+	}
+	if table.Phase != "preflop" && table.Phase != "flop" && table.Phase != "turn" {
+		return false
+	}
+
+	inHand, bettors := 0, 0
+	for _, player := range table.Players {
+		if player.IsActive && !player.HasFolded {
+			inHand++
+		}
+		if canStillBet(player) {
+			bettors++
+		}
+	}
+	if inHand < 2 || bettors > 1 {
+		return false
+	}
+
+	for i := range table.Players {
+		table.Players[i].RunItTwiceVote = 0
+	}
+	table.Phase = PhaseRunItTwice
+	table.RunItTwiceDeadline = time.Now().Add(runItTwiceTimeout)
+
+	// El timer no toma el lock hasta que vence: se valida contra la mano y el plazo vigentes
+	tableID, hand, deadline := table.ID, table.HandNumber, table.RunItTwiceDeadline
+	time.AfterFunc(runItTwiceTimeout, func() {
+		if pe.expireRunItTwice(tableID, hand, deadline) {
+			pe.notifyUpdate(tableID)
+		}
+	})
+
+	// Los desconectados y los sentados afuera no pueden decidir
+	pe.voteForAbsentPlayers(table)
+	return true
+}
+
+// VoteRunItTwice registra cuántas veces quiere correr el board el jugador (1 = una sola vez).
+// Cuando todos votaron se usa el menor pedido: basta que uno no acepte para correrlo una vez.
+func (pe *PokerEngine) VoteRunItTwice(tableID, playerID string, runs int) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	if table.Phase != PhaseRunItTwice {
+		return nil, fmt.Errorf("no hay un run it twice pendiente")
+	}
+
+	playerIndex := findPlayerSeat(table, playerID)
+	if playerIndex == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	player := &table.Players[playerIndex]
+	if !player.IsActive || player.HasFolded {
+		return nil, fmt.Errorf("solo los jugadores que siguen en la mano pueden decidir")
+	}
+	if runs < 1 || runs > maxRunouts {
+		return nil, fmt.Errorf("cantidad de runouts inválida: %d (entre 1 y %d)", runs, maxRunouts)
+	}
+	pe.castRunItTwiceVote(table, playerIndex, runs)
+	pe.settleRunItTwice(table)
+	return table, nil
+}
+
+// castRunItTwiceVote anota el voto del jugador y lo registra en el historial
+func (pe *PokerEngine) castRunItTwiceVote(table *PokerTable, seat, runs int) {
+	table.Players[seat].RunItTwiceVote = runs
+	pe.recordRunItTwiceVote(table, seat, runs)
+}
+
+// voteForAbsentPlayers vota una sola vez por los jugadores de la mano que están
+// desconectados o sentados afuera, y corre el board si ya decidieron todos
+func (pe *PokerEngine) voteForAbsentPlayers(table *PokerTable) {
+	for i, player := range table.Players {
+		if player.IsActive && !player.HasFolded && player.RunItTwiceVote == 0 &&
+			(!player.IsConnected || player.SittingOut) {
+			pe.castRunItTwiceVote(table, i, 1)
+		}
+	}
+	pe.settleRunItTwice(table)
+}
+
+// settleRunItTwice corre el board cuando ya votaron todos los jugadores de la mano.
+// Se usa el menor pedido: basta que uno no acepte para correrlo una vez.
+func (pe *PokerEngine) settleRunItTwice(table *PokerTable) {
+	agreed := maxRunouts
+	for _, p := range table.Players {
+		if !p.IsActive || p.HasFolded {
+			continue
+		}
+		if p.RunItTwiceVote == 0 {
+			return
+		}
+		if p.RunItTwiceVote < agreed {
+			agreed = p.RunItTwiceVote
+		}
+	}
+
+	table.RunItTwiceDeadline = time.Time{}
+	pe.runOutBoards(table, agreed)
+	table.Phase = "showdown"
+	pe.completeHand(table)
+}
+
+// expireRunItTwice vota una sola vez por quienes no decidieron a tiempo y corre el board.
+// Retorna true si la mesa cambió.
+func (pe *PokerEngine) expireRunItTwice(tableID string, hand int, deadline time.Time) bool {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists || table.HandNumber != hand || table.Phase != PhaseRunItTwice ||
+		!table.RunItTwiceDeadline.Equal(deadline) {
+		return false // Ya decidieron todos
+	}
+
+	for i, player := range table.Players {
+		if player.IsActive && !player.HasFolded && player.RunItTwiceVote == 0 {
+			pe.castRunItTwiceVote(table, i, 1)
+		}
+	}
+	pe.settleRunItTwice(table)
+	return true
+}
+
+// runOutBoards reparte las cartas comunitarias que faltan una vez por runout.
+// Si el deck no alcanza para todos los runouts pedidos, se corren menos.
+func (pe *PokerEngine) runOutBoards(table *PokerTable, runs int) {
+	missing := 5 - len(table.CommunityCards)
+	streets := 0
+	switch len(table.CommunityCards) {
+	case 0:
+		streets = 3
+	case 3:
+		streets = 2
+	case 4:
+		streets = 1
+	}
+	cardsPerRun := missing + streets // Cartas comunitarias más una quemada por calle
+
+	for runs > 1 && runs*cardsPerRun > len(table.Deck) {
+		runs--
+	}
+
+	table.Boards = make([][]Card, 0, runs)
+	for run := 0; run < runs; run++ {
+		board := make([]Card, len(table.CommunityCards), 5)
+		copy(board, table.CommunityCards)
+		for len(board) < 5 && len(table.Deck) > 0 {
+			// Quemar una carta al comienzo de cada calle
+			if len(board) == 0 || len(board) >= 3 {
+				table.Deck = table.Deck[1:]
+			}
+			count := 1
+			if len(board) == 0 {
+				count = 3
+			}
+			for i := 0; i < count && len(table.Deck) > 0; i++ {
+				board = append(board, table.Deck[0])
+				table.Deck = table.Deck[1:]
+			}
+		}
+		table.Boards = append(table.Boards, board)
+	}
+
+	// El primer runout queda como board principal para compatibilidad
+	table.CommunityCards = table.Boards[0]
+	table.Runouts = runs
+}
+
+// showdownBoards retorna los boards a evaluar en el showdown (uno por runout)
+func showdownBoards(table *PokerTable) [][]Card {
+	if len(table.Boards) > 1 {
+		return table.Boards
+	}
+	return [][]Card{table.CommunityCards}
+}
+
+// runoutShare retorna la parte de un pot que se juega en un runout; el resto va al primero
+func runoutShare(amount, runs, run int) int {
+	share := amount / runs
+	if run == 0 {
+		share += amount % runs
+	}
+	return share
+}
@@ -0,0 +1,229 @@
+package poker
+
+import (
+	"testing"
+)
+
+// runItTwiceConfig es la configuración de las mesas heads-up 10/20 de los tests de run it twice
+var runItTwiceConfig = TableConfig{
+	SmallBlind: 10, BigBlind: 20, RunItTwice: true,
+	BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000, IsCashGame: true,
+}
+
+// goAllInPreflop lleva a ambos jugadores all-in preflop: bob es el button
+// (small blind) y actúa primero.
+func goAllInPreflop(t *testing.T, engine *PokerEngine, table *PokerTable) {
+	t.Helper()
+	if _, err := engine.PlayerAction(table.ID, "bob", "all_in", 0); err != nil {
+		t.Fatalf("Unexpected error on all-in: %v", err)
+	}
+	if _, err := engine.PlayerAction(table.ID, "alice", "call", 0); err != nil {
+		t.Fatalf("Unexpected error on call: %v", err)
+	}
+}
+
+// totalChips suma los stacks de todos los jugadores (con la mano ya repartida)
+func totalChips(table *PokerTable) int {
+	total := 0
+	for _, player := range table.Players {
+		total += player.Stack
+	}
+	return total
+}
+
+// TestRunItTwiceAgreed verifica que con consentimiento de todos se corran dos boards independientes
+func TestRunItTwiceAgreed(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "run_it_twice_agreed", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+	table.AutoRestart = false
+
+	if table.Phase != PhaseRunItTwice {
+		t.Fatalf("Expected run it twice decision, got phase %s", table.Phase)
+	}
+	if _, err := engine.PlayerAction(table.ID, table.Players[table.CurrentPlayer].ID, "check", 0); err == nil {
+		t.Error("Betting actions should be rejected while waiting for the decision")
+	}
+
+	if _, err := engine.VoteRunItTwice(table.ID, "bob", 2); err != nil {
+		t.Fatalf("Unexpected error voting: %v", err)
+	}
+	if table.Phase != PhaseRunItTwice {
+		t.Fatalf("Board should wait for every player, got phase %s", table.Phase)
+	}
+	if _, err := engine.VoteRunItTwice(table.ID, "alice", 3); err != nil {
+		t.Fatalf("Unexpected error voting: %v", err)
+	}
+
+	// Se usa el menor pedido
+	if table.Phase != "showdown" || table.Runouts != 2 || len(table.Boards) != 2 {
+		t.Fatalf("Expected showdown with 2 runouts, got phase %s runouts %d boards %d",
+			table.Phase, table.Runouts, len(table.Boards))
+	}
+
+	seen := make(map[Card]bool)
+	for _, player := range table.Players {
+		for _, card := range player.Cards {
+			seen[card] = true
+		}
+	}
+	for run, board := range table.Boards {
+		if len(board) != 5 {
+			t.Fatalf("Board %d has %d cards, expected 5", run, len(board))
+		}
+		for _, card := range board {
+			if seen[card] {
+				t.Errorf("Card %s of %s dealt twice", card.Rank, card.Suit)
+			}
+			seen[card] = true
+		}
+	}
+
+	if totalChips(table) != 2000 {
+		t.Errorf("Chips not conserved: %d", totalChips(table))
+	}
+}
+
+// TestRunItTwiceDeclined verifica que basta un rechazo para correr el board una sola vez
+func TestRunItTwiceDeclined(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "run_it_twice_declined", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+	table.AutoRestart = false
+
+	engine.VoteRunItTwice(table.ID, "alice", 2)
+	if _, err := engine.VoteRunItTwice(table.ID, "bob", 1); err != nil {
+		t.Fatalf("Unexpected error voting: %v", err)
+	}
+
+	if table.Phase != "showdown" || table.Runouts != 1 || len(table.CommunityCards) != 5 {
+		t.Fatalf("Expected a single runout, got phase %s runouts %d board %d",
+			table.Phase, table.Runouts, len(table.CommunityCards))
+	}
+	if totalChips(table) != 2000 {
+		t.Errorf("Chips not conserved: %d", totalChips(table))
+	}
+}
+
+// TestRunItTwiceDisabled verifica que sin la opción de la mesa la mano siga normalmente
+func TestRunItTwiceDisabled(t *testing.T) {
+	engine := NewPokerEngine()
+	config := runItTwiceConfig
+	config.RunItTwice = false
+	table := newTestTable(t, engine, "run_it_twice_disabled", config, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+
+	if table.Phase != "flop" {
+		t.Errorf("Expected flop without run it twice, got %s", table.Phase)
+	}
+	if _, err := engine.VoteRunItTwice(table.ID, "alice", 2); err == nil {
+		t.Error("Vote should be rejected without a pending decision")
+	}
+}
+
+// TestRunItTwiceVoteValidation verifica los votos inválidos
+func TestRunItTwiceVoteValidation(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "run_it_twice_validation", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+
+	if _, err := engine.VoteRunItTwice(table.ID, "alice", 0); err == nil {
+		t.Error("Zero runouts should be rejected")
+	}
+	if _, err := engine.VoteRunItTwice(table.ID, "alice", maxRunouts+1); err == nil {
+		t.Error("Too many runouts should be rejected")
+	}
+	if _, err := engine.VoteRunItTwice(table.ID, "carol", 2); err == nil {
+		t.Error("Unknown player should be rejected")
+	}
+}
+
+// TestRunItTwiceDeadline verifica que al vencer el plazo se corra el board una sola vez
+func TestRunItTwiceDeadline(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "run_it_twice_deadline", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+	table.AutoRestart = false
+
+	if table.RunItTwiceDeadline.IsZero() {
+		t.Fatal("The decision should have a deadline")
+	}
+
+	engine.VoteRunItTwice(table.ID, "alice", 2)
+	if !engine.expireRunItTwice(table.ID, table.HandNumber, table.RunItTwiceDeadline) {
+		t.Fatal("Deadline should resolve the pending decision")
+	}
+
+	if table.Phase != "showdown" || table.Runouts != 1 {
+		t.Fatalf("Expected a single runout after the deadline, got phase %s runouts %d", table.Phase, table.Runouts)
+	}
+	if table.Players[findPlayerSeat(table, "bob")].RunItTwiceVote != 1 {
+		t.Error("Undecided player should count as one run")
+	}
+	if totalChips(table) != 2000 {
+		t.Errorf("Chips not conserved: %d", totalChips(table))
+	}
+}
+
+// TestRunItTwiceAbsentPlayer verifica que un jugador desconectado cuente como una sola vez
+func TestRunItTwiceAbsentPlayer(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "run_it_twice_absent", runItTwiceConfig, "alice", "bob")
+	startTestGame(t, engine, table)
+	goAllInPreflop(t, engine, table)
+	table.AutoRestart = false
+
+	if err := engine.SetPlayerConnected(table.ID, "bob", false); err != nil {
+		t.Fatalf("Unexpected error disconnecting: %v", err)
+	}
+	if table.Phase != PhaseRunItTwice {
+		t.Fatalf("Board should wait for alice, got phase %s", table.Phase)
+	}
+
+	if _, err := engine.VoteRunItTwice(table.ID, "alice", 2); err != nil {
+		t.Fatalf("Unexpected error voting: %v", err)
+	}
+	if table.Phase != "showdown" || table.Runouts != 1 {
+		t.Fatalf("Expected a single runout, got phase %s runouts %d", table.Phase, table.Runouts)
+	}
+}
+
+// TestRunItTwiceSplitsPots verifica que cada runout reparta su parte del pot por separado
+func TestRunItTwiceSplitsPots(t *testing.T) {
+	engine := NewPokerEngine()
+
+	aliceWins := []Card{{"hearts", "A"}, {"clubs", "A"}, {"diamonds", "7"}, {"spades", "8"}, {"clubs", "2"}}
+	bobWins := []Card{{"hearts", "K"}, {"clubs", "K"}, {"diamonds", "7"}, {"spades", "8"}, {"clubs", "2"}}
+
+	table := &PokerTable{
+		Players: []PokerPlayer{
+			{ID: "alice", IsActive: true, IsAllIn: true, TotalBet: 101, Cards: []Card{{"spades", "A"}, {"diamonds", "3"}}},
+			{ID: "bob", IsActive: true, IsAllIn: true, TotalBet: 101, Cards: []Card{{"spades", "K"}, {"diamonds", "4"}}},
+			{ID: "carol", HasFolded: true, TotalBet: 20},
+		},
+		Boards:         [][]Card{aliceWins, bobWins},
+		CommunityCards: aliceWins,
+	}
+
+	engine.createSidePots(table)
+	engine.distributeSidePots(table)
+
+	// Pot de 222: 111 por runout
+	if table.Players[0].Stack != 111 || table.Players[1].Stack != 111 {
+		t.Errorf("Expected 111 each, got alice %d bob %d", table.Players[0].Stack, table.Players[1].Stack)
+	}
+
+	// Con un pot impar, el resto va al primer runout
+	table.Players[0].Stack, table.Players[1].Stack = 0, 0
+	table.Players[2].TotalBet = 21
+	engine.createSidePots(table)
+	engine.distributeSidePots(table)
+	if table.Players[0].Stack != 112 || table.Players[1].Stack != 111 {
+		t.Errorf("Expected 112/111, got alice %d bob %d", table.Players[0].Stack, table.Players[1].Stack)
+	}
+}
@@ -0,0 +1,319 @@
+package poker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ====== ASIENTOS Y DEAD BUTTON ======
+//
+// Players es un mapa fijo de asientos: el índice es el número de asiento y un
+// asiento vacío es un PokerPlayer sin ID. El button avanza asiento por asiento
+// con la regla del dead button: el big blind siempre pasa al siguiente jugador
+// activo, el small blind queda en el asiento del big blind anterior y el button
+// en el del small blind anterior, aunque esos asientos estén vacíos.
+
+// Límites de asientos por mesa
+const (
+	minTableSeats = 2
+	maxTableSeats = 10
+)
+
+// normalizeSeatCount retorna la cantidad de asientos de la mesa
+// (por defecto el máximo que admite la variante)
+func normalizeSeatCount(seats int, variant string) int {
+	variantMax := maxPlayersForVariant(normalizeVariant(variant))
+	if seats <= 0 || seats > variantMax {
+		return variantMax
+	}
+	if seats < minTableSeats {
+		return minTableSeats
+	}
+	return seats
+}
+
+// validateSeatCount verifica que la cantidad de asientos sea válida para la variante
+func validateSeatCount(seats int, variant string) error {
+	if seats == 0 {
+		return nil
+	}
+	if seats < minTableSeats || seats > maxTableSeats {
+		return fmt.Errorf("la mesa debe tener entre %d y %d asientos", minTableSeats, maxTableSeats)
+	}
+	if variantMax := maxPlayersForVariant(normalizeVariant(variant)); seats > variantMax {
+		return fmt.Errorf("la variante %s admite como máximo %d asientos", normalizeVariant(variant), variantMax)
+	}
+	return nil
+}
+
+// isEmptySeat indica si el asiento no tiene jugador
+func isEmptySeat(player PokerPlayer) bool {
+	return player.ID == ""
+}
+
+// seatCount retorna la cantidad de asientos de la mesa
+func seatCount(table *PokerTable) int {
+	if table.MaxSeats > 0 {
+		return table.MaxSeats
+	}
+	return normalizeSeatCount(0, table.Variant)
+}
+
+// seatedPlayers cuenta los asientos ocupados
+func seatedPlayers(table *PokerTable) int {
+	count := 0
+	for _, player := range table.Players {
+		if !isEmptySeat(player) {
+			count++
+		}
+	}
+	return count
+}
+
+// findPlayerSeat retorna el asiento del jugador o -1 si no está sentado
+func findPlayerSeat(table *PokerTable, playerID string) int {
+	if playerID == "" {
+		return -1
+	}
+	for i, player := range table.Players {
+		if player.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// newSeatMap crea un mapa de asientos vacíos
+func newSeatMap(seats int) []PokerPlayer {
+	players := make([]PokerPlayer, seats)
+	for i := range players {
+		players[i].Position = i
+	}
+	return players
+}
+
+// resizeSeats ajusta el mapa de asientos a la cantidad indicada.
+// Falla si hay un jugador sentado en un asiento que desaparece.
+func resizeSeats(table *PokerTable, seats int) error {
+	for i := seats; i < len(table.Players); i++ {
+		if !isEmptySeat(table.Players[i]) {
+			return fmt.Errorf("no se puede reducir la mesa a %d asientos: el asiento %d está ocupado", seats, i)
+		}
+	}
+
+	if len(table.Players) > seats {
+		table.Players = table.Players[:seats]
+	}
+	for len(table.Players) < seats {
+		table.Players = append(table.Players, PokerPlayer{Position: len(table.Players)})
+	}
+	table.MaxSeats = seats
+	return nil
+}
+
+// seatPlayer sienta a un jugador nuevo en el asiento pedido, o en el primero libre si seat es -1
+func (pe *PokerEngine) seatPlayer(table *PokerTable, playerID, playerName string, stack, seat int) error {
+	if findPlayerSeat(table, playerID) != -1 {
+		return fmt.Errorf("player already at table")
+	}
+
+	// Completar asientos vacíos si la mesa se armó con menos posiciones
+	if len(table.Players) < seatCount(table) {
+		if err := resizeSeats(table, seatCount(table)); err != nil {
+			return err
+		}
+	}
+
+	if seat == -1 {
+		for i, player := range table.Players {
+			if isEmptySeat(player) {
+				seat = i
+				break
+			}
+		}
+		if seat == -1 {
+			return fmt.Errorf("table is full")
+		}
+	} else {
+		if seat < 0 || seat >= len(table.Players) {
+			return fmt.Errorf("asiento inválido: %d (la mesa tiene %d asientos)", seat, len(table.Players))
+		}
+		if !isEmptySeat(table.Players[seat]) {
+			return fmt.Errorf("el asiento %d está ocupado", seat)
+		}
+	}
+
+	// El primer jugador sentado es el host
+	isHost := seatedPlayers(table) == 0
+
+	table.Players[seat] = PokerPlayer{
+		ID:           playerID,
+		Name:         playerName,
+		Stack:        stack,
+		Cards:        make([]Card, 0, holeCardCount(table.Variant)),
+		Position:     seat,
+		IsActive:     true,
+		IsReady:      false,
+		IsHost:       isHost,
+		IsConnected:  true,
+		LastSeenTime: time.Now(),
+		TimeBank:     table.TimeBank,
+		BuyIn:        stack,
+	}
+
+	if table.Phase == "waiting" {
+		table.Phase = "lobby" // Esperando que los jugadores estén listos
+	} else if table.Phase != "lobby" {
+		// Si hay una mano en progreso, el jugador debe esperar a la siguiente mano
+		table.Players[seat].IsActive = false
+	}
+
+	return nil
+}
+
+// AddPlayerAtSeat sienta a un jugador en un asiento elegido con el buy-in indicado
+// (0 usa el buy-in estándar de la mesa)
+func (pe *PokerEngine) AddPlayerAtSeat(tableID, playerID, playerName string, seat, buyInAmount int) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		table = pe.createTableInternal(tableID)
+	}
+
+	if buyInAmount == 0 {
+		buyInAmount = table.BuyInAmount
+	}
+	if buyInAmount < table.MinBuyIn {
+		return table, fmt.Errorf("buy-in amount %d is below minimum %d", buyInAmount, table.MinBuyIn)
+	}
+	if buyInAmount > table.MaxBuyIn {
+		return table, fmt.Errorf("buy-in amount %d is above maximum %d", buyInAmount, table.MaxBuyIn)
+	}
+
+	if seat < 0 {
+		return table, fmt.Errorf("asiento inválido: %d", seat)
+	}
+
+	if err := pe.seatPlayer(table, playerID, playerName, buyInAmount, seat); err != nil {
+		return table, err
+	}
+	return table, nil
+}
+
+// ChangeSeat mueve a un jugador a otro asiento libre (solo entre manos, en el lobby)
+func (pe *PokerEngine) ChangeSeat(tableID, playerID string, seat int) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	from := findPlayerSeat(table, playerID)
+	if from == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if table.Phase != "lobby" && table.Phase != "waiting" {
+		return nil, fmt.Errorf("solo se puede cambiar de asiento en el lobby")
+	}
+
+	if len(table.Players) < seatCount(table) {
+		if err := resizeSeats(table, seatCount(table)); err != nil {
+			return nil, err
+		}
+	}
+	if seat < 0 || seat >= len(table.Players) {
+		return nil, fmt.Errorf("asiento inválido: %d (la mesa tiene %d asientos)", seat, len(table.Players))
+	}
+	if seat == from {
+		return table, nil
+	}
+	if !isEmptySeat(table.Players[seat]) {
+		return nil, fmt.Errorf("el asiento %d está ocupado", seat)
+	}
+
+	table.Players[seat] = table.Players[from]
+	table.Players[seat].Position = seat
+	table.Players[from] = PokerPlayer{Position: from}
+	return table, nil
+}
+
+// nextActiveSeat retorna el siguiente asiento (en sentido horario) con un jugador activo, o -1
+func nextActiveSeat(table *PokerTable, from int) int {
+	seats := len(table.Players)
+	for offset := 1; offset <= seats; offset++ {
+		seat := ((from+offset)%seats + seats) % seats
+		if table.Players[seat].IsActive {
+			return seat
+		}
+	}
+	return -1
+}
+
+// previousActiveSeat retorna el asiento activo anterior (en sentido antihorario), o -1
+func previousActiveSeat(table *PokerTable, from int) int {
+	seats := len(table.Players)
+	for offset := 1; offset <= seats; offset++ {
+		seat := ((from-offset)%seats + seats) % seats
+		if table.Players[seat].IsActive {
+			return seat
+		}
+	}
+	return -1
+}
+
+// isActiveSeat indica si el asiento existe y tiene un jugador activo en esta mano
+func isActiveSeat(table *PokerTable, seat int) bool {
+	return seat >= 0 && seat < len(table.Players) && table.Players[seat].IsActive
+}
+
+// moveButton ubica button y blinds para la nueva mano aplicando la regla del dead button.
+// Requiere al menos 2 jugadores activos.
+func (pe *PokerEngine) moveButton(table *PokerTable, activePlayers []int) {
+	firstHand := table.HandNumber <= 1 || !isValidSeat(table, table.BigBlindSeat)
+
+	if len(activePlayers) == 2 {
+		// Heads-up: el button pone el small blind y el otro jugador el big blind
+		if firstHand {
+			table.DealerPosition = nextActiveSeat(table, table.DealerPosition)
+			table.BigBlindSeat = nextActiveSeat(table, table.DealerPosition)
+		} else {
+			table.BigBlindSeat = nextActiveSeat(table, table.BigBlindSeat)
+			table.DealerPosition = nextActiveSeat(table, table.BigBlindSeat)
+		}
+		table.SmallBlindSeat = table.DealerPosition
+		return
+	}
+
+	if firstHand {
+		table.DealerPosition = nextActiveSeat(table, table.DealerPosition)
+		table.SmallBlindSeat = nextActiveSeat(table, table.DealerPosition)
+		table.BigBlindSeat = nextActiveSeat(table, table.SmallBlindSeat)
+		return
+	}
+
+	// El big blind siempre avanza al siguiente jugador activo; small blind y button
+	// quedan donde estaban el big blind y el small blind anteriores (pueden quedar muertos)
+	previousSmallBlind := table.SmallBlindSeat
+	previousBigBlind := table.BigBlindSeat
+	table.BigBlindSeat = nextActiveSeat(table, previousBigBlind)
+	table.SmallBlindSeat = previousBigBlind
+	table.DealerPosition = previousSmallBlind
+
+	// Si entró un jugador entre los blinds anteriores o se pasó de heads-up a multiway,
+	// reconstruir las posiciones hacia atrás desde el big blind
+	if table.SmallBlindSeat == table.BigBlindSeat || !isValidSeat(table, table.DealerPosition) ||
+		table.DealerPosition == table.BigBlindSeat || table.DealerPosition == table.SmallBlindSeat {
+		table.SmallBlindSeat = previousActiveSeat(table, table.BigBlindSeat)
+		table.DealerPosition = previousActiveSeat(table, table.SmallBlindSeat)
+	}
+}
+
+// isValidSeat indica si el número de asiento existe en la mesa
+func isValidSeat(table *PokerTable, seat int) bool {
+	return seat >= 0 && seat < len(table.Players)
+}
@@ -0,0 +1,246 @@
+package poker
+
+import (
+	"fmt"
+	"testing"
+)
+
+// seatConfig es la configuración de una mesa 10/20 con la cantidad de asientos indicada
+func seatConfig(maxSeats int) TableConfig {
+	return TableConfig{
+		SmallBlind: 10, BigBlind: 20, MaxSeats: maxSeats,
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000, IsCashGame: true,
+	}
+}
+
+// mustSeat sienta un jugador (llamado "seatN") en cada asiento indicado y falla el test si es rechazado
+func mustSeat(t *testing.T, engine *PokerEngine, table *PokerTable, seats ...int) {
+	t.Helper()
+	for _, seat := range seats {
+		id := fmt.Sprintf("seat%d", seat)
+		if _, err := engine.AddPlayerAtSeat(table.ID, id, id, seat, 0); err != nil {
+			t.Fatalf("Error seating %s: %v", id, err)
+		}
+	}
+}
+
+// assertButton verifica el asiento del button y de los blinds
+func assertButton(t *testing.T, table *PokerTable, button, smallBlind, bigBlind int) {
+	t.Helper()
+	if table.DealerPosition != button || table.SmallBlindSeat != smallBlind || table.BigBlindSeat != bigBlind {
+		t.Fatalf("Hand %d: expected button %d / SB %d / BB %d, got %d / %d / %d", table.HandNumber,
+			button, smallBlind, bigBlind, table.DealerPosition, table.SmallBlindSeat, table.BigBlindSeat)
+	}
+}
+
+// TestSeatSelection verifica que los jugadores elijan asiento y que los números sean estables
+func TestSeatSelection(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "seat_selection", seatConfig(6))
+	mustSeat(t, engine, table, 4, 1)
+
+	if len(table.Players) != 6 || table.MaxSeats != 6 {
+		t.Fatalf("Expected a 6-seat map, got %d seats (max %d)", len(table.Players), table.MaxSeats)
+	}
+	if table.Players[4].ID != "seat4" || table.Players[4].Position != 4 || !table.Players[4].IsHost {
+		t.Errorf("First player should sit at seat 4 as host, got %+v", table.Players[4])
+	}
+	if table.Players[1].ID != "seat1" || table.Players[1].IsHost {
+		t.Errorf("Second player should sit at seat 1 without being host")
+	}
+	if !isEmptySeat(table.Players[0]) {
+		t.Error("Seat 0 should stay empty")
+	}
+
+	if _, err := engine.AddPlayerAtSeat(table.ID, "intruder", "intruder", 4, 0); err == nil {
+		t.Error("Taking an occupied seat should fail")
+	}
+	if _, err := engine.AddPlayerAtSeat(table.ID, "outside", "outside", 6, 0); err == nil {
+		t.Error("Seat outside the table should be rejected")
+	}
+
+	// AddPlayer usa el primer asiento libre
+	if _, err := engine.AddPlayer(table.ID, "auto", "auto"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if table.Players[0].ID != "auto" {
+		t.Errorf("AddPlayer should take the first free seat (0)")
+	}
+
+	// Cambiar de asiento solo en el lobby
+	if _, err := engine.ChangeSeat(table.ID, "auto", 5); err != nil {
+		t.Fatalf("Unexpected error changing seat: %v", err)
+	}
+	if table.Players[5].ID != "auto" || table.Players[5].Position != 5 || !isEmptySeat(table.Players[0]) {
+		t.Error("Player should have moved from seat 0 to seat 5")
+	}
+
+	engine.startHand(table)
+	if _, err := engine.ChangeSeat(table.ID, "auto", 0); err == nil {
+		t.Error("Changing seat during a hand should fail")
+	}
+}
+
+// TestTableFull verifica el límite de asientos configurado
+func TestTableFull(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "table_full", seatConfig(3))
+	mustSeat(t, engine, table, 0, 1, 2)
+
+	if _, err := engine.AddPlayer(table.ID, "fourth", "fourth"); err == nil {
+		t.Error("Fourth player should not fit in a 3-seat table")
+	}
+}
+
+// TestSeatCountConfig verifica los valores por defecto y la validación de asientos
+func TestSeatCountConfig(t *testing.T) {
+	engine := NewPokerEngine()
+
+	if table := engine.CreateTable("seats_default"); table.MaxSeats != 10 || len(table.Players) != 10 {
+		t.Errorf("Default table should have 10 seats, got %d", table.MaxSeats)
+	}
+	if stud := mustCreateTable(t, engine, "seats_stud", TableConfig{Variant: VariantStud}); stud.MaxSeats != 8 {
+		t.Errorf("Stud table should default to 8 seats, got %d", stud.MaxSeats)
+	}
+
+	table := newTestTable(t, engine, "seats_config", seatConfig(9))
+	mustSeat(t, engine, table, 0, 7)
+	config := TableConfig{SmallBlind: 10, BigBlind: 20, BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000}
+
+	for _, seats := range []int{1, 11} {
+		config.MaxSeats = seats
+		if err := engine.UpdateTableConfig(table.ID, config); err == nil {
+			t.Errorf("%d seats should be rejected", seats)
+		}
+	}
+
+	config.MaxSeats = 6
+	if err := engine.UpdateTableConfig(table.ID, config); err == nil {
+		t.Error("Shrinking below an occupied seat should be rejected")
+	}
+
+	config.MaxSeats = 8
+	if err := engine.UpdateTableConfig(table.ID, config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(table.Players) != 8 || table.Players[7].ID != "seat7" {
+		t.Errorf("Expected 8 seats keeping seat 7, got %d seats", len(table.Players))
+	}
+
+	config.MaxSeats = 8
+	config.Variant = VariantTripleDraw
+	if err := engine.UpdateTableConfig(table.ID, config); err == nil {
+		t.Error("Triple draw should not allow 8 seats")
+	}
+}
+
+// TestButtonMovesSeatBySeat verifica que button y blinds avancen por asiento, saltando los vacíos
+func TestButtonMovesSeatBySeat(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "button_moves", seatConfig(9))
+	mustSeat(t, engine, table, 1, 4, 7)
+
+	engine.startHand(table)
+	assertButton(t, table, 1, 4, 7)
+	if table.CurrentPlayer != 1 {
+		t.Errorf("Seat 1 should act first preflop, got %d", table.CurrentPlayer)
+	}
+	if table.Players[4].CurrentBet != 10 || table.Players[7].CurrentBet != 20 {
+		t.Errorf("Blinds not posted by seats 4 and 7")
+	}
+
+	engine.startHand(table)
+	assertButton(t, table, 4, 7, 1)
+	if table.CurrentPlayer != 4 {
+		t.Errorf("Seat 4 should act first preflop, got %d", table.CurrentPlayer)
+	}
+
+	engine.startHand(table)
+	assertButton(t, table, 7, 1, 4)
+}
+
+// TestStudButtonSkipsEmptySeats verifica que en stud el button de referencia salte los asientos vacíos
+func TestStudButtonSkipsEmptySeats(t *testing.T) {
+	engine := NewPokerEngine()
+	config := studConfig
+	config.MaxSeats = 8
+	table := newTestTable(t, engine, "stud_button_seats", config)
+	mustSeat(t, engine, table, 1, 4, 6)
+
+	for _, want := range []int{1, 4, 6, 1} {
+		engine.startHand(table)
+		if table.DealerPosition != want {
+			t.Fatalf("Hand %d: expected the button on seat %d, got %d", table.HandNumber, want, table.DealerPosition)
+		}
+	}
+}
+
+// TestDeadButton verifica que el button quede muerto cuando el small blind anterior se va
+func TestDeadButton(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "dead_button", seatConfig(10))
+	mustSeat(t, engine, table, 0, 2, 4, 6)
+
+	engine.startHand(table)
+	assertButton(t, table, 2, 4, 6)
+
+	// El small blind pierde todas sus fichas
+	table.Players[4].Stack = 0
+	engine.startHand(table)
+
+	// El big blind avanza igual; el button queda en el asiento vacío del small blind anterior
+	assertButton(t, table, 4, 6, 0)
+	if table.Players[4].IsActive {
+		t.Error("Busted player should not be dealt in")
+	}
+	if table.Pot != 30 || table.Players[6].CurrentBet != 10 || table.Players[0].CurrentBet != 20 {
+		t.Errorf("Expected blinds from seats 6 and 0, got pot %d", table.Pot)
+	}
+	if table.CurrentPlayer != 2 {
+		t.Errorf("Seat 2 should act first preflop, got %d", table.CurrentPlayer)
+	}
+
+	engine.startHand(table)
+	assertButton(t, table, 6, 0, 2)
+}
+
+// TestDeadSmallBlind verifica que no se cobre small blind si el big blind anterior se va
+func TestDeadSmallBlind(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "dead_small_blind", seatConfig(10))
+	mustSeat(t, engine, table, 0, 2, 4, 6)
+
+	engine.startHand(table)
+	assertButton(t, table, 2, 4, 6)
+
+	table.Players[6].Stack = 0
+	engine.startHand(table)
+
+	// Nadie recibe el big blind dos veces: el small blind queda muerto en el asiento 6
+	assertButton(t, table, 4, 6, 0)
+	if table.Pot != 20 {
+		t.Errorf("Only the big blind should be posted, got pot %d", table.Pot)
+	}
+	if table.CurrentPlayer != 2 {
+		t.Errorf("Seat 2 should act first preflop, got %d", table.CurrentPlayer)
+	}
+}
+
+// TestHeadsUpSeats verifica que heads-up el button ponga el small blind y actúe primero
+func TestHeadsUpSeats(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "heads_up_seats", seatConfig(10))
+	mustSeat(t, engine, table, 3, 8)
+
+	engine.startHand(table)
+	assertButton(t, table, 3, 3, 8)
+	if table.CurrentPlayer != 3 || table.Players[3].CurrentBet != 10 {
+		t.Errorf("Button should post the small blind and act first, got player %d", table.CurrentPlayer)
+	}
+
+	engine.startHand(table)
+	assertButton(t, table, 8, 8, 3)
+	if table.CurrentPlayer != 8 {
+		t.Errorf("Button should act first preflop, got %d", table.CurrentPlayer)
+	}
+}
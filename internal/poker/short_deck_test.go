@@ -0,0 +1,185 @@
+package poker
+
+import (
+	"testing"
+)
+
+// TestShortDeckDeck verifica que el deck de short-deck tenga 36 cartas únicas del 6 al As
+func TestShortDeckDeck(t *testing.T) {
+	engine := NewPokerEngine()
+	deck := engine.createShuffledDeckFor(VariantShortDeck)
+
+	if len(deck) != 36 {
+		t.Fatalf("Expected 36 cards, got %d", len(deck))
+	}
+
+	seen := make(map[Card]bool)
+	for _, card := range deck {
+		if CardValue(card.Rank) < 6 {
+			t.Errorf("Short deck should not contain %s of %s", card.Rank, card.Suit)
+		}
+		if seen[card] {
+			t.Errorf("Duplicate card %+v", card)
+		}
+		seen[card] = true
+	}
+}
+
+// TestShortDeckEvaluation verifica las reglas de ranking de short-deck
+func TestShortDeckEvaluation(t *testing.T) {
+	tests := []struct {
+		name           string
+		playerCards    []Card
+		communityCards []Card
+		expectedRank   HandRank
+		expectedValue  int
+	}{
+		{
+			name:        "A-6-7-8-9 es escalera",
+			playerCards: []Card{{Suit: "hearts", Rank: "A"}, {Suit: "clubs", Rank: "6"}},
+			communityCards: []Card{
+				{Suit: "diamonds", Rank: "7"}, {Suit: "spades", Rank: "8"}, {Suit: "hearts", Rank: "9"},
+				{Suit: "clubs", Rank: "K"}, {Suit: "diamonds", Rank: "Q"},
+			},
+			expectedRank:  Straight,
+			expectedValue: 500009,
+		},
+		{
+			name:        "A-6-7-8-9 del mismo palo es escalera de color",
+			playerCards: []Card{{Suit: "spades", Rank: "A"}, {Suit: "spades", Rank: "6"}},
+			communityCards: []Card{
+				{Suit: "spades", Rank: "7"}, {Suit: "spades", Rank: "8"}, {Suit: "spades", Rank: "9"},
+				{Suit: "clubs", Rank: "K"}, {Suit: "diamonds", Rank: "J"},
+			},
+			expectedRank:  StraightFlush,
+			expectedValue: 900009,
+		},
+		{
+			name:        "Escalera normal sigue funcionando",
+			playerCards: []Card{{Suit: "hearts", Rank: "10"}, {Suit: "clubs", Rank: "J"}},
+			communityCards: []Card{
+				{Suit: "diamonds", Rank: "Q"}, {Suit: "spades", Rank: "K"}, {Suit: "hearts", Rank: "9"},
+				{Suit: "clubs", Rank: "6"}, {Suit: "diamonds", Rank: "6"},
+			},
+			expectedRank:  Straight,
+			expectedValue: 500013,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EvaluateShortDeckHand(tt.playerCards, tt.communityCards)
+			if result.Rank != tt.expectedRank {
+				t.Errorf("Expected %v, got %v (%s)", tt.expectedRank, result.Rank, result.RankName)
+			}
+			if result.Value != tt.expectedValue {
+				t.Errorf("Expected value %d, got %d", tt.expectedValue, result.Value)
+			}
+		})
+	}
+}
+
+// TestShortDeckFlushBeatsFullHouse verifica que el flush le gane al full house en short-deck
+func TestShortDeckFlushBeatsFullHouse(t *testing.T) {
+	board := []Card{
+		{Suit: "hearts", Rank: "K"}, {Suit: "hearts", Rank: "10"}, {Suit: "hearts", Rank: "7"},
+		{Suit: "clubs", Rank: "K"}, {Suit: "spades", Rank: "6"},
+	}
+	flush := []Card{{Suit: "hearts", Rank: "A"}, {Suit: "hearts", Rank: "8"}}
+	fullHouse := []Card{{Suit: "diamonds", Rank: "K"}, {Suit: "diamonds", Rank: "6"}}
+
+	flushEval := EvaluateShortDeckHand(flush, board)
+	fullHouseEval := EvaluateShortDeckHand(fullHouse, board)
+
+	if flushEval.Rank != Flush || fullHouseEval.Rank != FullHouse {
+		t.Fatalf("Unexpected ranks: %s vs %s", flushEval.RankName, fullHouseEval.RankName)
+	}
+	if CompareHands(flushEval, fullHouseEval) != 1 {
+		t.Errorf("Flush (%d) should beat full house (%d) in short deck", flushEval.Value, fullHouseEval.Value)
+	}
+
+	// En Hold'em estándar el orden es el contrario
+	if CompareHands(EvaluateHand(flush, board), EvaluateHand(fullHouse, board)) != -1 {
+		t.Errorf("Full house should beat flush in standard hold'em")
+	}
+
+	// El full house sigue ganándole a la escalera
+	straight := EvaluateShortDeckHand(
+		[]Card{{Suit: "clubs", Rank: "A"}, {Suit: "diamonds", Rank: "Q"}},
+		[]Card{{Suit: "hearts", Rank: "K"}, {Suit: "spades", Rank: "J"}, {Suit: "clubs", Rank: "10"}, {Suit: "clubs", Rank: "7"}, {Suit: "spades", Rank: "6"}},
+	)
+	if CompareHands(fullHouseEval, straight) != 1 {
+		t.Errorf("Full house (%d) should beat straight (%d)", fullHouseEval.Value, straight.Value)
+	}
+}
+
+// TestShortDeckKickers verifica que los colores y los kickers desempaten con todas las cartas
+func TestShortDeckKickers(t *testing.T) {
+	tests := []struct {
+		name          string
+		better, worse []Card
+		board         []Card
+	}{
+		{
+			name:   "colores con la misma carta alta",
+			better: []Card{{Suit: "spades", Rank: "K"}, {Suit: "spades", Rank: "9"}},
+			worse:  []Card{{Suit: "spades", Rank: "Q"}, {Suit: "spades", Rank: "8"}},
+			board: []Card{
+				{Suit: "spades", Rank: "A"}, {Suit: "spades", Rank: "10"}, {Suit: "spades", Rank: "7"},
+				{Suit: "diamonds", Rank: "J"}, {Suit: "clubs", Rank: "6"},
+			},
+		},
+		{
+			name:   "colores que difieren en la cuarta carta",
+			better: []Card{{Suit: "hearts", Rank: "K"}, {Suit: "hearts", Rank: "9"}},
+			worse:  []Card{{Suit: "hearts", Rank: "K"}, {Suit: "hearts", Rank: "8"}},
+			board: []Card{
+				{Suit: "hearts", Rank: "A"}, {Suit: "hearts", Rank: "10"}, {Suit: "hearts", Rank: "6"},
+				{Suit: "diamonds", Rank: "J"}, {Suit: "clubs", Rank: "Q"},
+			},
+		},
+		{
+			name:   "trío con kicker",
+			better: []Card{{Suit: "clubs", Rank: "A"}, {Suit: "diamonds", Rank: "7"}},
+			worse:  []Card{{Suit: "clubs", Rank: "Q"}, {Suit: "diamonds", Rank: "8"}},
+			board: []Card{
+				{Suit: "hearts", Rank: "9"}, {Suit: "spades", Rank: "9"}, {Suit: "diamonds", Rank: "9"},
+				{Suit: "clubs", Rank: "K"}, {Suit: "hearts", Rank: "6"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := EvaluateShortDeckHand(tt.better, tt.board)
+			worse := EvaluateShortDeckHand(tt.worse, tt.board)
+			if better.Rank != worse.Rank {
+				t.Fatalf("Expected the same category, got %s vs %s", better.RankName, worse.RankName)
+			}
+			if CompareHands(better, worse) != 1 {
+				t.Errorf("%s (%d) should beat %s (%d)", better.RankName, better.Value, worse.RankName, worse.Value)
+			}
+		})
+	}
+}
+
+// TestShortDeckTableDealsFromShortDeck verifica que la mesa reparta desde el deck de 36 cartas
+func TestShortDeckTableDealsFromShortDeck(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "short_deck_table", TableConfig{
+		Variant: VariantShortDeck, SmallBlind: 10, BigBlind: 20,
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+	}, "alice", "bob")
+	startTestGame(t, engine, table)
+
+	if len(table.Deck) != 32 {
+		t.Errorf("Expected 32 cards left (36 - 4 dealt), got %d", len(table.Deck))
+	}
+	for _, player := range table.Players {
+		for _, card := range player.Cards {
+			if CardValue(card.Rank) < 6 {
+				t.Errorf("Dealt card %s of %s is not part of the short deck", card.Rank, card.Suit)
+			}
+		}
+	}
+}
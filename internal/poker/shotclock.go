@@ -0,0 +1,182 @@
+package poker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ====== SHOT CLOCK Y TIME BANK ======
+//
+// Cada vez que le toca actuar a un jugador arranca el shot clock de la mesa.
+// Si no actúa antes de ActionDeadline el engine pasa por él (check) o, si
+// hay una apuesta que igualar, se retira; en las rondas de descarte queda
+// servido. Cada jugador tiene un time bank que puede gastar para extender el
+// plazo; lo que no usa se conserva para las próximas decisiones.
+
+// defaultTimeBank es el time bank de las mesas creadas con CreateTable. El shot
+// clock viene apagado: cada mesa lo activa con ActionTimeout.
+const defaultTimeBank = 60 * time.Second
+
+// SetUpdateHandler registra una función que se llama cuando el engine cambia una
+// mesa por su cuenta (shot clock vencido, auto-restart), para avisar a los clientes
+func (pe *PokerEngine) SetUpdateHandler(handler func(tableID string)) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.onUpdate = handler
+}
+
+// notifyUpdate avisa del cambio de la mesa (llamar sin el lock tomado)
+func (pe *PokerEngine) notifyUpdate(tableID string) {
+	pe.mu.RLock()
+	handler := pe.onUpdate
+	pe.mu.RUnlock()
+
+	if handler != nil {
+		handler(tableID)
+	}
+}
+
+// UseTimeBank extiende el plazo del jugador que tiene el turno con todo su time bank
+func (pe *PokerEngine) UseTimeBank(tableID, playerID string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	playerIndex := findPlayerSeat(table, playerID)
+	if playerIndex == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if table.ActionTimeout <= 0 {
+		return nil, fmt.Errorf("la mesa no tiene shot clock")
+	}
+	if table.ActionSeat != playerIndex {
+		return nil, fmt.Errorf("not your turn")
+	}
+	if table.TimeBankActive {
+		return nil, fmt.Errorf("el time bank ya está en uso")
+	}
+
+	player := &table.Players[playerIndex]
+	if player.TimeBank <= 0 {
+		return nil, fmt.Errorf("no te queda time bank")
+	}
+
+	table.TimeBankActive = true
+	table.TimeBankStart = table.ActionDeadline
+	table.ActionDeadline = table.ActionDeadline.Add(player.TimeBank)
+	pe.scheduleShotClock(table)
+
+	return table, nil
+}
+
+// resetShotClock cobra el time bank usado y arranca el plazo del jugador que tiene el turno
+func (pe *PokerEngine) resetShotClock(table *PokerTable) {
+	pe.chargeTimeBank(table)
+	pe.stopShotClock(table)
+
+	// Los jugadores sentados afuera no esperan el plazo
+	pe.actForSittingOut(table)
+
+	if table.ActionTimeout <= 0 || !awaitingAction(table) {
+		return
+	}
+
+	table.ActionSeat = table.CurrentPlayer
+	table.ActionDeadline = time.Now().Add(table.ActionTimeout)
+	pe.scheduleShotClock(table)
+}
+
+// chargeTimeBank descuenta el time bank que gastó el jugador que tenía el turno
+func (pe *PokerEngine) chargeTimeBank(table *PokerTable) {
+	if table.TimeBankActive && isValidSeat(table, table.ActionSeat) {
+		player := &table.Players[table.ActionSeat]
+		if used := time.Since(table.TimeBankStart); used > 0 {
+			player.TimeBank -= used
+		}
+		if player.TimeBank < 0 {
+			player.TimeBank = 0
+		}
+	}
+	table.TimeBankActive = false
+	table.TimeBankStart = time.Time{}
+}
+
+// stopShotClock cancela el plazo en curso de la mesa
+func (pe *PokerEngine) stopShotClock(table *PokerTable) {
+	if timer, ok := pe.shotClocks[table.ID]; ok {
+		timer.Stop()
+		delete(pe.shotClocks, table.ID)
+	}
+	table.ActionSeat = -1
+	table.ActionDeadline = time.Time{}
+}
+
+// scheduleShotClock programa el vencimiento del plazo actual
+func (pe *PokerEngine) scheduleShotClock(table *PokerTable) {
+	if timer, ok := pe.shotClocks[table.ID]; ok {
+		timer.Stop()
+	}
+
+	tableID, hand, seat, deadline := table.ID, table.HandNumber, table.ActionSeat, table.ActionDeadline
+	pe.shotClocks[tableID] = time.AfterFunc(time.Until(deadline), func() {
+		if pe.expireShotClock(tableID, hand, seat, deadline) {
+			pe.notifyUpdate(tableID)
+		}
+	})
+}
+
+// expireShotClock actúa por el jugador si el plazo sigue vigente.
+// Retorna true si la mesa cambió.
+func (pe *PokerEngine) expireShotClock(tableID string, hand, seat int, deadline time.Time) bool {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists || table.HandNumber != hand || table.ActionSeat != seat || !table.ActionDeadline.Equal(deadline) {
+		return false // El jugador actuó o el plazo se extendió
+	}
+	delete(pe.shotClocks, tableID)
+
+	pe.autoAct(table)
+	pe.resetShotClock(table)
+	return true
+}
+
+// autoAct actúa por el jugador que tiene el turno: pasa si puede, se retira si
+// hay una apuesta que igualar (o si dejó la mesa) y queda servido en las rondas de descarte
+func (pe *PokerEngine) autoAct(table *PokerTable) {
+	player := table.Players[table.CurrentPlayer]
+	var err error
+	switch {
+	case isDrawPhase(table.Phase):
+		_, err = pe.playerDraw(table.ID, player.ID, nil)
+	case player.Leaving:
+		_, err = pe.playerAction(table.ID, player.ID, "fold", 0)
+	case table.CurrentBet > player.CurrentBet:
+		_, err = pe.playerAction(table.ID, player.ID, "fold", 0)
+	default:
+		_, err = pe.playerAction(table.ID, player.ID, "check", 0)
+	}
+	if err != nil {
+		// Si la acción automática no es válida, el jugador se retira
+		pe.playerAction(table.ID, player.ID, "fold", 0)
+	}
+}
+
+// awaitingAction indica si la mano espera la decisión del jugador que tiene el turno
+func awaitingAction(table *PokerTable) bool {
+	switch table.Phase {
+	case "lobby", "waiting", "showdown", PhaseRunItTwice:
+		return false
+	}
+	if !isValidSeat(table, table.CurrentPlayer) {
+		return false
+	}
+	player := table.Players[table.CurrentPlayer]
+	return !isEmptySeat(player) && player.IsActive && !player.HasFolded
+}
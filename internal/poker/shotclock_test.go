@@ -0,0 +1,155 @@
+package poker
+
+import (
+	"testing"
+	"time"
+)
+
+// shotClockConfig es la configuración de las mesas heads-up con shot clock y time bank
+func shotClockConfig(timeout, timeBank time.Duration) TableConfig {
+	return TableConfig{
+		SmallBlind: 10, BigBlind: 20, BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+		IsCashGame: true, ActionTimeout: timeout, TimeBank: timeBank,
+	}
+}
+
+// expireNow vence el plazo en curso como lo haría el timer
+func expireNow(t *testing.T, engine *PokerEngine, table *PokerTable) {
+	t.Helper()
+	if !engine.expireShotClock(table.ID, table.HandNumber, table.ActionSeat, table.ActionDeadline) {
+		t.Fatal("Expected the shot clock to act for the player")
+	}
+}
+
+// TestShotClockAutoCheckAndFold verifica el check automático sin apuesta y el fold automático con apuesta
+func TestShotClockAutoCheckAndFold(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "shot_clock_auto", shotClockConfig(time.Hour, 0), "seat0", "seat1")
+
+	engine.startHand(table)
+	if table.ActionSeat != table.CurrentPlayer || time.Until(table.ActionDeadline) < 59*time.Minute {
+		t.Fatalf("Expected the clock to run for seat %d, got seat %d until %v",
+			table.CurrentPlayer, table.ActionSeat, table.ActionDeadline)
+	}
+
+	// El button completa y el big blind no actúa: check automático
+	mustAct(t, engine, table, "call", 0)
+	bigBlind := table.CurrentPlayer
+	expireNow(t, engine, table)
+	if table.Phase != "flop" || table.Players[bigBlind].HasFolded {
+		t.Fatalf("Expected an automatic check to the flop, got phase %s", table.Phase)
+	}
+
+	// Un plazo viejo ya no actúa
+	staleDeadline := table.ActionDeadline.Add(-time.Second)
+	if engine.expireShotClock(table.ID, table.HandNumber, table.ActionSeat, staleDeadline) {
+		t.Error("A stale deadline should not act")
+	}
+
+	// Con una apuesta que igualar el jugador se retira
+	mustAct(t, engine, table, "raise", 40)
+	idle := table.CurrentPlayer
+	expireNow(t, engine, table)
+	if !table.Players[idle].HasFolded || table.Phase != "showdown" {
+		t.Errorf("Expected an automatic fold ending the hand, got phase %s", table.Phase)
+	}
+	if table.ActionSeat != -1 || !table.ActionDeadline.IsZero() {
+		t.Error("The clock should stop when the hand ends")
+	}
+
+	assertContains(t, table.HandHistories[0].Format(""), "seat0: checks", "seat1: folds")
+}
+
+// TestShotClockTimerFires verifica que el timer real actúe y avise del cambio
+func TestShotClockTimerFires(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "shot_clock_timer", shotClockConfig(20*time.Millisecond, 0), "seat0", "seat1")
+	table.AutoRestart = false
+
+	updates := make(chan string, 1)
+	engine.SetUpdateHandler(func(tableID string) { updates <- tableID })
+
+	engine.StartHand(table)
+
+	select {
+	case tableID := <-updates:
+		if tableID != table.ID {
+			t.Errorf("Expected an update for %s, got %s", table.ID, tableID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("The shot clock never fired")
+	}
+
+	// El small blind no completó: se retira y el big blind gana los blinds
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	if table.Phase != "showdown" || len(table.HandHistories) != 1 {
+		t.Errorf("Expected the idle player to fold, got phase %s", table.Phase)
+	}
+}
+
+// TestShotClockOffByDefault verifica que las mesas sin ActionTimeout no tengan shot clock
+func TestShotClockOffByDefault(t *testing.T) {
+	engine := NewPokerEngine()
+	table := engine.CreateTable("shot_clock_default")
+	for _, id := range []string{"seat0", "seat1"} {
+		if _, err := engine.AddPlayer(table.ID, id, id); err != nil {
+			t.Fatalf("Error adding %s: %v", id, err)
+		}
+	}
+
+	engine.StartHand(table)
+	if table.ActionTimeout != 0 || table.ActionSeat != -1 || !table.ActionDeadline.IsZero() {
+		t.Errorf("Expected no shot clock, got timeout %v seat %d", table.ActionTimeout, table.ActionSeat)
+	}
+	if _, scheduled := engine.shotClocks[table.ID]; scheduled {
+		t.Error("No timer should be scheduled")
+	}
+}
+
+// TestUseTimeBank verifica que el time bank extienda el plazo y se descuente lo usado
+func TestUseTimeBank(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "shot_clock_time_bank", shotClockConfig(time.Hour, 30*time.Second), "seat0", "seat1")
+
+	engine.startHand(table)
+	current := table.Players[table.CurrentPlayer]
+	other := table.Players[(table.CurrentPlayer+1)%2]
+	deadline := table.ActionDeadline
+
+	if _, err := engine.UseTimeBank(table.ID, other.ID); err == nil {
+		t.Error("Only the player to act can use the time bank")
+	}
+	if _, err := engine.UseTimeBank(table.ID, current.ID); err != nil {
+		t.Fatalf("Unexpected error using the time bank: %v", err)
+	}
+	if !table.TimeBankActive || !table.ActionDeadline.Equal(deadline.Add(30*time.Second)) {
+		t.Fatalf("Expected the deadline extended by 30s, got %v", table.ActionDeadline.Sub(deadline))
+	}
+	if _, err := engine.UseTimeBank(table.ID, current.ID); err == nil {
+		t.Error("The time bank cannot be used twice in the same decision")
+	}
+
+	// Gastó 10 segundos del time bank antes de actuar
+	seat := table.CurrentPlayer
+	table.TimeBankStart = time.Now().Add(-10 * time.Second)
+	mustAct(t, engine, table, "call", 0)
+
+	remaining := table.Players[seat].TimeBank
+	if remaining > 20*time.Second || remaining < 19*time.Second {
+		t.Errorf("Expected about 20s left in the time bank, got %v", remaining)
+	}
+	if table.TimeBankActive {
+		t.Error("The time bank should stop when the player acts")
+	}
+
+	noClock := newTestTable(t, engine, "shot_clock_disabled", seatConfig(6))
+	mustSeat(t, engine, noClock, 0, 1)
+	engine.startHand(noClock)
+	if noClock.ActionSeat != -1 || !noClock.ActionDeadline.IsZero() {
+		t.Error("Tables without a timeout should not run a clock")
+	}
+	if _, err := engine.UseTimeBank(noClock.ID, noClock.Players[noClock.CurrentPlayer].ID); err == nil {
+		t.Error("Time bank requires a shot clock")
+	}
+}
@@ -0,0 +1,160 @@
+package poker
+
+import "fmt"
+
+// ====== SHOWDOWN: MOSTRAR O TIRAR LAS CARTAS ======
+//
+// En el showdown muestra primero el último agresor de la ronda final (o, si nadie
+// apostó, el primer jugador a la izquierda del button) y después el resto en
+// sentido horario. Quien ya no puede ganar ningún pot en el que participa tira
+// sus cartas sin mostrarlas, salvo que pida mostrarlas. Si algún jugador quedó
+// all-in se muestran todas las manos.
+
+// revealShowdown decide qué manos se muestran al terminar la mano y en qué orden
+func (pe *PokerEngine) revealShowdown(table *PokerTable) {
+	order := showdownOrder(table)
+	table.ShowdownOrder = order
+
+	// Sin showdown (todos se retiraron) nadie está obligado a mostrar
+	if len(order) <= 1 {
+		return
+	}
+
+	allIn := false
+	for _, seat := range order {
+		if table.Players[seat].IsAllIn || table.Players[seat].Stack == 0 {
+			allIn = true
+		}
+	}
+
+	boards := showdownBoards(table)
+	valuesByBoard := make([]map[int]int, len(boards))
+	for run, board := range boards {
+		valuesByBoard[run] = pe.showdownValues(table, board)
+	}
+
+	// Mejor valor mostrado hasta ahora en cada pot y runout (-1 si nadie mostró)
+	best := make([][]int, len(table.SidePots))
+	for i := range best {
+		best[i] = make([]int, len(boards))
+		for run := range best[i] {
+			best[i][run] = -1
+		}
+	}
+
+	for _, seat := range order {
+		player := &table.Players[seat]
+		if !allIn && !canStillWin(table, seat, valuesByBoard, best) {
+			player.HasMucked = true
+			continue
+		}
+
+		player.CardsRevealed = true
+		for potIndex, sidePot := range table.SidePots {
+			if !containsSeat(sidePot.EligiblePlayers, seat) {
+				continue
+			}
+			for run := range boards {
+				if value, ok := valuesByBoard[run][seat]; ok && value > best[potIndex][run] {
+					best[potIndex][run] = value
+				}
+			}
+		}
+	}
+}
+
+// canStillWin indica si la mano del jugador gana o empata algún pot en el que participa
+// contra las manos ya mostradas
+func canStillWin(table *PokerTable, seat int, valuesByBoard []map[int]int, best [][]int) bool {
+	for potIndex, sidePot := range table.SidePots {
+		if !containsSeat(sidePot.EligiblePlayers, seat) {
+			continue
+		}
+		for run := range valuesByBoard {
+			if value, ok := valuesByBoard[run][seat]; ok && value >= best[potIndex][run] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// showdownOrder retorna los asientos que llegaron al showdown en el orden en que muestran
+func showdownOrder(table *PokerTable) []int {
+	seats := len(table.Players)
+	if seats == 0 {
+		return nil
+	}
+
+	inHand := func(seat int) bool {
+		player := table.Players[seat]
+		return player.IsActive && !player.HasFolded
+	}
+
+	// Empieza el último agresor; si nadie apostó, el primero a la izquierda del button
+	start := -1
+	if table.LastAggressor >= 0 && table.LastAggressor < seats && inHand(table.LastAggressor) {
+		start = table.LastAggressor
+	} else {
+		for offset := 1; offset <= seats; offset++ {
+			seat := ((table.DealerPosition+offset)%seats + seats) % seats
+			if inHand(seat) {
+				start = seat
+				break
+			}
+		}
+	}
+	if start == -1 {
+		return nil
+	}
+
+	order := make([]int, 0, seats)
+	for offset := 0; offset < seats; offset++ {
+		seat := (start + offset) % seats
+		if inHand(seat) {
+			order = append(order, seat)
+		}
+	}
+	return order
+}
+
+// containsSeat indica si el asiento está en la lista
+func containsSeat(seats []int, seat int) bool {
+	for _, s := range seats {
+		if s == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// ShowCards muestra voluntariamente las cartas del jugador al terminar la mano
+// (una mano tirada en el showdown, o la del ganador de un pot sin disputa)
+func (pe *PokerEngine) ShowCards(tableID, playerID string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	if table.Phase != "showdown" {
+		return nil, fmt.Errorf("solo se pueden mostrar las cartas al terminar la mano")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	player := &table.Players[seat]
+	if player.HasFolded || len(player.Cards) == 0 {
+		return nil, fmt.Errorf("no tienes cartas para mostrar")
+	}
+
+	player.CardsRevealed = true
+	player.HasMucked = false
+	pe.recordShownCards(table, seat)
+	return table, nil
+}
@@ -0,0 +1,190 @@
+package poker
+
+import (
+	"testing"
+)
+
+// dealShowdown lleva una mesa de alice, bob y carol al river con tres manos:
+// alice (0) pareja de K, bob (1) pareja de 2 y carol (2) pareja de A
+func dealShowdown(table *PokerTable, dealer, lastAggressor int) {
+	table.AutoRestart = false
+
+	hands := [][]Card{
+		{{"hearts", "K"}, {"clubs", "K"}},
+		{{"hearts", "7"}, {"diamonds", "2"}},
+		{{"hearts", "A"}, {"clubs", "A"}},
+	}
+	for i, cards := range hands {
+		player := &table.Players[i]
+		player.IsActive = true
+		player.Stack = 500
+		player.TotalBet = 100
+		player.Cards = cards
+	}
+	table.CommunityCards = []Card{{"clubs", "2"}, {"diamonds", "5"}, {"hearts", "9"}, {"spades", "J"}, {"spades", "4"}}
+	table.Phase = "river"
+	table.DealerPosition = dealer
+	table.LastAggressor = lastAggressor
+}
+
+// assertShowdownOrder verifica el orden en que se muestran las manos
+func assertShowdownOrder(t *testing.T, table *PokerTable, expected []int) {
+	t.Helper()
+	if len(table.ShowdownOrder) != len(expected) {
+		t.Fatalf("Expected showdown order %v, got %v", expected, table.ShowdownOrder)
+	}
+	for i := range expected {
+		if table.ShowdownOrder[i] != expected[i] {
+			t.Fatalf("Expected showdown order %v, got %v", expected, table.ShowdownOrder)
+		}
+	}
+}
+
+// TestShowdownAggressorShowsFirst verifica que muestre primero el último agresor y que se tiren las manos perdedoras
+func TestShowdownAggressorShowsFirst(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "showdown_aggressor", seatConfig(3), "alice", "bob", "carol")
+	dealShowdown(table, 0, 1)
+
+	engine.completeHand(table)
+
+	// bob apostó el river: muestra él, después carol y alice en sentido horario
+	assertShowdownOrder(t, table, []int{1, 2, 0})
+	if !table.Players[1].CardsRevealed || !table.Players[2].CardsRevealed {
+		t.Error("Aggressor and the better hand after him should be shown")
+	}
+	if table.Players[0].CardsRevealed || !table.Players[0].HasMucked {
+		t.Error("Alice cannot beat carol and should muck")
+	}
+
+	view, err := engine.GetTableForPlayer(table.ID, "bob")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if view.Players[0].Cards[0].Rank != "?" {
+		t.Error("Mucked hand should stay hidden")
+	}
+	if view.Players[2].Cards[0].Rank != "A" {
+		t.Error("Shown hand should be visible to every player")
+	}
+}
+
+// TestShowdownNoAggressor verifica que sin apuestas en el river empiece el primero a la izquierda del button
+func TestShowdownNoAggressor(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "showdown_no_aggressor", seatConfig(3), "alice", "bob", "carol")
+	dealShowdown(table, 2, -1)
+
+	engine.completeHand(table)
+
+	assertShowdownOrder(t, table, []int{0, 1, 2})
+	if !table.Players[0].CardsRevealed || !table.Players[2].CardsRevealed {
+		t.Error("Alice shows first and carol beats her")
+	}
+	if !table.Players[1].HasMucked {
+		t.Error("Bob cannot beat alice and should muck")
+	}
+}
+
+// TestShowdownAllInRevealsAll verifica que con un jugador all-in se muestren todas las manos
+func TestShowdownAllInRevealsAll(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "showdown_all_in", seatConfig(3), "alice", "bob", "carol")
+	dealShowdown(table, 0, 1)
+	table.Players[2].IsAllIn = true
+	table.Players[2].Stack = 0
+
+	engine.completeHand(table)
+
+	for _, player := range table.Players {
+		if !player.CardsRevealed || player.HasMucked {
+			t.Errorf("%s should be shown when a player is all-in", player.Name)
+		}
+	}
+}
+
+// TestShowCards verifica que un jugador pueda mostrar voluntariamente al terminar la mano
+func TestShowCards(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "showdown_show_cards", seatConfig(3), "alice", "bob", "carol")
+	dealShowdown(table, 0, 1)
+
+	if _, err := engine.ShowCards(table.ID, "alice"); err == nil {
+		t.Error("Showing cards before the showdown should be rejected")
+	}
+
+	engine.completeHand(table)
+
+	// alice tiró su mano pero puede mostrarla
+	if _, err := engine.ShowCards(table.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error showing cards: %v", err)
+	}
+	if !table.Players[0].CardsRevealed || table.Players[0].HasMucked {
+		t.Error("Alice should be shown after asking")
+	}
+
+	view, _ := engine.GetTableForPlayer(table.ID, "bob")
+	if view.Players[0].Cards[0].Rank != "K" {
+		t.Error("Shown hand should be visible to every player")
+	}
+
+	if _, err := engine.ShowCards(table.ID, "dave"); err == nil {
+		t.Error("Unknown player should be rejected")
+	}
+}
+
+// TestShowCardsUncontested verifica que el ganador sin disputa no muestre salvo que lo pida
+func TestShowCardsUncontested(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "showdown_uncontested", seatConfig(10))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	// Button 1, small blind 2, big blind 0: UTG (1) y el small blind se retiran
+	engine.startHand(table)
+	mustAct(t, engine, table, "fold", 0)
+	mustAct(t, engine, table, "fold", 0)
+
+	winner := table.Players[0]
+	if winner.CardsRevealed || winner.HasMucked {
+		t.Error("Uncontested winner should not be forced to show or muck")
+	}
+	view, _ := engine.GetTableForPlayer(table.ID, "seat1")
+	if view.Players[0].Cards[0].Rank != "?" {
+		t.Error("Uncontested winner's hand should stay hidden")
+	}
+
+	if _, err := engine.ShowCards(table.ID, "seat1"); err == nil {
+		t.Error("A folded player should not be able to show")
+	}
+	if _, err := engine.ShowCards(table.ID, winner.ID); err != nil {
+		t.Fatalf("Unexpected error showing cards: %v", err)
+	}
+	view, _ = engine.GetTableForPlayer(table.ID, "seat1")
+	if view.Players[0].Cards[0].Rank == "?" {
+		t.Error("Winner's hand should be visible after showing")
+	}
+
+	// La próxima mano vuelve a ocultar las cartas
+	engine.startHand(table)
+	if table.Players[0].CardsRevealed || table.ShowdownOrder != nil {
+		t.Error("Showdown state should be cleared when a new hand starts")
+	}
+}
+
+// TestLastAggressorTracked verifica que se recuerde quién subió en la ronda que terminó
+func TestLastAggressorTracked(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "showdown_last_aggressor", seatConfig(10))
+	mustSeat(t, engine, table, 0, 1)
+	table.AutoRestart = false
+
+	engine.startHand(table)
+	raiser := table.CurrentPlayer
+	mustAct(t, engine, table, "raise", 40)
+	mustAct(t, engine, table, "call", 0)
+
+	if table.Phase != "flop" || table.LastAggressor != raiser {
+		t.Errorf("Expected last aggressor %d on the flop, got %d (phase %s)", raiser, table.LastAggressor, table.Phase)
+	}
+}
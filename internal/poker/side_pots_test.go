@@ -125,4 +125,34 @@ func TestGetSortedBetLevels(t *testing.T) {
 			t.Errorf("Expected bet level %d at index %d, got %d", expected[i], i, level)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// TestSidePotsIncludePreviousStreetsAndFolds verifica que el pot conserve las
+// fichas de calles anteriores y de jugadores que foldearon
+func TestSidePotsIncludePreviousStreetsAndFolds(t *testing.T) {
+	engine := NewPokerEngine()
+	table := engine.CreateTable("test_side_pots_streets")
+
+	table.Players = []PokerPlayer{
+		{ID: "alice", Name: "Alice", IsActive: true, TotalBet: 100, CurrentBet: 50, IsAllIn: true}, // 150 en total
+		{ID: "bob", Name: "Bob", IsActive: true, TotalBet: 100, CurrentBet: 200},                   // 300 en total
+		{ID: "carol", Name: "Carol", IsActive: true, TotalBet: 100, CurrentBet: 200},               // 300 en total
+		{ID: "dave", Name: "Dave", IsActive: false, HasFolded: true, TotalBet: 100},                // foldeó en el flop
+	}
+
+	engine.createSidePots(table)
+
+	if table.Pot != 850 {
+		t.Fatalf("Expected total pot 850, got %d", table.Pot)
+	}
+	if len(table.SidePots) != 2 {
+		t.Fatalf("Expected 2 side pots, got %d", len(table.SidePots))
+	}
+	// Main pot: 150 de Alice, Bob y Carol + 100 muertos de Dave
+	if table.SidePots[0].Amount != 550 || len(table.SidePots[0].EligiblePlayers) != 3 {
+		t.Errorf("Unexpected main pot: %+v", table.SidePots[0])
+	}
+	if table.SidePots[1].Amount != 300 || len(table.SidePots[1].EligiblePlayers) != 2 {
+		t.Errorf("Unexpected side pot: %+v", table.SidePots[1])
+	}
+}
@@ -0,0 +1,158 @@
+package poker
+
+import (
+	"fmt"
+	"time"
+)
+
+// ====== SIT OUT Y BLINDS PERDIDOS ======
+//
+// Un jugador sentado afuera conserva su asiento y su stack pero no recibe
+// cartas. Si el big blind pasa por su asiento mientras está afuera, debe el
+// big blind (vivo) y el small blind (muerto); si le tocaba el small blind, debe
+// el small blind. Al volver los paga en la primera mano, salvo que esa mano
+// ya le toque poner un blind.
+
+// SitOut sienta afuera al jugador a partir de la próxima mano.
+// Si está jugando una mano, pasa o se retira cuando le toque actuar.
+func (pe *PokerEngine) SitOut(tableID, playerID string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if table.Players[seat].SittingOut {
+		return nil, fmt.Errorf("el jugador ya está sentado afuera")
+	}
+
+	pe.sitOut(table, seat)
+	return table, nil
+}
+
+// SitIn vuelve a sentar al jugador en juego desde la próxima mano
+func (pe *PokerEngine) SitIn(tableID, playerID string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	player := &table.Players[seat]
+	if player.Leaving {
+		return nil, fmt.Errorf("el jugador está dejando la mesa")
+	}
+	if !player.SittingOut {
+		return nil, fmt.Errorf("el jugador no está sentado afuera")
+	}
+
+	player.SittingOut = false
+	player.SittingOutSince = time.Time{}
+	player.IsConnected = true
+	return table, nil
+}
+
+// sitOut marca al jugador como sentado afuera y actúa por él si le toca
+func (pe *PokerEngine) sitOut(table *PokerTable, seat int) {
+	player := &table.Players[seat]
+	player.SittingOut = true
+	player.SittingOutSince = time.Now()
+
+	// Si hay un run it twice pendiente, lo corre una sola vez
+	if table.Phase == PhaseRunItTwice {
+		pe.voteForAbsentPlayers(table)
+		return
+	}
+
+	if awaitingAction(table) && table.CurrentPlayer == seat {
+		pe.resetShotClock(table)
+	}
+}
+
+// actForSittingOut pasa o retira a los jugadores sentados afuera a los que les toca actuar
+func (pe *PokerEngine) actForSittingOut(table *PokerTable) {
+	for i := 0; i < len(table.Players) && awaitingAction(table); i++ {
+		if !table.Players[table.CurrentPlayer].SittingOut {
+			return
+		}
+		pe.autoAct(table)
+	}
+}
+
+// trackMissedBlinds anota los blinds que perdieron los jugadores sentados afuera
+// cuando el big blind pasó de previousBigBlind al big blind de esta mano
+func (pe *PokerEngine) trackMissedBlinds(table *PokerTable, previousBigBlind int) {
+	if !isValidSeat(table, previousBigBlind) || !isValidSeat(table, table.BigBlindSeat) {
+		return
+	}
+
+	seats := len(table.Players)
+	for seat := (previousBigBlind + 1) % seats; seat != table.BigBlindSeat; seat = (seat + 1) % seats {
+		if missesBlinds(table.Players[seat]) {
+			table.Players[seat].OwedBlinds = table.SmallBlind + table.BigBlind
+		}
+	}
+
+	if isValidSeat(table, table.SmallBlindSeat) && missesBlinds(table.Players[table.SmallBlindSeat]) {
+		player := &table.Players[table.SmallBlindSeat]
+		if player.OwedBlinds < table.SmallBlind {
+			player.OwedBlinds = table.SmallBlind
+		}
+	}
+}
+
+// missesBlinds indica si el asiento tiene un jugador con fichas que no juega la mano
+func missesBlinds(player PokerPlayer) bool {
+	return !isEmptySeat(player) && !player.IsActive && player.Stack > 0
+}
+
+// postOwedBlinds cobra los blinds perdidos a los jugadores que vuelven: el big
+// blind es vivo (cuenta para igualar) y el resto es dinero muerto
+func (pe *PokerEngine) postOwedBlinds(table *PokerTable, activePlayers []int) {
+	for _, seat := range activePlayers {
+		player := &table.Players[seat]
+		if player.OwedBlinds <= 0 {
+			continue
+		}
+
+		// Si esta mano ya pone un blind, no debe nada
+		owed := player.OwedBlinds
+		player.OwedBlinds = 0
+		if seat == table.SmallBlindSeat || seat == table.BigBlindSeat {
+			continue
+		}
+
+		if owed > player.Stack {
+			owed = player.Stack
+		}
+		live := 0
+		if owed >= table.BigBlind {
+			live = table.BigBlind
+		}
+
+		player.Stack -= owed
+		player.CurrentBet += live
+		player.TotalBet += owed - live
+		table.Pot += owed
+		pe.recordAction(table, seat, "missed_blinds", owed, 0)
+
+		if player.Stack == 0 {
+			player.IsAllIn = true
+			table.PlayersToAct[seat] = false
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package poker
+
+import (
+	"testing"
+	"time"
+)
+
+// foldToShowdown retira jugadores hasta que la mano termina
+func foldToShowdown(t *testing.T, engine *PokerEngine, table *PokerTable) {
+	t.Helper()
+	for table.Phase != "showdown" {
+		mustAct(t, engine, table, "fold", 0)
+	}
+}
+
+// TestSitOutSkipsPlayer verifica que un jugador sentado afuera conserve asiento y stack sin recibir cartas
+func TestSitOutSkipsPlayer(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "sit_out_skip", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	if _, err := engine.SitOut(table.ID, "seat2"); err != nil {
+		t.Fatalf("Unexpected error sitting out: %v", err)
+	}
+	if _, err := engine.SitOut(table.ID, "seat2"); err == nil {
+		t.Error("Sitting out twice should be rejected")
+	}
+
+	engine.startHand(table)
+	player := table.Players[2]
+	if player.IsActive || len(player.Cards) != 0 || player.Stack != 1000 {
+		t.Errorf("Sitting out player should keep the stack without cards: %+v", player)
+	}
+	if player.SittingOutSince.IsZero() || player.ID != "seat2" {
+		t.Error("Sitting out player should keep the seat and the time they left")
+	}
+	if table.DealerPosition == 2 || table.SmallBlindSeat == 2 || table.BigBlindSeat == 2 {
+		t.Error("Blinds should skip the sitting out player")
+	}
+}
+
+// TestSitOutMidHand verifica que el jugador que se sienta afuera pase o se retire cuando le toca
+func TestSitOutMidHand(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "sit_out_mid_hand", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2)
+	table.AutoRestart = false
+
+	// Button 1, small blind 2, big blind 0: UTG (1) enfrenta el big blind y se retira
+	engine.startHand(table)
+	engine.SitOut(table.ID, "seat1")
+	if !table.Players[1].HasFolded || table.CurrentPlayer != 2 {
+		t.Fatalf("Expected seat1 to fold and seat2 to act, got current %d", table.CurrentPlayer)
+	}
+
+	// El big blind se sienta afuera sin apuesta que igualar: pasa
+	engine.SitOut(table.ID, "seat0")
+	mustAct(t, engine, table, "call", 0)
+	if table.Phase != "flop" || table.Players[0].HasFolded {
+		t.Fatalf("Expected seat0 to check to the flop, got phase %s", table.Phase)
+	}
+	mustAct(t, engine, table, "check", 0)
+	if table.Phase != "turn" {
+		t.Errorf("Expected seat0 to check the flop, got phase %s", table.Phase)
+	}
+}
+
+// TestMissedBlindsOwedAndPosted verifica que se deban y se paguen los blinds perdidos
+func TestMissedBlindsOwedAndPosted(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "sit_out_missed_blinds", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2, 3)
+	table.AutoRestart = false
+
+	// Mano 1: button 1, small blind 2, big blind 3
+	engine.startHand(table)
+	foldToShowdown(t, engine, table)
+
+	// Mano 2: el big blind pasa de 3 a 1 salteando a seat0
+	engine.SitOut(table.ID, "seat0")
+	engine.startHand(table)
+	assertButton(t, table, 2, 3, 1)
+	if table.Players[0].OwedBlinds != 30 {
+		t.Fatalf("Expected seat0 to owe small and big blind, got %d", table.Players[0].OwedBlinds)
+	}
+	foldToShowdown(t, engine, table)
+
+	// Mano 3: vuelve fuera de los blinds y paga 20 vivos y 10 muertos
+	if _, err := engine.SitIn(table.ID, "seat0"); err != nil {
+		t.Fatalf("Unexpected error sitting in: %v", err)
+	}
+	engine.startHand(table)
+	player := table.Players[0]
+	if player.OwedBlinds != 0 || player.CurrentBet != 20 || player.TotalBet != 10 || player.Stack != 970 {
+		t.Errorf("Expected 20 live and 10 dead posted, got bet %d dead %d stack %d",
+			player.CurrentBet, player.TotalBet, player.Stack)
+	}
+	foldToShowdown(t, engine, table)
+
+	assertContains(t, table.HandHistories[2].Format(""), "seat0: posts small & big blinds 30")
+	if _, err := ReplayHand(table.HandHistories[2]); err != nil {
+		t.Errorf("Hand with missed blinds should replay: %v", err)
+	}
+	if totalChips(table) != 4000 {
+		t.Errorf("Chips not conserved: %d", totalChips(table))
+	}
+
+	if _, err := engine.SitIn(table.ID, "seat0"); err == nil {
+		t.Error("Sitting in without sitting out should be rejected")
+	}
+}
+
+// TestMissedSmallBlind verifica que el small blind muerto se deba y se pague como dinero muerto
+func TestMissedSmallBlind(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "sit_out_small_blind", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2, 3)
+	table.AutoRestart = false
+
+	// Mano 1: big blind 3. En la mano 2 le toca el small blind a seat3, que está afuera
+	engine.startHand(table)
+	foldToShowdown(t, engine, table)
+	engine.SitOut(table.ID, "seat3")
+	engine.startHand(table)
+	if table.SmallBlindSeat != 3 || table.Players[3].OwedBlinds != 10 {
+		t.Fatalf("Expected seat3 to owe the dead small blind, got %d", table.Players[3].OwedBlinds)
+	}
+	foldToShowdown(t, engine, table)
+
+	// Mano 3: vuelve en el button y pone 10 muertos
+	engine.SitIn(table.ID, "seat3")
+	engine.startHand(table)
+	player := table.Players[3]
+	if table.DealerPosition != 3 || player.TotalBet != 10 || player.CurrentBet != 0 || player.OwedBlinds != 0 {
+		t.Errorf("Expected a dead small blind on the button, got %+v", player)
+	}
+}
+
+// TestMissedBlindsReturnInBigBlind verifica que quien vuelve en el big blind no pague lo perdido
+func TestMissedBlindsReturnInBigBlind(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "sit_out_big_blind", seatConfig(6))
+	mustSeat(t, engine, table, 0, 1, 2, 3)
+	table.AutoRestart = false
+
+	engine.startHand(table)
+	foldToShowdown(t, engine, table)
+
+	// Desconectarse también sienta afuera
+	if err := engine.SetPlayerConnected(table.ID, "seat0", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !table.Players[0].SittingOut {
+		t.Fatal("Disconnected player should be sitting out")
+	}
+
+	// Manos 2 a 4: el big blind pasa por 1, 2 y 3; la deuda no se acumula
+	for hand := 2; hand <= 4; hand++ {
+		engine.startHand(table)
+		foldToShowdown(t, engine, table)
+	}
+	if table.Players[0].OwedBlinds != 30 {
+		t.Fatalf("Expected seat0 to owe 30, got %d", table.Players[0].OwedBlinds)
+	}
+
+	// Mano 5: vuelve justo en el big blind y solo pone el big blind
+	engine.SitIn(table.ID, "seat0")
+	engine.startHand(table)
+	if table.BigBlindSeat != 0 {
+		t.Fatalf("Expected seat0 in the big blind, got %d", table.BigBlindSeat)
+	}
+	if table.Players[0].OwedBlinds != 0 || table.Players[0].TotalBet != 0 || table.Players[0].CurrentBet != 20 {
+		t.Errorf("Player returning in the big blind should only post it: %+v", table.Players[0])
+	}
+}
+
+// TestSetPlayerConnectedWhileClockRuns verifica que una desconexión después de que
+// venció el shot clock vea la mesa que dejó el timer (correr con -race)
+func TestSetPlayerConnectedWhileClockRuns(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "sit_out_clock", shotClockConfig(time.Millisecond, 0), "seat0", "seat1")
+	table.AutoRestart = false
+
+	// El small blind no actúa a tiempo y el timer lo retira
+	engine.StartHand(table)
+	engine.mu.RLock()
+	idle, other := table.Players[table.CurrentPlayer].ID, table.Players[table.BigBlindSeat].ID
+	engine.mu.RUnlock()
+	time.Sleep(20 * time.Millisecond)
+
+	if err := engine.SetPlayerConnected(table.ID, other, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := engine.HeartbeatPlayer(table.ID, idle); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	if table.Phase != "showdown" || !table.Players[findPlayerSeat(table, other)].SittingOut {
+		t.Errorf("Expected the clock to finish the hand and %s to sit out, got phase %s", other, table.Phase)
+	}
+}
@@ -0,0 +1,116 @@
+package poker
+
+import "fmt"
+
+// ====== STRADDLE ======
+//
+// Un straddle es un blind voluntario de 2x el big blind que se pide antes de
+// repartir. El jugador que hace straddle actúa último preflop y el raise
+// mínimo pasa a ser el tamaño del straddle.
+
+// Modos de straddle de la mesa
+const (
+	StraddleOff         = "off"         // Sin straddle
+	StraddleUTG         = "utg"         // Solo el jugador a la izquierda del big blind
+	StraddleMississippi = "mississippi" // Cualquier jugador excepto los blinds
+)
+
+// normalizeStraddleMode retorna el modo por defecto (sin straddle) si no se especificó
+func normalizeStraddleMode(mode string) string {
+	if mode == "" {
+		return StraddleOff
+	}
+	return mode
+}
+
+// validateStraddleMode verifica que el modo de straddle sea soportado
+func validateStraddleMode(mode string) error {
+	switch mode {
+	case "", StraddleOff, StraddleUTG, StraddleMississippi:
+		return nil
+	default:
+		return fmt.Errorf("modo de straddle desconocido: %s", mode)
+	}
+}
+
+// RequestStraddle registra que el jugador quiere hacer straddle en la próxima mano.
+// Si al repartir no está en una posición válida (o no tiene fichas) el pedido se descarta.
+func (pe *PokerEngine) RequestStraddle(tableID, playerID string) (*PokerTable, error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	table, exists := pe.tables[tableID]
+	if !exists {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	if normalizeStraddleMode(table.Straddle) == StraddleOff {
+		return nil, fmt.Errorf("la mesa no permite straddle")
+	}
+
+	if table.Variant == VariantStud {
+		return nil, fmt.Errorf("straddle no disponible en stud")
+	}
+
+	seat := findPlayerSeat(table, playerID)
+	if seat == -1 {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	table.Players[seat].StraddleRequested = true
+	return table, nil
+}
+
+// postStraddle coloca el straddle pedido para esta mano, si hay uno válido.
+// Retorna el índice del jugador que hizo straddle o -1.
+func (pe *PokerEngine) postStraddle(table *PokerTable, activePlayers []int) int {
+	// Los pedidos valen solo para una mano
+	defer func() {
+		for i := range table.Players {
+			table.Players[i].StraddleRequested = false
+		}
+	}()
+
+	mode := normalizeStraddleMode(table.Straddle)
+	if mode == StraddleOff || len(activePlayers) < 3 {
+		return -1
+	}
+
+	sbPlayerIndex, bbPlayerIndex := pe.blindPositions(table, activePlayers)
+	amount := 2 * table.BigBlind
+
+	// Recorrer los asientos en orden de acción preflop desde UTG; en Mississippi vale cualquiera excepto los blinds
+	candidates := len(activePlayers) - 1
+	if mode == StraddleUTG {
+		candidates = 1
+	}
+	playerIndex := bbPlayerIndex
+	for offset := 1; offset <= candidates; offset++ {
+		playerIndex = nextActiveSeat(table, playerIndex)
+		if playerIndex == sbPlayerIndex || playerIndex == bbPlayerIndex {
+			continue
+		}
+		player := &table.Players[playerIndex]
+		if !player.StraddleRequested || player.IsAllIn || player.Stack < amount {
+			continue
+		}
+
+		player.Stack -= amount
+		player.CurrentBet = amount
+		if player.Stack == 0 {
+			// Straddle por todo el stack: queda all-in y no vuelve a actuar
+			player.IsAllIn = true
+			table.PlayersToAct[playerIndex] = false
+		}
+		table.Pot += amount
+		pe.recordAction(table, playerIndex, "straddle", amount, 0)
+
+		// El straddle es un raise a ciegas: sube la apuesta y el raise mínimo
+		table.CurrentBet = amount
+		table.LastRaiseSize = amount
+		table.RaiseCount++
+		return playerIndex
+	}
+
+	return -1
+}
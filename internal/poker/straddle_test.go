@@ -0,0 +1,183 @@
+package poker
+
+import (
+	"testing"
+)
+
+// straddleConfig es la configuración de una mesa 10/20 con el modo de straddle indicado.
+// Con alice, bob, carol y dave sentados, en la primera mano el dealer es bob (1),
+// carol (2) pone el small blind, dave (3) el big blind y alice (0) es UTG.
+func straddleConfig(mode string) TableConfig {
+	return TableConfig{
+		SmallBlind: 10, BigBlind: 20, Straddle: mode,
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000, IsCashGame: true,
+	}
+}
+
+// startStraddleHand inicia la mano y verifica las posiciones esperadas
+func startStraddleHand(t *testing.T, engine *PokerEngine, table *PokerTable) {
+	t.Helper()
+
+	if _, err := engine.StartGame(table.ID, "alice"); err != nil {
+		t.Fatalf("Error starting game: %v", err)
+	}
+	if table.DealerPosition != 1 {
+		t.Fatalf("Expected bob (1) on the button, got %d", table.DealerPosition)
+	}
+}
+
+// TestUTGStraddle verifica el straddle de UTG: sube la apuesta a 2x BB y actúa último
+func TestUTGStraddle(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "utg_straddle", straddleConfig(StraddleUTG), "alice", "bob", "carol", "dave")
+
+	if _, err := engine.RequestStraddle(table.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error requesting straddle: %v", err)
+	}
+	startStraddleHand(t, engine, table)
+
+	if table.Players[0].CurrentBet != 40 || table.CurrentBet != 40 {
+		t.Fatalf("Expected UTG straddle of 40, got player bet %d table bet %d",
+			table.Players[0].CurrentBet, table.CurrentBet)
+	}
+	if table.Pot != 10+20+40 {
+		t.Errorf("Expected pot 70, got %d", table.Pot)
+	}
+	if table.CurrentPlayer != 1 {
+		t.Fatalf("Expected the player after the straddle (1) to act first, got %d", table.CurrentPlayer)
+	}
+	for _, player := range table.Players {
+		if player.StraddleRequested {
+			t.Errorf("Straddle request of %s should be cleared after the deal", player.Name)
+		}
+	}
+
+	// El raise mínimo es el tamaño del straddle
+	if _, err := engine.PlayerAction(table.ID, "bob", "raise", 20); err == nil {
+		t.Error("Raise of 20 should be rejected over a straddle of 40")
+	}
+
+	// Todos igualan y el straddle tiene la opción al final
+	for _, id := range []string{"bob", "carol", "dave"} {
+		if _, err := engine.PlayerAction(table.ID, id, "call", 0); err != nil {
+			t.Fatalf("Unexpected error on %s call: %v", id, err)
+		}
+	}
+	if table.Phase != "preflop" || table.CurrentPlayer != 0 {
+		t.Fatalf("Straddler should act last preflop, got phase %s player %d", table.Phase, table.CurrentPlayer)
+	}
+	if _, err := engine.PlayerAction(table.ID, "alice", "check", 0); err != nil {
+		t.Fatalf("Straddler should be able to check their option: %v", err)
+	}
+	if table.Phase != "flop" {
+		t.Errorf("Expected flop after the straddler checks, got %s", table.Phase)
+	}
+}
+
+// TestUTGStraddleWrongSeat verifica que en modo UTG se ignore el pedido de otro asiento
+func TestUTGStraddleWrongSeat(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "utg_straddle_wrong_seat", straddleConfig(StraddleUTG), "alice", "bob", "carol", "dave")
+
+	if _, err := engine.RequestStraddle(table.ID, "bob"); err != nil {
+		t.Fatalf("Unexpected error requesting straddle: %v", err)
+	}
+	startStraddleHand(t, engine, table)
+
+	if table.CurrentBet != 20 || table.Players[1].CurrentBet != 0 {
+		t.Errorf("Button cannot straddle in UTG mode, got table bet %d", table.CurrentBet)
+	}
+	if table.Players[1].StraddleRequested {
+		t.Error("Ignored straddle request should be cleared")
+	}
+}
+
+// TestMississippiStraddle verifica el straddle desde el button: actúa primero el small blind
+func TestMississippiStraddle(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "mississippi_straddle", straddleConfig(StraddleMississippi), "alice", "bob", "carol", "dave")
+
+	if _, err := engine.RequestStraddle(table.ID, "bob"); err != nil {
+		t.Fatalf("Unexpected error requesting straddle: %v", err)
+	}
+	startStraddleHand(t, engine, table)
+
+	if table.Players[1].CurrentBet != 40 || table.CurrentBet != 40 {
+		t.Fatalf("Expected button straddle of 40, got %d", table.Players[1].CurrentBet)
+	}
+	if table.CurrentPlayer != 2 {
+		t.Fatalf("Expected the small blind (2) to act first, got %d", table.CurrentPlayer)
+	}
+
+	for _, id := range []string{"carol", "dave", "alice"} {
+		if _, err := engine.PlayerAction(table.ID, id, "call", 0); err != nil {
+			t.Fatalf("Unexpected error on %s call: %v", id, err)
+		}
+	}
+	if table.CurrentPlayer != 1 || table.Phase != "preflop" {
+		t.Errorf("Straddler should act last preflop, got player %d phase %s", table.CurrentPlayer, table.Phase)
+	}
+}
+
+// TestStraddleNotAllowed verifica los casos donde no se permite straddle
+func TestStraddleNotAllowed(t *testing.T) {
+	engine := NewPokerEngine()
+
+	table := newTestTable(t, engine, "straddle_off", straddleConfig(""), "alice", "bob", "carol", "dave")
+	if _, err := engine.RequestStraddle(table.ID, "alice"); err == nil {
+		t.Error("Straddle should be rejected when the table does not allow it")
+	}
+
+	stud := mustCreateTable(t, engine, "straddle_stud", TableConfig{
+		Variant: VariantStud, SmallBlind: 10, BigBlind: 20, Straddle: StraddleMississippi,
+	})
+	engine.AddPlayer(stud.ID, "alice", "alice")
+	if _, err := engine.RequestStraddle(stud.ID, "alice"); err == nil {
+		t.Error("Straddle should be rejected in stud")
+	}
+
+	if err := engine.UpdateTableConfig(table.ID, TableConfig{
+		SmallBlind: 10, BigBlind: 20, Straddle: "double",
+		BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+	}); err == nil {
+		t.Error("Unknown straddle mode should be rejected")
+	}
+}
+
+// TestStraddleHeadsUpIgnored verifica que no haya straddle heads-up
+func TestStraddleHeadsUpIgnored(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "straddle_heads_up", straddleConfig(StraddleMississippi), "alice", "bob")
+
+	engine.RequestStraddle(table.ID, "alice")
+	engine.RequestStraddle(table.ID, "bob")
+	startTestGame(t, engine, table)
+
+	if table.CurrentBet != 20 {
+		t.Errorf("Heads-up straddle should be ignored, got table bet %d", table.CurrentBet)
+	}
+}
+
+// TestAllInStraddle verifica que un straddle por todo el stack no deje la ronda preflop esperando al straddler
+func TestAllInStraddle(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "all_in_straddle", straddleConfig(StraddleUTG), "alice", "bob", "carol", "dave")
+	table.Players[0].Stack = 40
+
+	if _, err := engine.RequestStraddle(table.ID, "alice"); err != nil {
+		t.Fatalf("Unexpected error requesting straddle: %v", err)
+	}
+	startStraddleHand(t, engine, table)
+
+	if !table.Players[0].IsAllIn || table.PlayersToAct[0] {
+		t.Fatalf("All-in straddler should not be left to act, got all-in %v to act %v",
+			table.Players[0].IsAllIn, table.PlayersToAct[0])
+	}
+
+	for i := 0; i < 3 && table.Phase == "preflop"; i++ {
+		mustAct(t, engine, table, "call", 0)
+	}
+	if table.Phase != "flop" {
+		t.Errorf("Expected the preflop round to close after the calls, got phase %s", table.Phase)
+	}
+}
@@ -0,0 +1,223 @@
+package poker
+
+// ====== SEVEN-CARD STUD ======
+//
+// Calles: third_street (2 tapadas + 1 descubierta), fourth, fifth y sixth street
+// (1 descubierta cada una) y seventh_street (1 tapada). Cada jugador pone ante,
+// la carta descubierta más baja paga el bring-in y desde fourth street actúa
+// primero la mejor mano visible.
+
+// Orden de palos para desempatar el bring-in (clubs es el más bajo)
+var studSuitOrder = map[string]int{
+	"clubs":    0,
+	"diamonds": 1,
+	"hearts":   2,
+	"spades":   3,
+}
+
+// startStudHand reparte third street, cobra antes y bring-in y define el primer jugador
+func (pe *PokerEngine) startStudHand(table *PokerTable, activePlayers []int) {
+	table.Phase = "third_street"
+	table.CurrentBet = 0
+
+	// Avanzar dealer position (solo referencia para desempates)
+	table.DealerPosition = nextActiveSeat(table, table.DealerPosition)
+
+	pe.postAntes(table, activePlayers)
+
+	// 2 cartas tapadas y 1 descubierta por jugador
+	for round := 0; round < 2; round++ {
+		for _, playerIndex := range activePlayers {
+			pe.dealStudCard(table, playerIndex, false)
+		}
+	}
+	for _, playerIndex := range activePlayers {
+		pe.dealStudCard(table, playerIndex, true)
+	}
+
+	// Los antes no cuentan como acción: todos deben actuar en third street
+	bringInIndex := pe.studBringInPlayer(table)
+	if bringInIndex == -1 {
+		pe.setFirstPlayerStud(table)
+		return
+	}
+
+	bringIn := table.BringIn
+	if bringIn <= 0 {
+		bringIn = table.SmallBlind
+	}
+	player := &table.Players[bringInIndex]
+	if player.Stack < bringIn {
+		bringIn = player.Stack
+	}
+	player.Stack -= bringIn
+	player.CurrentBet = bringIn
+	if player.Stack == 0 {
+		player.IsAllIn = true
+	}
+	table.Pot += bringIn
+	table.CurrentBet = bringIn
+	pe.recordAction(table, bringInIndex, "bring_in", bringIn, 0)
+
+	// Si nadie completa, la ronda termina cuando la acción vuelve al bring-in
+	table.PlayersToAct[bringInIndex] = false
+
+	// Actúa primero el jugador a la izquierda del bring-in
+	table.CurrentPlayer = bringInIndex
+	pe.nextPlayer(table)
+}
+
+// dealStudCard reparte una carta a un jugador, tapada o descubierta
+func (pe *PokerEngine) dealStudCard(table *PokerTable, playerIndex int, faceUp bool) {
+	if len(table.Deck) == 0 {
+		return
+	}
+
+	card := table.Deck[0]
+	table.Deck = table.Deck[1:]
+
+	if faceUp {
+		table.Players[playerIndex].UpCards = append(table.Players[playerIndex].UpCards, card)
+	} else {
+		table.Players[playerIndex].Cards = append(table.Players[playerIndex].Cards, card)
+	}
+}
+
+// dealStudStreet reparte una carta a cada jugador que sigue en la mano
+func (pe *PokerEngine) dealStudStreet(table *PokerTable, faceUp bool) {
+	// Quemar una carta
+	if len(table.Deck) > 0 {
+		table.Deck = table.Deck[1:]
+	}
+
+	inHand := make([]int, 0, len(table.Players))
+	for i, player := range table.Players {
+		if player.IsActive && !player.HasFolded {
+			inHand = append(inHand, i)
+		}
+	}
+
+	// Si no alcanzan las cartas se reparte una única carta comunitaria descubierta
+	if len(table.Deck) < len(inHand) {
+		if len(table.Deck) > 0 {
+			table.CommunityCards = append(table.CommunityCards, table.Deck[0])
+			table.Deck = table.Deck[1:]
+		}
+		return
+	}
+
+	for _, playerIndex := range inHand {
+		pe.dealStudCard(table, playerIndex, faceUp)
+	}
+}
+
+// advanceStudStreet reparte la siguiente calle de stud o termina la mano
+func (pe *PokerEngine) advanceStudStreet(table *PokerTable) {
+	switch table.Phase {
+	case "third_street":
+		pe.dealStudStreet(table, true)
+		table.Phase = "fourth_street"
+	case "fourth_street":
+		pe.dealStudStreet(table, true)
+		table.Phase = "fifth_street"
+	case "fifth_street":
+		pe.dealStudStreet(table, true)
+		table.Phase = "sixth_street"
+	case "sixth_street":
+		// La última carta va tapada
+		pe.dealStudStreet(table, false)
+		table.Phase = "seventh_street"
+	case "seventh_street":
+		table.Phase = "showdown"
+		pe.completeHand(table)
+		return
+	}
+
+	pe.setFirstPlayerStud(table)
+}
+
+// studBringInPlayer retorna el jugador con la carta descubierta más baja (-1 si no hay)
+func (pe *PokerEngine) studBringInPlayer(table *PokerTable) int {
+	bringInIndex := -1
+	var lowest Card
+
+	for i, player := range table.Players {
+		if !player.IsActive || player.HasFolded || player.IsAllIn || len(player.UpCards) == 0 {
+			continue
+		}
+
+		card := player.UpCards[0]
+		if bringInIndex == -1 || studCardLess(card, lowest) {
+			bringInIndex = i
+			lowest = card
+		}
+	}
+
+	return bringInIndex
+}
+
+// studCardLess compara cartas para el bring-in: primero por valor y luego por palo
+func studCardLess(a, b Card) bool {
+	if CardValue(a.Rank) != CardValue(b.Rank) {
+		return CardValue(a.Rank) < CardValue(b.Rank)
+	}
+	return studSuitOrder[a.Suit] < studSuitOrder[b.Suit]
+}
+
+// setFirstPlayerStud hace actuar primero a la mejor mano visible (desde fourth street)
+// En caso de empate actúa primero el jugador más cercano a la izquierda del dealer
+func (pe *PokerEngine) setFirstPlayerStud(table *PokerTable) {
+	best := -1
+
+	for offset := 1; offset <= len(table.Players); offset++ {
+		i := (table.DealerPosition + offset) % len(table.Players)
+		player := table.Players[i]
+		if !player.IsActive || player.HasFolded || player.IsAllIn {
+			continue
+		}
+
+		if best == -1 || compareShowingCards(player.UpCards, table.Players[best].UpCards) > 0 {
+			best = i
+		}
+	}
+
+	if best != -1 {
+		table.CurrentPlayer = best
+	}
+}
+
+// compareShowingCards compara las cartas descubiertas de dos jugadores de stud
+func compareShowingCards(a, b []Card) int {
+	if result := CompareHands(evaluateFiveCards(a), evaluateFiveCards(b)); result != 0 {
+		return result
+	}
+
+	// Desempatar carta por carta de mayor a menor
+	aValues := sortedCardValues(a)
+	bValues := sortedCardValues(b)
+	for i := 0; i < len(aValues) && i < len(bValues); i++ {
+		if aValues[i] != bValues[i] {
+			if aValues[i] > bValues[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// sortedCardValues retorna los valores de las cartas ordenados de mayor a menor
+func sortedCardValues(cards []Card) []int {
+	values := make([]int, len(cards))
+	for i, card := range cards {
+		values[i] = CardValue(card.Rank)
+	}
+	for i := 0; i < len(values)-1; i++ {
+		for j := i + 1; j < len(values); j++ {
+			if values[i] < values[j] {
+				values[i], values[j] = values[j], values[i]
+			}
+		}
+	}
+	return values
+}
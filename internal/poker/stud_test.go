@@ -0,0 +1,180 @@
+package poker
+
+import (
+	"testing"
+)
+
+// studConfig es la configuración de las mesas de stud de los tests
+var studConfig = TableConfig{
+	Variant: VariantStud, SmallBlind: 5, BigBlind: 20, Ante: 5, BringIn: 5,
+	BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+}
+
+// TestStudThirdStreet verifica el reparto, los antes y el bring-in de third street
+func TestStudThirdStreet(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "stud_third", studConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	if table.Phase != "third_street" {
+		t.Fatalf("Expected third_street, got %s", table.Phase)
+	}
+
+	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if len(player.Cards) != 2 || len(player.UpCards) != 1 {
+			t.Errorf("Player %s has %d down / %d up cards, expected 2 / 1",
+				player.Name, len(player.Cards), len(player.UpCards))
+		}
+		if player.AnteBet != 5 {
+			t.Errorf("Player %s should have posted the ante, got %d", player.Name, player.AnteBet)
+		}
+	}
+
+	// 3 antes + bring-in
+	if table.Pot != 20 {
+		t.Errorf("Expected pot 20, got %d", table.Pot)
+	}
+
+	bringIn := engine.studBringInPlayer(table)
+	if table.Players[bringIn].CurrentBet != 5 || table.CurrentBet != 5 {
+		t.Errorf("Bring-in player should have bet 5, got %d (table bet %d)",
+			table.Players[bringIn].CurrentBet, table.CurrentBet)
+	}
+	if table.CurrentPlayer != (bringIn+1)%3 {
+		t.Errorf("Expected player %d to act after bring-in %d, got %d", (bringIn+1)%3, bringIn, table.CurrentPlayer)
+	}
+}
+
+// TestStudBringInTieBreak verifica que el palo desempate el bring-in
+func TestStudBringInTieBreak(t *testing.T) {
+	engine := NewPokerEngine()
+	table := &PokerTable{
+		Variant: VariantStud,
+		Players: []PokerPlayer{
+			{ID: "a", IsActive: true, UpCards: []Card{{Suit: "spades", Rank: "2"}}},
+			{ID: "b", IsActive: true, UpCards: []Card{{Suit: "clubs", Rank: "2"}}},
+			{ID: "c", IsActive: true, UpCards: []Card{{Suit: "hearts", Rank: "3"}}},
+		},
+	}
+
+	if got := engine.studBringInPlayer(table); got != 1 {
+		t.Errorf("Expected player 1 (2 of clubs) to bring in, got %d", got)
+	}
+}
+
+// TestStudCardVisibility verifica que las cartas descubiertas sean públicas y las tapadas privadas
+func TestStudCardVisibility(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "stud_visibility", studConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	filtered, err := engine.GetTableForPlayer(table.ID, "alice")
+	if err != nil {
+		t.Fatalf("Error getting filtered table: %v", err)
+	}
+
+	for i, player := range filtered.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if player.ID == "alice" {
+			continue
+		}
+		for _, card := range player.Cards {
+			if card.Suit != "hidden" {
+				t.Errorf("Down card of %s should be hidden, got %+v", player.Name, card)
+			}
+		}
+		if len(player.UpCards) != 1 || player.UpCards[0] != table.Players[i].UpCards[0] {
+			t.Errorf("Up cards of %s should be visible, got %+v", player.Name, player.UpCards)
+		}
+	}
+}
+
+// TestStudFirstToActByShowingHand verifica que actúe primero la mejor mano visible
+func TestStudFirstToActByShowingHand(t *testing.T) {
+	engine := NewPokerEngine()
+	table := &PokerTable{
+		Variant:        VariantStud,
+		DealerPosition: 0,
+		Players: []PokerPlayer{
+			{ID: "a", IsActive: true, UpCards: []Card{{Suit: "spades", Rank: "A"}, {Suit: "hearts", Rank: "K"}}},
+			{ID: "b", IsActive: true, UpCards: []Card{{Suit: "clubs", Rank: "4"}, {Suit: "diamonds", Rank: "4"}}},
+			{ID: "c", IsActive: true, UpCards: []Card{{Suit: "hearts", Rank: "A"}, {Suit: "clubs", Rank: "Q"}}},
+		},
+	}
+
+	engine.setFirstPlayerStud(table)
+	if table.CurrentPlayer != 1 {
+		t.Errorf("Expected player with open pair to act first, got %d", table.CurrentPlayer)
+	}
+
+	// Sin pares, A-K le gana a A-Q
+	table.Players[1].HasFolded = true
+	engine.setFirstPlayerStud(table)
+	if table.CurrentPlayer != 0 {
+		t.Errorf("Expected A-K showing to act first, got %d", table.CurrentPlayer)
+	}
+}
+
+// TestStudCompleteBringIn verifica que se pueda completar el bring-in hasta la apuesta mínima
+func TestStudCompleteBringIn(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "stud_complete", studConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	current := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerAction(table.ID, current.ID, "raise", 15); err != nil {
+		t.Fatalf("Completing the bring-in should be allowed: %v", err)
+	}
+	if table.CurrentBet != 20 {
+		t.Errorf("Expected current bet 20 after completion, got %d", table.CurrentBet)
+	}
+}
+
+// TestStudFullHand juega una mano completa hasta el showdown
+func TestStudFullHand(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "stud_full_hand", studConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+	table.AutoRestart = false
+
+	totalChips := 0
+	for _, player := range table.Players {
+		totalChips += playerInvestment(player) + player.Stack
+	}
+
+	for i := 0; i < 100 && table.Phase != "showdown"; i++ {
+		player := table.Players[table.CurrentPlayer]
+		action := "check"
+		if table.CurrentBet > player.CurrentBet {
+			action = "call"
+		}
+		if _, err := engine.PlayerAction(table.ID, player.ID, action, 0); err != nil {
+			t.Fatalf("Unexpected error on %s (%s, phase %s): %v", action, player.Name, table.Phase, err)
+		}
+	}
+
+	if table.Phase != "showdown" {
+		t.Fatalf("Expected showdown, got %s", table.Phase)
+	}
+
+	finalChips := 0
+	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if len(player.Cards) != 3 || len(player.UpCards) != 4 {
+			t.Errorf("Player %s has %d down / %d up cards, expected 3 / 4",
+				player.Name, len(player.Cards), len(player.UpCards))
+		}
+		finalChips += player.Stack
+	}
+
+	if finalChips != totalChips {
+		t.Errorf("Chips not conserved: started with %d, ended with %d", totalChips, finalChips)
+	}
+}
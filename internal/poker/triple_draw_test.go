@@ -0,0 +1,365 @@
+package poker
+
+import (
+	"testing"
+)
+
+// tripleDrawConfig es la configuración de las mesas de 2-7 triple draw de los tests
+var tripleDrawConfig = TableConfig{
+	Variant: VariantTripleDraw, SmallBlind: 10, BigBlind: 20,
+	BuyInAmount: 1000, MinBuyIn: 500, MaxBuyIn: 2000,
+}
+
+// lowballHand arma una mano de 5 cartas a partir de pares rango/palo
+func lowballHand(cards ...string) []Card {
+	hand := make([]Card, 0, len(cards)/2)
+	for i := 0; i+1 < len(cards); i += 2 {
+		hand = append(hand, Card{Rank: cards[i], Suit: cards[i+1]})
+	}
+	return hand
+}
+
+// TestDeuceToSevenEvaluation verifica el orden de manos en 2-7 lowball
+func TestDeuceToSevenEvaluation(t *testing.T) {
+	tests := []struct {
+		name   string
+		better []Card
+		worse  []Card
+	}{
+		{
+			name:   "7-5-4-3-2 es la mejor mano",
+			better: lowballHand("7", "hearts", "5", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"),
+			worse:  lowballHand("7", "hearts", "6", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"),
+		},
+		{
+			name:   "Se compara desde la carta más alta",
+			better: lowballHand("8", "hearts", "6", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"),
+			worse:  lowballHand("8", "hearts", "6", "clubs", "5", "diamonds", "3", "spades", "2", "hearts"),
+		},
+		{
+			name:   "El As es siempre alto",
+			better: lowballHand("K", "hearts", "Q", "clubs", "J", "diamonds", "9", "spades", "8", "hearts"),
+			worse:  lowballHand("A", "hearts", "5", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"),
+		},
+		{
+			name:   "Cualquier mano sin par le gana a un par",
+			better: lowballHand("K", "hearts", "Q", "clubs", "J", "diamonds", "9", "spades", "7", "hearts"),
+			worse:  lowballHand("2", "hearts", "2", "clubs", "3", "diamonds", "4", "spades", "5", "hearts"),
+		},
+		{
+			name:   "La escalera cuenta en contra",
+			better: lowballHand("Q", "hearts", "Q", "clubs", "J", "diamonds", "9", "spades", "7", "hearts"),
+			worse:  lowballHand("6", "hearts", "5", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"),
+		},
+		{
+			name:   "El color cuenta en contra",
+			better: lowballHand("K", "hearts", "K", "clubs", "K", "diamonds", "9", "spades", "7", "hearts"),
+			worse:  lowballHand("7", "hearts", "5", "hearts", "4", "hearts", "3", "hearts", "2", "hearts"),
+		},
+		{
+			name:   "Par más bajo gana",
+			better: lowballHand("3", "hearts", "3", "clubs", "K", "diamonds", "Q", "spades", "J", "hearts"),
+			worse:  lowballHand("4", "hearts", "4", "clubs", "7", "diamonds", "5", "spades", "2", "hearts"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := EvaluateDeuceToSevenLow(tt.better)
+			worse := EvaluateDeuceToSevenLow(tt.worse)
+			if CompareHands(better, worse) != 1 {
+				t.Errorf("Expected %s (%d) to beat %s (%d)",
+					better.RankName, better.Value, worse.RankName, worse.Value)
+			}
+		})
+	}
+}
+
+// TestDeuceToSevenWheelIsNotStraight verifica que A-2-3-4-5 no sea escalera
+func TestDeuceToSevenWheelIsNotStraight(t *testing.T) {
+	wheel := EvaluateDeuceToSevenLow(lowballHand("A", "hearts", "5", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"))
+	if wheel.Rank != HighCard {
+		t.Errorf("A-2-3-4-5 should be a high card hand in 2-7, got %s", wheel.RankName)
+	}
+	if wheel.RankName != "A-5-4-3-2 Low" {
+		t.Errorf("Expected 'A-5-4-3-2 Low', got %s", wheel.RankName)
+	}
+
+	straight := EvaluateDeuceToSevenLow(lowballHand("6", "hearts", "5", "clubs", "4", "diamonds", "3", "spades", "2", "hearts"))
+	if straight.Rank != Straight {
+		t.Errorf("6-5-4-3-2 should be a straight, got %s", straight.RankName)
+	}
+}
+
+// TestTripleDrawDeal verifica el reparto de 5 cartas y la primera ronda de apuestas
+func TestTripleDrawDeal(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "triple_draw_deal", tripleDrawConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	if table.Phase != "predraw" {
+		t.Fatalf("Expected predraw phase, got %s", table.Phase)
+	}
+	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if len(player.Cards) != 5 {
+			t.Errorf("Player %s has %d cards, expected 5", player.Name, len(player.Cards))
+		}
+	}
+	if len(table.Deck) != 52-15 {
+		t.Errorf("Expected %d cards in deck, got %d", 52-15, len(table.Deck))
+	}
+
+	// En una ronda de apuestas no se puede descartar
+	player := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerDraw(table.ID, player.ID, []int{0}); err == nil {
+		t.Error("Draw should be rejected during a betting round")
+	}
+}
+
+// completeTripleDrawBetting hace que todos igualen o pasen hasta terminar la ronda de apuestas
+func completeTripleDrawBetting(t *testing.T, engine *PokerEngine, table *PokerTable) {
+	t.Helper()
+
+	phase := table.Phase
+	for i := 0; i < 20 && table.Phase == phase; i++ {
+		player := table.Players[table.CurrentPlayer]
+		action := "check"
+		if table.CurrentBet > player.CurrentBet {
+			action = "call"
+		}
+		if _, err := engine.PlayerAction(table.ID, player.ID, action, 0); err != nil {
+			t.Fatalf("Unexpected error on %s (%s, phase %s): %v", action, player.Name, table.Phase, err)
+		}
+	}
+}
+
+// countTripleDrawCards cuenta todas las cartas de la mano: jugadores, deck y descartes
+func countTripleDrawCards(table *PokerTable) map[Card]int {
+	counts := make(map[Card]int)
+	for _, player := range table.Players {
+		for _, card := range player.Cards {
+			counts[card]++
+		}
+	}
+	for _, card := range table.Deck {
+		counts[card]++
+	}
+	for _, card := range table.Discards {
+		counts[card]++
+	}
+	return counts
+}
+
+// TestTripleDrawDiscard verifica el descarte, la pila de descartes y la validación de índices
+func TestTripleDrawDiscard(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "triple_draw_discard", tripleDrawConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+
+	completeTripleDrawBetting(t, engine, table)
+	if table.Phase != "first_draw" {
+		t.Fatalf("Expected first_draw, got %s", table.Phase)
+	}
+
+	// Durante el descarte no se puede apostar
+	current := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerAction(table.ID, current.ID, "check", 0); err == nil {
+		t.Error("Betting actions should be rejected during a draw round")
+	}
+
+	// Índices inválidos
+	if _, err := engine.PlayerDraw(table.ID, current.ID, []int{5}); err == nil {
+		t.Error("Out of range discard index should be rejected")
+	}
+	if _, err := engine.PlayerDraw(table.ID, current.ID, []int{1, 1}); err == nil {
+		t.Error("Repeated discard index should be rejected")
+	}
+
+	// Fuera de turno
+	other := table.Players[(table.CurrentPlayer+1)%len(table.Players)]
+	if _, err := engine.PlayerDraw(table.ID, other.ID, []int{0}); err == nil {
+		t.Error("Draw out of turn should be rejected")
+	}
+
+	currentIndex := table.CurrentPlayer
+	kept := table.Players[currentIndex].Cards[2:]
+	discarded := table.Players[currentIndex].Cards[:2]
+	deckBefore := len(table.Deck)
+
+	if _, err := engine.PlayerDraw(table.ID, current.ID, []int{0, 1}); err != nil {
+		t.Fatalf("Unexpected error drawing: %v", err)
+	}
+
+	player := table.Players[currentIndex]
+	if len(player.Cards) != 5 || player.LastDrawCount != 2 {
+		t.Errorf("Expected 5 cards after drawing 2, got %d cards (draw count %d)", len(player.Cards), player.LastDrawCount)
+	}
+	for i, card := range kept {
+		if player.Cards[i] != card {
+			t.Errorf("Kept card %d changed: expected %+v, got %+v", i, card, player.Cards[i])
+		}
+	}
+	if len(table.Deck) != deckBefore-2 {
+		t.Errorf("Expected %d cards in deck, got %d", deckBefore-2, len(table.Deck))
+	}
+	if len(table.Discards) != 2 || table.Discards[0] != discarded[0] || table.Discards[1] != discarded[1] {
+		t.Errorf("Discarded cards should be in the discard pile, got %+v", table.Discards)
+	}
+
+	// El mazo sigue completo y sin duplicados
+	counts := countTripleDrawCards(table)
+	if len(counts) != 52 {
+		t.Errorf("Expected 52 distinct cards between hands, deck and discards, got %d", len(counts))
+	}
+	for card, count := range counts {
+		if count != 1 {
+			t.Errorf("Card %+v appears %d times", card, count)
+		}
+	}
+}
+
+// TestTripleDrawReshufflesDiscards verifica que se rebarajen los descartes si el deck no alcanza
+func TestTripleDrawReshufflesDiscards(t *testing.T) {
+	engine := NewPokerEngine()
+	table := &PokerTable{
+		Variant:  VariantTripleDraw,
+		Deck:     lowballHand("9", "clubs"),
+		Discards: lowballHand("K", "clubs", "Q", "clubs", "J", "clubs"),
+	}
+	player := &PokerPlayer{Cards: lowballHand("2", "hearts", "3", "hearts", "4", "hearts", "5", "hearts", "7", "hearts")}
+
+	engine.replaceCards(table, player, map[int]bool{2: true, 3: true, 4: true})
+
+	if len(player.Cards) != 5 {
+		t.Fatalf("Expected 5 cards after drawing, got %d", len(player.Cards))
+	}
+	for _, card := range player.Cards[2:] {
+		if card.Suit != "clubs" {
+			t.Errorf("Player should not receive their own discards, got %+v", card)
+		}
+	}
+	if len(table.Discards) != 3 {
+		t.Errorf("Only the new discards should remain in the pile, got %d", len(table.Discards))
+	}
+	if len(table.Deck) != 1 {
+		t.Errorf("Expected 1 card left in deck, got %d", len(table.Deck))
+	}
+}
+
+// TestTripleDrawFullHand verifica una mano completa con tres descartes hasta el showdown
+func TestTripleDrawFullHand(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "triple_draw_full_hand", tripleDrawConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+	table.AutoRestart = false
+
+	totalChips := 0
+	for _, player := range table.Players {
+		totalChips += player.Stack + player.CurrentBet
+	}
+
+	expectedPhases := []string{
+		"predraw", "first_draw", "post_first_draw", "second_draw",
+		"post_second_draw", "third_draw", "post_third_draw", "showdown",
+	}
+
+	for step, phase := range expectedPhases {
+		if table.Phase != phase {
+			t.Fatalf("Step %d: expected phase %s, got %s", step, phase, table.Phase)
+		}
+		if phase == "showdown" {
+			break
+		}
+
+		if isDrawPhase(phase) {
+			for i := 0; i < len(table.Players) && table.Phase == phase; i++ {
+				player := table.Players[table.CurrentPlayer]
+				if _, err := engine.PlayerDraw(table.ID, player.ID, []int{0}); err != nil {
+					t.Fatalf("Unexpected error drawing (%s, phase %s): %v", player.Name, phase, err)
+				}
+			}
+		} else {
+			completeTripleDrawBetting(t, engine, table)
+		}
+	}
+
+	finalChips := 0
+	for _, player := range table.Players {
+		if isEmptySeat(player) {
+			continue
+		}
+		if len(player.Cards) != 5 {
+			t.Errorf("Player %s has %d cards, expected 5", player.Name, len(player.Cards))
+		}
+		finalChips += player.Stack
+	}
+	if finalChips != totalChips {
+		t.Errorf("Chips not conserved: started with %d, ended with %d", totalChips, finalChips)
+	}
+}
+
+// TestTripleDrawAllInStandsPat verifica que el jugador all-in quede servido y que
+// los demás descarten una sola vez por ronda
+func TestTripleDrawAllInStandsPat(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "triple_draw_all_in", tripleDrawConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+	table.AutoRestart = false
+
+	shortStack := table.CurrentPlayer
+	table.Players[shortStack].Stack = 100
+	mustAct(t, engine, table, "all_in", 0)
+	completeTripleDrawBetting(t, engine, table)
+
+	if table.Phase != "first_draw" {
+		t.Fatalf("Expected first_draw, got %s", table.Phase)
+	}
+	if table.PlayersToAct[shortStack] || table.CurrentPlayer == shortStack {
+		t.Fatalf("The all-in player should stand pat, got current player %d", table.CurrentPlayer)
+	}
+
+	first := table.Players[table.CurrentPlayer]
+	if _, err := engine.PlayerDraw(table.ID, first.ID, []int{0}); err != nil {
+		t.Fatalf("Unexpected error drawing: %v", err)
+	}
+	if _, err := engine.PlayerDraw(table.ID, first.ID, []int{0}); err == nil {
+		t.Fatal("A second draw in the same round should be rejected")
+	}
+
+	second := table.Players[table.CurrentPlayer]
+	if second.ID == first.ID || table.CurrentPlayer == shortStack {
+		t.Fatalf("Expected the other player with chips to draw, got %s", second.ID)
+	}
+	if _, err := engine.PlayerDraw(table.ID, second.ID, nil); err != nil {
+		t.Fatalf("Unexpected error standing pat: %v", err)
+	}
+	if table.Phase != "post_first_draw" {
+		t.Errorf("Expected post_first_draw after both players drew, got %s", table.Phase)
+	}
+}
+
+// TestTripleDrawEveryoneAllIn verifica que la mano llegue al showdown si nadie puede descartar ni apostar
+func TestTripleDrawEveryoneAllIn(t *testing.T) {
+	engine := NewPokerEngine()
+	table := newTestTable(t, engine, "triple_draw_everyone_all_in", tripleDrawConfig, "alice", "bob", "carol")
+	startTestGame(t, engine, table)
+	table.AutoRestart = false
+
+	for i := 0; i < 3 && table.Phase == "predraw"; i++ {
+		mustAct(t, engine, table, "all_in", 0)
+	}
+
+	if table.Phase != "showdown" {
+		t.Fatalf("Expected the hand to run out to showdown, got %s", table.Phase)
+	}
+	total := 0
+	for _, player := range table.Players {
+		total += player.Stack
+	}
+	if total != 3000 {
+		t.Errorf("Chips not conserved: expected 3000, got %d", total)
+	}
+}
@@ -0,0 +1,120 @@
+package poker
+
+import "fmt"
+
+// Variantes de juego soportadas por el engine
+const (
+	VariantHoldem     = "holdem"      // Texas Hold'em (2 cartas privadas)
+	VariantOmaha      = "omaha"       // Pot-limit Omaha (4 cartas privadas, exactamente 2 + 3 del board)
+	VariantShortDeck  = "short_deck"  // Short-deck (6+) Hold'em con deck de 36 cartas
+	VariantStud       = "stud"        // Seven-card stud con ante y bring-in, sin cartas comunitarias
+	VariantTripleDraw = "triple_draw" // 2-7 triple draw lowball con tres rondas de descarte
+)
+
+// normalizeVariant devuelve la variante por defecto si no se especificó ninguna
+func normalizeVariant(variant string) string {
+	if variant == "" {
+		return VariantHoldem
+	}
+	return variant
+}
+
+// validateVariant verifica que la variante sea conocida por el engine
+func validateVariant(variant string) error {
+	switch normalizeVariant(variant) {
+	case VariantHoldem, VariantOmaha, VariantShortDeck, VariantStud, VariantTripleDraw:
+		return nil
+	default:
+		return fmt.Errorf("variante de juego desconocida: %s", variant)
+	}
+}
+
+// holeCardCount retorna cuántas cartas privadas recibe cada jugador según la variante
+func holeCardCount(variant string) int {
+	switch variant {
+	case VariantOmaha:
+		return 4
+	case VariantStud:
+		return 3 // 2 cartas tapadas en third street + 1 en seventh street
+	case VariantTripleDraw:
+		return 5
+	default:
+		return 2
+	}
+}
+
+// maxPlayersForVariant retorna el máximo de jugadores que admite la variante
+func maxPlayersForVariant(variant string) int {
+	switch variant {
+	case VariantStud:
+		return 8 // 8 jugadores x 7 cartas es lo máximo que cubre un deck de 52
+	case VariantTripleDraw:
+		return 6 // Con más jugadores los descartes no alcanzan para reponer
+	default:
+		return 10
+	}
+}
+
+// deckRanks retorna los ranks que componen el deck de la variante
+func deckRanks(variant string) []string {
+	if variant == VariantShortDeck {
+		return []string{"6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+	}
+	return []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+}
+
+// hasShowdownHand indica si el jugador tiene suficientes cartas para evaluar su mano en el showdown
+// sobre el board indicado (con run it twice hay un board por runout)
+func hasShowdownHand(table *PokerTable, player PokerPlayer, board []Card) bool {
+	switch table.Variant {
+	case VariantStud:
+		return len(player.Cards)+len(player.UpCards)+len(board) >= 5
+	case VariantTripleDraw:
+		return len(player.Cards) == 5
+	}
+	return len(player.Cards) >= 2 && len(board) >= 5
+}
+
+// evaluatePlayerHand evalúa la mano de un jugador aplicando las reglas de la variante de la mesa
+func evaluatePlayerHand(table *PokerTable, player PokerPlayer) HandEvaluation {
+	return evaluatePlayerHandOnBoard(table, player, table.CommunityCards)
+}
+
+// showdownValue retorna el Value con el que compite la mano del jugador en el showdown.
+// Hold'em y stud se rankean con las tablas precalculadas (ver lookup.go); las demás
+// variantes, y las manos de menos de 5 cartas, con el evaluador de la variante.
+func showdownValue(table *PokerTable, player PokerPlayer, board []Card) int {
+	var cards []Card
+	switch table.Variant {
+	case VariantOmaha, VariantShortDeck, VariantTripleDraw:
+		return evaluatePlayerHandOnBoard(table, player, board).Value
+	case VariantStud:
+		cards = append(append(append(cards, player.Cards...), player.UpCards...), board...)
+	default:
+		cards = append(append(cards, player.Cards...), board...)
+	}
+	if value, err := lookupValue(cards); err == nil {
+		return value
+	}
+	return evaluatePlayerHandOnBoard(table, player, board).Value
+}
+
+// evaluatePlayerHandOnBoard es evaluatePlayerHand sobre un board específico (run it twice)
+func evaluatePlayerHandOnBoard(table *PokerTable, player PokerPlayer, board []Card) HandEvaluation {
+	switch table.Variant {
+	case VariantOmaha:
+		return EvaluateOmahaHand(player.Cards, board)
+	case VariantShortDeck:
+		return EvaluateShortDeckHand(player.Cards, board)
+	case VariantStud:
+		// En stud la mano son las cartas tapadas, las descubiertas y la comunitaria si hizo falta
+		cards := make([]Card, 0, len(player.UpCards)+len(board))
+		cards = append(cards, player.UpCards...)
+		cards = append(cards, board...)
+		return EvaluateHand(player.Cards, cards)
+	case VariantTripleDraw:
+		return EvaluateDeuceToSevenLow(player.Cards)
+	default:
+		return EvaluateHand(player.Cards, board)
+	}
+}
@@ -0,0 +1,6 @@
+package tournament
+
+// AdvanceBlindLevel sube el nivel de blinds sin esperar al timer (solo para tests)
+func (t *Tournament) AdvanceBlindLevel() {
+	t.advanceBlindLevel()
+}
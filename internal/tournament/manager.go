@@ -13,6 +13,7 @@ type Manager struct {
 	mu          sync.RWMutex
 	tournaments map[string]*Tournament
 	pokerEngine *poker.PokerEngine
+	onFinish    func(*Tournament)
 }
 
 // NewManager crea un nuevo manager de torneos
@@ -39,11 +40,20 @@ func (m *Manager) CreateTournament(id string, config TournamentConfig) (*Tournam
 	}
 
 	tournament := NewTournament(id, config, m.pokerEngine)
+	tournament.onFinish = m.onFinish
 	m.tournaments[id] = tournament
 
 	return tournament, nil
 }
 
+// SetFinishHandler registra una función que se llama cuando termina un torneo,
+// con los premios ya asignados y el torneo bloqueado
+func (m *Manager) SetFinishHandler(handler func(*Tournament)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onFinish = handler
+}
+
 // validateConfig valida la configuración del torneo
 func (m *Manager) validateConfig(config TournamentConfig) error {
 	if config.Name == "" {
@@ -91,6 +101,10 @@ func (m *Manager) validateConfig(config TournamentConfig) error {
 		if level.Duration <= 0 {
 			return fmt.Errorf("blind level %d: duration must be positive", i)
 		}
+
+		if level.Ante < 0 {
+			return fmt.Errorf("blind level %d: ante cannot be negative", i)
+		}
 	}
 
 	return nil
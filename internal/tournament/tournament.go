@@ -2,6 +2,7 @@ package tournament
 
 import (
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
@@ -75,11 +76,13 @@ type Tournament struct {
 	StartTime    time.Time           `json:"start_time"`
 	EndTime      *time.Time          `json:"end_time,omitempty"`
 	Winners      []string            `json:"winners,omitempty"`
+	Prizes       map[string]int      `json:"prizes,omitempty"` // Premio de cada jugador (por ID)
 	
 	// Control interno
 	pokerEngine    *poker.PokerEngine
 	levelTimer     *time.Timer
 	nextPlayerPos  int // Para tracking de posiciones finales
+	onFinish       func(*Tournament) // Aviso al terminar (premios asignados)
 }
 
 // NewTournament crea un nuevo torneo
@@ -231,8 +234,9 @@ func (t *Tournament) createInitialTables() error {
 		// Configurar blinds iniciales
 		if len(t.Config.BlindLevels) > 0 {
 			level := t.Config.BlindLevels[0]
-			pokerTable.SmallBlind = level.SmallBlind
-			pokerTable.BigBlind = level.BigBlind
+			if err := t.pokerEngine.SetBlindLevel(tableID, level.SmallBlind, level.BigBlind, level.Ante); err != nil {
+				return fmt.Errorf("failed to set blinds on table %s: %w", tableID, err)
+			}
 		}
 
 		// Agregar jugadores a la mesa de poker
@@ -290,8 +294,11 @@ func (t *Tournament) advanceBlindLevel() {
 		
 		for _, table := range t.Tables {
 			if table.IsActive && table.PokerTable != nil {
-				table.PokerTable.SmallBlind = level.SmallBlind
-				table.PokerTable.BigBlind = level.BigBlind
+				// Con el lock del engine: la mesa puede estar jugando una mano
+				if err := t.pokerEngine.SetBlindLevel(table.ID, level.SmallBlind, level.BigBlind, level.Ante); err != nil {
+					// Corre desde el timer: no hay a quién retornar el error
+					log.Printf("failed to set blinds on table %s: %v", table.ID, err)
+				}
 			}
 		}
 
@@ -328,7 +335,9 @@ func (t *Tournament) EliminatePlayer(playerID string) error {
 
 	// Verificar si es mesa final
 	if len(activePlayers) <= t.Config.MaxTablesSize && t.Status != StatusFinalTable {
-		t.createFinalTable(activePlayers)
+		if err := t.createFinalTable(activePlayers); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -346,7 +355,7 @@ func (t *Tournament) getActivePlayers() []string {
 }
 
 // createFinalTable crea la mesa final
-func (t *Tournament) createFinalTable(activePlayers []string) {
+func (t *Tournament) createFinalTable(activePlayers []string) error {
 	t.Status = StatusFinalTable
 
 	// Desactivar todas las mesas actuales
@@ -361,8 +370,9 @@ func (t *Tournament) createFinalTable(activePlayers []string) {
 	// Configurar blinds actuales
 	if t.CurrentLevel < len(t.Config.BlindLevels) {
 		level := t.Config.BlindLevels[t.CurrentLevel]
-		pokerTable.SmallBlind = level.SmallBlind
-		pokerTable.BigBlind = level.BigBlind
+		if err := t.pokerEngine.SetBlindLevel(finalTableID, level.SmallBlind, level.BigBlind, level.Ante); err != nil {
+			return fmt.Errorf("failed to set blinds on table %s: %w", finalTableID, err)
+		}
 	}
 
 	// Mover jugadores a mesa final
@@ -380,6 +390,7 @@ func (t *Tournament) createFinalTable(activePlayers []string) {
 	}
 
 	t.Tables[finalTableID] = finalTable
+	return nil
 }
 
 // finishTournament termina el torneo
@@ -395,9 +406,12 @@ func (t *Tournament) finishTournament(winners []string) {
 	}
 
 	// Distribuir premios (lógica básica - 100% al ganador)
-	if len(winners) > 0 {
-		// En una implementación real, aquí se distribuirían los premios
-		// según una estructura de payout
+	if len(winners) > 0 && t.PrizePool > 0 {
+		t.Prizes = map[string]int{winners[0]: t.PrizePool}
+	}
+
+	if t.onFinish != nil {
+		t.onFinish(t)
 	}
 }
 
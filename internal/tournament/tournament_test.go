@@ -2,6 +2,7 @@ package tournament_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/poker"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/tournament"
@@ -161,4 +162,114 @@ func TestManager_ListTournaments(t *testing.T) {
 	if _, exists := tournaments["t2"]; !exists {
 		t.Errorf("tournament t2 not found in list")
 	}
-}
\ No newline at end of file
+}
+
+func TestTournament_AntesPushedToTables(t *testing.T) {
+	pokerEngine := poker.NewPokerEngine()
+	manager := tournament.NewManager(pokerEngine)
+
+	config := tournament.TournamentConfig{
+		Name:          "Ante Tournament",
+		BuyIn:         100,
+		StartingStack: 1500,
+		MaxPlayers:    9,
+		MinPlayers:    2,
+		MaxTablesSize: 9,
+		BlindLevels: []tournament.BlindLevel{
+			{Level: 1, SmallBlind: 10, BigBlind: 20, Ante: 5, Duration: time.Hour},
+			{Level: 2, SmallBlind: 20, BigBlind: 40, Ante: 10, Duration: time.Minute},
+		},
+	}
+
+	tourney, err := manager.CreateTournament("antes", config)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+	tourney.RegisterPlayer("player1", "Alice")
+	tourney.RegisterPlayer("player2", "Bob")
+
+	if err := tourney.StartTournament(); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	for _, table := range tourney.GetActiveTables() {
+		if table.PokerTable.Ante != 5 {
+			t.Errorf("expected table %s to start with ante 5, got %d", table.ID, table.PokerTable.Ante)
+		}
+	}
+
+	tourney.AdvanceBlindLevel()
+
+	for _, table := range tourney.GetActiveTables() {
+		if table.PokerTable.Ante != 10 || table.PokerTable.BigBlind != 40 {
+			t.Errorf("expected table %s at level 2 (BB 40, ante 10), got BB %d ante %d",
+				table.ID, table.PokerTable.BigBlind, table.PokerTable.Ante)
+		}
+	}
+}
+
+func TestManager_RejectsNegativeAnte(t *testing.T) {
+	pokerEngine := poker.NewPokerEngine()
+	manager := tournament.NewManager(pokerEngine)
+
+	config := tournament.TournamentConfig{
+		Name:          "Bad Ante",
+		BuyIn:         100,
+		StartingStack: 1500,
+		MaxPlayers:    9,
+		MinPlayers:    2,
+		MaxTablesSize: 9,
+		BlindLevels: []tournament.BlindLevel{
+			{Level: 1, SmallBlind: 10, BigBlind: 20, Ante: -5, Duration: time.Minute},
+		},
+	}
+
+	if _, err := manager.CreateTournament("bad_ante", config); err == nil {
+		t.Errorf("expected error for negative ante")
+	}
+}
+
+func TestTournament_PrizeToWinner(t *testing.T) {
+	pokerEngine := poker.NewPokerEngine()
+	manager := tournament.NewManager(pokerEngine)
+
+	var finished *tournament.Tournament
+	manager.SetFinishHandler(func(tourney *tournament.Tournament) { finished = tourney })
+
+	config := tournament.TournamentConfig{
+		Name:              "Prize Tournament",
+		BuyIn:             100,
+		StartingStack:     1500,
+		MaxPlayers:        9,
+		MinPlayers:        2,
+		MaxTablesSize:     9,
+		RegistrationDelay: time.Hour,
+		BlindLevels: []tournament.BlindLevel{
+			{Level: 1, SmallBlind: 10, BigBlind: 20, Duration: time.Hour},
+		},
+	}
+
+	tourney, err := manager.CreateTournament("prizes", config)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+	tourney.RegisterPlayer("player1", "Alice")
+	tourney.RegisterPlayer("player2", "Bob")
+	tourney.RegisterPlayer("player3", "Carol")
+	if err := tourney.StartTournament(); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	tourney.EliminatePlayer("player3")
+	if finished != nil {
+		t.Fatal("tournament should not finish with two players left")
+	}
+	tourney.EliminatePlayer("player2")
+
+	if finished != tourney || tourney.GetStatus() != tournament.StatusFinished {
+		t.Fatal("expected the finish handler to be called")
+	}
+	if len(tourney.Prizes) != 1 || tourney.Prizes["player1"] != 300 {
+		t.Errorf("expected the whole prize pool for player1, got %v", tourney.Prizes)
+	}
+}
@@ -1,12 +1,14 @@
 package ws
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/game"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 )
@@ -43,6 +45,7 @@ func (c *Connection) readPump() {
 	defer func() {
 		c.hub.Unregister(c.channel, c)
 		c.ws.Close()
+		c.handleDisconnect()
 	}()
 
 	// Configure websocket
@@ -105,6 +108,24 @@ func (c *Connection) handleMessage(msgType MessageType, payload InboundPayload)
 		c.handlePokerAction(payload)
 	case TypeGetState:
 		c.handleGetState()
+	case TypeHandHistory:
+		c.handleHandHistory(payload)
+	case TypeStackDeck:
+		c.handleStackDeck(payload)
+	case TypeClientSeed:
+		c.handleClientSeed(payload)
+	case TypeSitOut:
+		c.handleSitOut(payload)
+	case TypeSitIn:
+		c.handleSitIn(payload)
+	case TypeLeaveTable:
+		c.handleLeaveTable(payload)
+	case TypeRebuy:
+		c.handleRebuy(payload)
+	case TypeBalance:
+		c.handleBalance(payload)
+	case TypeSettlement:
+		c.handleSettlement(payload)
 	case TypeTournamentCreate:
 		c.handleTournamentCreate(payload)
 	case TypeTournamentRegister:
@@ -121,6 +142,10 @@ func (c *Connection) handleMessage(msgType MessageType, payload InboundPayload)
 		c.handleStartGame(payload)
 	case TypeReadyStatus:
 		c.handleReadyStatus()
+	case TypeTakeSeat:
+		c.handleTakeSeat(payload)
+	case TypeChangeSeat:
+		c.handleChangeSeat(payload)
 	default:
 		log.Printf("⚠️ Unhandled message type: %s", msgType)
 	}
@@ -157,6 +182,59 @@ func (c *Connection) handleJoin(payload InboundPayload) {
 	})
 }
 
+func (c *Connection) handleTakeSeat(payload InboundPayload) {
+	log.Printf("🪑 Player %s taking seat %d on table %s", payload.Player, payload.Seat, c.channel)
+
+	state, err := c.hub.mgr.JoinAtSeat(c.channel, payload.Player, payload.Seat, payload.BuyInAmount)
+	if err != nil {
+		log.Printf("⚠️ Take seat failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	// Asignar nombre del jugador a esta conexión
+	c.playerName = payload.Player
+	c.broadcastSeatUpdate(state)
+}
+
+func (c *Connection) handleChangeSeat(payload InboundPayload) {
+	log.Printf("🪑 Player %s moving to seat %d on table %s", payload.Player, payload.Seat, c.channel)
+
+	state, err := c.hub.mgr.ChangeSeat(c.channel, payload.Player, payload.Seat)
+	if err != nil {
+		log.Printf("⚠️ Change seat failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	c.broadcastSeatUpdate(state)
+}
+
+// broadcastSeatUpdate envía a cada jugador el estado filtrado después de un cambio de asientos
+func (c *Connection) broadcastSeatUpdate(state *game.TableState) {
+	c.hub.BroadcastPersonalized(c.channel, func(conn *Connection) []byte {
+		if conn.playerName == "" {
+			return nil // Conexión sin jugador asignado
+		}
+
+		filteredState, err := c.hub.mgr.GetTableStateForPlayer(c.channel, conn.playerName)
+		if err != nil {
+			log.Printf("❌ Failed to get filtered state for %s: %v", conn.playerName, err)
+			filteredState = state // fallback al estado sin filtrar
+		}
+
+		out, err := PackOutbound(TypeUpdate, 1, OutboundPayload{State: filteredState})
+		if err != nil {
+			log.Printf("❌ Failed to pack seat update for %s: %v", conn.playerName, err)
+			return nil
+		}
+
+		return out
+	})
+}
+
 func (c *Connection) handleBet(payload InboundPayload) {
 	log.Printf("💰 Player %s betting %d on table %s", payload.Player, payload.Amount, c.channel)
 
@@ -277,7 +355,26 @@ func ServeWS(hub *Hub) http.HandlerFunc {
 func (c *Connection) handlePokerAction(payload InboundPayload) {
 	log.Printf("🎮 Player %s action %s on table %s", payload.Player, payload.Action, c.channel)
 
-	state, err := c.hub.mgr.PokerAction(c.channel, payload.Player, payload.Action, payload.Amount)
+	var state *game.TableState
+	var err error
+	switch payload.Action {
+	case "draw":
+		state, err = c.hub.mgr.DrawCards(c.channel, payload.Player, payload.Discards)
+	case "straddle":
+		// Acción previa al reparto: se aplica en la próxima mano
+		state, err = c.hub.mgr.RequestStraddle(c.channel, payload.Player)
+	case "run_it_twice":
+		// Consentimiento para correr el board varias veces con todos all-in
+		state, err = c.hub.mgr.VoteRunItTwice(c.channel, payload.Player, payload.Runs)
+	case "show":
+		// Mostrar las cartas después del showdown (mano tirada o pot sin disputa)
+		state, err = c.hub.mgr.ShowCards(c.channel, payload.Player)
+	case "time_bank":
+		// Extender el shot clock: el nuevo plazo viaja en el estado de la mesa
+		state, err = c.hub.mgr.UseTimeBank(c.channel, payload.Player)
+	default:
+		state, err = c.hub.mgr.PokerAction(c.channel, payload.Player, payload.Action, payload.Amount)
+	}
 	if err != nil {
 		log.Printf("⚠️ Poker action failed: %v", err)
 		errMsg, _ := CreateErrorMessage(err.Error())
@@ -306,6 +403,26 @@ func (c *Connection) handlePokerAction(payload InboundPayload) {
 
 		return out
 	})
+
+	// El straddle, el show y el time bank no terminan la mano: no repetir el resultado de la anterior
+	if payload.Action != "straddle" && payload.Action != "show" && payload.Action != "time_bank" {
+		c.broadcastHandResult(state)
+	}
+}
+
+// broadcastHandResult anuncia el resultado del showdown cuando la acción terminó la mano
+func (c *Connection) broadcastHandResult(state *game.TableState) {
+	if state.PokerTable == nil || state.PokerTable.HandResult == nil || state.PokerTable.Phase != "showdown" {
+		return
+	}
+
+	out, err := CreateHandResultMessage(state)
+	if err != nil {
+		log.Printf("❌ Failed to pack hand result: %v", err)
+		return
+	}
+
+	c.hub.Broadcast(c.channel, out)
 }
 
 func (c *Connection) handleGetState() {
@@ -330,6 +447,206 @@ func (c *Connection) handleGetState() {
 	c.send(out)
 }
 
+// handleHandHistory envía al jugador el historial de las manos que jugó en la mesa
+func (c *Connection) handleHandHistory(payload InboundPayload) {
+	log.Printf("📜 Player %s requesting hand history on table %s", payload.Player, c.channel)
+
+	history, err := c.hub.mgr.GetHandHistory(c.channel, payload.Player)
+	if err != nil {
+		log.Printf("⚠️ Get hand history failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	out, err := PackOutbound(TypeHandHistory, 1, OutboundPayload{
+		HandHistory: history,
+		Message:     "Hand history",
+	})
+	if err != nil {
+		log.Printf("❌ Failed to pack hand history response: %v", err)
+		errMsg, _ := CreateErrorMessage("Internal server error")
+		c.send(errMsg)
+		return
+	}
+
+	c.send(out)
+}
+
+// handleStackDeck carga un deck programado para la próxima mano de la mesa
+func (c *Connection) handleStackDeck(payload InboundPayload) {
+	log.Printf("🃏 Stacking %d cards for the next hand on table %s", len(payload.Cards), c.channel)
+
+	if err := c.hub.mgr.StackDeck(c.channel, payload.Cards); err != nil {
+		log.Printf("⚠️ Stack deck failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	out, err := PackOutbound(TypeStackDeck, 1, OutboundPayload{
+		Message: "Deck stacked for the next hand",
+	})
+	if err != nil {
+		log.Printf("❌ Failed to pack stack deck response: %v", err)
+		errMsg, _ := CreateErrorMessage("Internal server error")
+		c.send(errMsg)
+		return
+	}
+
+	c.send(out)
+}
+
+// handleClientSeed guarda la semilla del jugador y avisa a la mesa (las client seeds son públicas)
+func (c *Connection) handleClientSeed(payload InboundPayload) {
+	log.Printf("🎲 Player %s setting client seed on table %s", payload.Player, c.channel)
+
+	state, err := c.hub.mgr.SetClientSeed(c.channel, payload.Player, payload.Seed)
+	if err != nil {
+		log.Printf("⚠️ Set client seed failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	c.broadcastSeatUpdate(state)
+}
+
+// handleSitOut deja al jugador sentado afuera conservando asiento y stack
+func (c *Connection) handleSitOut(payload InboundPayload) {
+	log.Printf("💤 Player %s sitting out on table %s", payload.Player, c.channel)
+
+	if _, err := c.hub.mgr.SitOut(c.channel, payload.Player); err != nil {
+		log.Printf("⚠️ Sit out failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	// Si le tocaba actuar, la mano avanzó: anunciar estado y resultado
+	c.hub.broadcastTableUpdate(c.channel)
+}
+
+// handleSitIn vuelve a sentar al jugador en juego desde la próxima mano
+func (c *Connection) handleSitIn(payload InboundPayload) {
+	log.Printf("🪑 Player %s sitting in on table %s", payload.Player, c.channel)
+
+	state, err := c.hub.mgr.SitIn(c.channel, payload.Player)
+	if err != nil {
+		log.Printf("⚠️ Sit in failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	c.broadcastSeatUpdate(state)
+}
+
+// handleLeaveTable saca al jugador de la mesa; el cash out se anuncia al liberarse el asiento
+func (c *Connection) handleLeaveTable(payload InboundPayload) {
+	log.Printf("🚪 Player %s leaving table %s", payload.Player, c.channel)
+
+	if _, _, err := c.hub.mgr.LeaveTable(c.channel, payload.Player); err != nil {
+		log.Printf("⚠️ Leave table failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	// Si le tocaba actuar, se retiró y la mano pudo terminar
+	c.hub.broadcastTableUpdate(c.channel)
+}
+
+// handleRebuy compra más fichas para el jugador y avisa a la mesa
+func (c *Connection) handleRebuy(payload InboundPayload) {
+	log.Printf("💰 Player %s rebuying %d on table %s", payload.Player, payload.Amount, c.channel)
+
+	state, err := c.hub.mgr.Rebuy(c.channel, payload.Player, payload.Amount)
+	if err != nil {
+		log.Printf("⚠️ Rebuy failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	c.broadcastSeatUpdate(state)
+}
+
+// handleBalance responde el saldo del jugador en el ledger
+func (c *Connection) handleBalance(payload InboundPayload) {
+	balance, err := c.hub.mgr.GetBalance(payload.Player)
+	if err != nil {
+		log.Printf("⚠️ Get balance failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	out, err := PackOutbound(TypeBalance, 1, OutboundPayload{
+		Balance: &balance,
+		Message: "Balance",
+	})
+	if err != nil {
+		log.Printf("❌ Failed to pack balance response: %v", err)
+		errMsg, _ := CreateErrorMessage("Internal server error")
+		c.send(errMsg)
+		return
+	}
+
+	c.send(out)
+}
+
+// handleSettlement responde la liquidación de la sesión de la mesa, exportada si se pidió un formato
+func (c *Connection) handleSettlement(payload InboundPayload) {
+	log.Printf("🧾 Settlement requested on table %s", c.channel)
+
+	settlement, err := c.hub.mgr.Settle(c.channel)
+	if err != nil {
+		log.Printf("⚠️ Settlement failed: %v", err)
+		errMsg, _ := CreateErrorMessage(err.Error())
+		c.send(errMsg)
+		return
+	}
+
+	response := OutboundPayload{Settlement: settlement, Message: "Settlement"}
+	switch payload.Format {
+	case "json":
+		data, err := settlement.JSON()
+		if err == nil {
+			response.Export = string(data)
+		}
+	case "csv":
+		var buf bytes.Buffer
+		if err := settlement.WriteCSV(&buf); err == nil {
+			response.Export = buf.String()
+		}
+	}
+
+	out, err := PackOutbound(TypeSettlement, 1, response)
+	if err != nil {
+		log.Printf("❌ Failed to pack settlement response: %v", err)
+		errMsg, _ := CreateErrorMessage("Internal server error")
+		c.send(errMsg)
+		return
+	}
+
+	c.send(out)
+}
+
+// handleDisconnect sienta afuera al jugador de la conexión que se cerró
+func (c *Connection) handleDisconnect() {
+	if c.playerName == "" {
+		return
+	}
+
+	if _, err := c.hub.mgr.SitOut(c.channel, c.playerName); err != nil {
+		return // Ya estaba afuera o no está sentado en la mesa
+	}
+
+	log.Printf("🔌 Player %s disconnected: sitting out on table %s", c.playerName, c.channel)
+	c.hub.broadcastTableUpdate(c.channel)
+}
+
 func (c *Connection) handleTournamentCreate(payload InboundPayload) {
 	log.Printf("🏆 Creating tournament %s: %s", payload.TournamentID, payload.TournamentName)
 
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/game"
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/poker"
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/store"
 )
 
@@ -17,12 +18,16 @@ type Hub struct {
 }
 
 func NewHub(s store.Store, m game.Manager) *Hub {
-	return &Hub{
+	h := &Hub{
 		store:      s,
 		mgr:        m,
 		clients:    make(map[string]map[*Connection]bool),
 		subscribed: make(map[string]bool),
 	}
+	// Los cambios que hace el engine sin un mensaje (shot clock, auto-restart) también se anuncian
+	m.OnTableUpdate(h.broadcastTableUpdate)
+	m.OnCashOut(h.broadcastCashOut)
+	return h
 }
 
 func (h *Hub) Register(channel string, c *Connection) {
@@ -96,3 +101,51 @@ func (h *Hub) BroadcastPersonalized(channel string, getPersonalizedMessage func(
 	}
 	h.mu.RUnlock()
 }
+
+// broadcastTableUpdate envía a cada jugador el estado filtrado de una mesa que
+// cambió sin un mensaje de por medio, y el resultado si la mano terminó
+func (h *Hub) broadcastTableUpdate(channel string) {
+	state, err := h.mgr.GetTableState(channel)
+	if err != nil {
+		log.Printf("⚠️ Table update for %s failed: %v", channel, err)
+		return
+	}
+
+	h.BroadcastPersonalized(channel, func(conn *Connection) []byte {
+		if conn.playerName == "" {
+			return nil
+		}
+
+		filteredState, err := h.mgr.GetTableStateForPlayer(channel, conn.playerName)
+		if err != nil {
+			log.Printf("❌ Failed to get filtered state for %s: %v", conn.playerName, err)
+			filteredState = state // fallback
+		}
+
+		out, err := CreatePokerUpdate(filteredState)
+		if err != nil {
+			log.Printf("❌ Failed to pack poker update for %s: %v", conn.playerName, err)
+			return nil
+		}
+		return out
+	})
+
+	if state.PokerTable != nil && state.PokerTable.HandResult != nil && state.PokerTable.Phase == "showdown" {
+		out, err := CreateHandResultMessage(state)
+		if err != nil {
+			log.Printf("❌ Failed to pack hand result: %v", err)
+			return
+		}
+		h.Broadcast(channel, out)
+	}
+}
+
+// broadcastCashOut anuncia a la mesa el stack con el que un jugador la dejó
+func (h *Hub) broadcastCashOut(cashOut poker.CashOut) {
+	out, err := CreateCashOutMessage(cashOut)
+	if err != nil {
+		log.Printf("❌ Failed to pack cash out: %v", err)
+		return
+	}
+	h.Broadcast(cashOut.TableID, out)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/game"
+	"github.com/Blind-Ledger/blind-ledger-core-backend/internal/poker"
 )
 
 // MessageType define los tipos de mensaje
@@ -22,6 +23,17 @@ const (
 	TypePokerAction MessageType = "poker_action"
 	TypePokerUpdate MessageType = "poker_update"
 	TypeGetState    MessageType = "get_state"
+	TypeHandResult  MessageType = "hand_result" // Reparto de pots al terminar la mano
+	TypeHandHistory MessageType = "hand_history" // Historial de manos en formato PokerStars
+	TypeStackDeck   MessageType = "stack_deck"   // Deck programado para la próxima mano (solo servidores de QA)
+	TypeClientSeed  MessageType = "client_seed"  // Semilla del jugador para el barajado provably fair
+	TypeSitOut      MessageType = "sit_out"      // Conservar el asiento sin recibir cartas
+	TypeSitIn       MessageType = "sit_in"       // Volver a jugar desde la próxima mano
+	TypeLeaveTable  MessageType = "leave_table"  // Dejar la mesa llevándose el stack
+	TypeCashOut     MessageType = "cash_out"     // Un jugador dejó la mesa: stack final y buy-in
+	TypeRebuy       MessageType = "rebuy"        // Recompra o top-up entre manos (amount)
+	TypeBalance     MessageType = "balance"      // Saldo del jugador en el ledger de fichas
+	TypeSettlement  MessageType = "settlement"   // Resultados de la sesión y quién le paga a quién
 
 	// Mensajes para lobby/ready system
 	TypeSetReady    MessageType = "set_ready"
@@ -41,6 +53,10 @@ const (
 	TypeGetTableConfig   MessageType = "get_table_config"
 	TypeUpdateTableConfig MessageType = "update_table_config"
 	TypeValidateBuyIn    MessageType = "validate_buy_in"
+
+	// Mensajes para asientos
+	TypeTakeSeat   MessageType = "take_seat"
+	TypeChangeSeat MessageType = "change_seat"
 )
 
 // InboundPayload para mensajes de entrada
@@ -49,7 +65,12 @@ type InboundPayload struct {
 	Amount int    `json:"amount,omitempty"`
 
 	// Nuevos campos para poker
-	Action string `json:"action,omitempty"` // fold, call, raise, all_in
+	Action   string `json:"action,omitempty"`   // fold, call, raise, all_in, draw, straddle, run_it_twice, show, time_bank
+	Discards []int  `json:"discards,omitempty"` // Índices de las cartas a descartar (acción draw)
+	Runs     int    `json:"runs,omitempty"`     // Veces a correr el board (acción run_it_twice, 1 = rechazar)
+	Cards    []poker.Card `json:"cards,omitempty"` // Cartas de arriba del deck en orden de reparto (stack_deck)
+	Seed     string       `json:"seed,omitempty"`  // Client seed que se mezcla en el barajado (client_seed)
+	Format   string       `json:"format,omitempty"` // Formato de exportación de la liquidación: json o csv (settlement)
 
 	// Campos para lobby/ready system
 	Ready bool `json:"ready,omitempty"` // true/false para set_ready
@@ -68,6 +89,9 @@ type InboundPayload struct {
 	MaxBuyIn     int  `json:"max_buy_in,omitempty"`     // Buy-in máximo permitido
 	IsCashGame   bool `json:"is_cash_game,omitempty"`   // true = cash game, false = torneo
 	AutoRestart  bool `json:"auto_restart,omitempty"`   // Si las manos se reinician automáticamente
+
+	// Campos para asientos
+	Seat int `json:"seat"` // Número de asiento (desde 0) para take_seat y change_seat
 }
 
 // Validate valida el payload según el tipo de mensaje
@@ -102,12 +126,20 @@ func (p InboundPayload) Validate(msgType MessageType) error {
 
 		// Validar acciones específicas
 		switch p.Action {
-		case "fold", "call", "all_in":
-			// Estas acciones no requieren amount
+		case "fold", "call", "all_in", "straddle", "show", "time_bank":
+			// Estas acciones no requieren amount (straddle es siempre 2x el big blind)
 		case "raise":
 			if p.Amount <= 0 {
 				return fmt.Errorf("raise amount must be positive")
 			}
+		case "draw":
+			if len(p.Discards) > 5 {
+				return fmt.Errorf("cannot discard more than 5 cards")
+			}
+		case "run_it_twice":
+			if p.Runs < 1 {
+				return fmt.Errorf("runs must be at least 1")
+			}
 		default:
 			return fmt.Errorf("invalid poker action: %s", p.Action)
 		}
@@ -115,6 +147,47 @@ func (p InboundPayload) Validate(msgType MessageType) error {
 	case TypeDistribute, TypeGetState:
 		// No requieren validación especial
 
+	case TypeHandHistory:
+		if p.Player == "" {
+			return fmt.Errorf("player name is required for hand_history")
+		}
+
+	case TypeStackDeck:
+		if len(p.Cards) == 0 {
+			return fmt.Errorf("cards are required for stack_deck")
+		}
+		if len(p.Cards) > 52 {
+			return fmt.Errorf("cannot stack more than 52 cards")
+		}
+
+	case TypeClientSeed:
+		if p.Player == "" {
+			return fmt.Errorf("player name is required for client_seed")
+		}
+		if p.Seed == "" || len(p.Seed) > 64 {
+			return fmt.Errorf("seed must be between 1 and 64 chars")
+		}
+
+	case TypeSitOut, TypeSitIn, TypeLeaveTable, TypeBalance:
+		if p.Player == "" {
+			return fmt.Errorf("player name is required for %s", msgType)
+		}
+
+	case TypeRebuy:
+		if p.Player == "" {
+			return fmt.Errorf("player name is required for rebuy")
+		}
+		if p.Amount <= 0 {
+			return fmt.Errorf("rebuy amount must be positive")
+		}
+
+	case TypeSettlement:
+		switch p.Format {
+		case "", "json", "csv":
+		default:
+			return fmt.Errorf("invalid settlement format: %s", p.Format)
+		}
+
 	case TypeSetReady:
 		if p.Player == "" {
 			return fmt.Errorf("player name is required for set_ready")
@@ -191,6 +264,20 @@ func (p InboundPayload) Validate(msgType MessageType) error {
 			return fmt.Errorf("buy_in_amount must be positive")
 		}
 
+	case TypeTakeSeat, TypeChangeSeat:
+		if p.Player == "" {
+			return fmt.Errorf("player name is required for %s", msgType)
+		}
+		if len(p.Player) > 50 {
+			return fmt.Errorf("player name too long (max 50 chars)")
+		}
+		if p.Seat < 0 || p.Seat >= 10 {
+			return fmt.Errorf("seat must be between 0 and 9")
+		}
+		if p.BuyInAmount < 0 {
+			return fmt.Errorf("buy_in_amount cannot be negative")
+		}
+
 	default:
 		return fmt.Errorf("unknown message type: %s", msgType)
 	}
@@ -207,6 +294,12 @@ type OutboundPayload struct {
 	PlayerTurn  string `json:"player_turn,omitempty"`
 	GamePhase   string `json:"game_phase,omitempty"`
 	ActionValid bool   `json:"action_valid,omitempty"`
+	HandResult  interface{} `json:"hand_result,omitempty"` // Ganadores, manos y montos de cada side pot
+	HandHistory string      `json:"hand_history,omitempty"` // Manos del jugador en formato de texto PokerStars
+	CashOut     interface{} `json:"cash_out,omitempty"`     // Stack final y buy-in del jugador que dejó la mesa
+	Balance     *int        `json:"balance,omitempty"`      // Saldo del jugador en el ledger (negativo = debe)
+	Settlement  interface{} `json:"settlement,omitempty"`   // Resultados de la sesión y pagos entre jugadores
+	Export      string      `json:"export,omitempty"`       // Liquidación exportada en el formato pedido
 
 	// Información para lobby/ready system
 	ReadyStatus map[string]bool `json:"ready_status,omitempty"`
@@ -272,11 +365,13 @@ func UnpackInbound(raw []byte) (MessageType, json.RawMessage, error) {
 
 	// Validar tipo de mensaje
 	switch env.Type {
-	case TypeJoin, TypeBet, TypeDistribute, TypePokerAction, TypeGetState,
+	case TypeJoin, TypeBet, TypeDistribute, TypePokerAction, TypeGetState, TypeHandHistory, TypeStackDeck, TypeClientSeed,
+		 TypeSitOut, TypeSitIn, TypeLeaveTable, TypeRebuy, TypeBalance, TypeSettlement,
 		 TypeSetReady, TypeStartGame, TypeReadyStatus,
 		 TypeTournamentCreate, TypeTournamentRegister, TypeTournamentStart,
 		 TypeTournamentInfo, TypeTournamentList,
-		 TypeJoinWithBuyIn, TypeGetTableConfig, TypeUpdateTableConfig, TypeValidateBuyIn:
+		 TypeJoinWithBuyIn, TypeGetTableConfig, TypeUpdateTableConfig, TypeValidateBuyIn,
+		 TypeTakeSeat, TypeChangeSeat:
 		// Tipos válidos
 	default:
 		return "", nil, fmt.Errorf("unknown message type: %s", env.Type)
@@ -322,6 +417,21 @@ func CreatePokerUpdate(state *game.TableState) ([]byte, error) {
 	return PackOutbound(TypePokerUpdate, 1, payload)
 }
 
+// CreateHandResultMessage crea el mensaje con el resultado del showdown
+func CreateHandResultMessage(state *game.TableState) ([]byte, error) {
+	payload := OutboundPayload{
+		HandResult: state.PokerTable.HandResult,
+		GamePhase:  state.PokerTable.Phase,
+	}
+
+	return PackOutbound(TypeHandResult, 1, payload)
+}
+
+// CreateCashOutMessage crea el mensaje con el cash out de un jugador que dejó la mesa
+func CreateCashOutMessage(cashOut poker.CashOut) ([]byte, error) {
+	return PackOutbound(TypeCashOut, 1, OutboundPayload{CashOut: cashOut})
+}
+
 // getCurrentTimestamp returns current Unix timestamp
 func getCurrentTimestamp() int64 {
 	return int64(1000) // Placeholder - implementar tiempo real